@@ -6,7 +6,15 @@ SPDX-License-Identifier: Apache-2.0
 package main
 
 import (
+	"context"
+
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
 	"knative.dev/eventing/pkg/adapter/v2"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	"knative.dev/pkg/injection"
+	"knative.dev/pkg/injection/clients/dynamicclient"
+	"knative.dev/pkg/signals"
 
 	myadapter "github.com/vmware-tanzu/sources-for-knative/pkg/horizon"
 )
@@ -16,5 +24,11 @@ const (
 )
 
 func main() {
-	adapter.Main(adapterName, myadapter.NewEnv, myadapter.NewAdapter)
+	ctx := signals.NewContext()
+	cfg := injection.ParseAndGetRESTConfigOrDie()
+	ctx = context.WithValue(ctx, kubeclient.Key{}, kubernetes.NewForConfigOrDie(cfg))
+	// used to report the delivery status
+	ctx = context.WithValue(ctx, dynamicclient.Key{}, dynamic.NewForConfigOrDie(cfg))
+
+	adapter.MainWithContext(ctx, adapterName, myadapter.NewEnv, myadapter.NewAdapter)
 }
@@ -7,10 +7,12 @@ package main
 
 import (
 	"context"
+	"os"
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
+	"knative.dev/pkg/injection"
 	"knative.dev/pkg/injection/sharedmain"
 	"knative.dev/pkg/logging"
 	"knative.dev/pkg/metrics"
@@ -18,16 +20,19 @@ import (
 	"knative.dev/pkg/webhook"
 	"knative.dev/pkg/webhook/certificates"
 	"knative.dev/pkg/webhook/configmaps"
+	"knative.dev/pkg/webhook/psbinding"
 	"knative.dev/pkg/webhook/resourcesemantics"
 	"knative.dev/pkg/webhook/resourcesemantics/defaulting"
 	"knative.dev/pkg/webhook/resourcesemantics/validation"
 
 	"github.com/vmware-tanzu/sources-for-knative/pkg/apis/sources/v1alpha1"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/reconciler/horizonbinding"
 )
 
 var types = map[schema.GroupVersionKind]resourcesemantics.GenericCRD{
 	// List the types to validate
-	v1alpha1.SchemeGroupVersion.WithKind("HorizonSource"): &v1alpha1.HorizonSource{},
+	v1alpha1.SchemeGroupVersion.WithKind("HorizonSource"):  &v1alpha1.HorizonSource{},
+	v1alpha1.SchemeGroupVersion.WithKind("HorizonBinding"): &v1alpha1.HorizonBinding{},
 }
 
 var callbacks = map[schema.GroupVersionKind]validation.Callback{}
@@ -105,6 +110,31 @@ func NewConfigValidationController(ctx context.Context, cmw configmap.Watcher) *
 	)
 }
 
+// NewHorizonBindingWebhook sets up the mutating webhook for HorizonBinding
+// subjects.
+func NewHorizonBindingWebhook(opts ...psbinding.ReconcilerOption) injection.ControllerConstructor {
+	return func(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
+		return psbinding.NewAdmissionController(ctx,
+			// Name of the resource webhook.
+			"horizonbindings.webhook.horizon.sources.tanzu.vmware.com",
+
+			// The path on which to serve the webhook.
+			"/horizonbindings",
+
+			// How to get all the Bindables for configuring the mutating webhook.
+			horizonbinding.ListAll,
+
+			// A function that infuses the context passed to Validate/SetDefaults with custom metadata.
+			func(ctx context.Context, _ psbinding.Bindable) (context.Context, error) {
+				// Here is where you would infuse the context with state
+				// (e.g. attach a store with configmap data)
+				return ctx, nil
+			},
+			opts...,
+		)
+	}
+}
+
 func main() {
 	// Set up a signal context with our webhook options
 	ctx := webhook.WithOptions(signals.NewContext(), webhook.Options{
@@ -113,10 +143,18 @@ func main() {
 		SecretName:  "webhook-certs",
 	})
 
+	hbSelector := psbinding.WithSelector(psbinding.ExclusionSelector)
+	if os.Getenv("HORIZON_BINDING_SELECTION_MODE") == "inclusion" {
+		hbSelector = psbinding.WithSelector(psbinding.InclusionSelector)
+	}
+
 	sharedmain.WebhookMainWithContext(ctx, admissionWebhookName,
 		certificates.NewController,
 		NewDefaultingAdmissionController,
 		NewValidationAdmissionController,
 		NewConfigValidationController,
+
+		// For each binding we have a controller and a binding webhook.
+		horizonbinding.NewController, NewHorizonBindingWebhook(hbSelector),
 	)
 }
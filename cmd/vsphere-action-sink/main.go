@@ -0,0 +1,79 @@
+/*
+Copyright 2020 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/kelseyhightower/envconfig"
+	"github.com/vmware/govmomi/vapi/rest"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/signals"
+
+	"github.com/vmware-tanzu/sources-for-knative/pkg/apis/sources/v1alpha1"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/vsphere"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/vsphere/actions"
+)
+
+const (
+	component = "vsphere-action-sink"
+)
+
+type envConfig struct {
+	// Actions is the JSON encoded list of actions to perform.
+	Actions string `envconfig:"VSPHERE_ACTIONS" required:"true"`
+
+	LoggingConfig string `envconfig:"K_LOGGING_CONFIG" default:""`
+}
+
+func main() {
+	ctx := signals.NewContext()
+
+	var env envConfig
+	if err := envconfig.Process("", &env); err != nil {
+		log.Fatalf("Unable to read environment config: %v", err)
+	}
+
+	loggingConfig, err := logging.JSONToConfig(env.LoggingConfig)
+	if err != nil {
+		log.Printf("Error parsing logging configuration: %v", err)
+	}
+	logger, _ := logging.NewLoggerFromConfig(loggingConfig, component)
+	defer logger.Sync()
+	ctx = logging.WithLogger(ctx, logger)
+
+	var acts []v1alpha1.VSphereAction
+	if err := json.Unmarshal([]byte(env.Actions), &acts); err != nil {
+		logger.Fatalf("Unable to parse actions: %v", err)
+	}
+
+	vClient, err := vsphere.NewSOAPClient(ctx)
+	if err != nil {
+		logger.Fatalf("Unable to create vSphere client: %v", err)
+	}
+	defer vClient.Logout(context.Background()) // using fresh context to avoid canceled err
+
+	var rc *rest.Client
+	if actions.NeedsREST(acts) {
+		if rc, err = vsphere.NewRESTClient(ctx); err != nil {
+			logger.Fatalf("Unable to create vSphere REST client: %v", err)
+		}
+		defer rc.Logout(context.Background())
+	}
+
+	ceClient, err := cloudevents.NewClientHTTP()
+	if err != nil {
+		logger.Fatalf("Unable to create CloudEvents client: %v", err)
+	}
+
+	e := actions.NewExecutor(vClient.Client, rc, vClient.URL().String(), acts)
+	if err := ceClient.StartReceiver(ctx, e.Handle); err != nil {
+		logger.Fatalf("Unable to receive CloudEvents: %v", err)
+	}
+}
@@ -11,10 +11,12 @@ import (
 	// Uncomment if you want to run locally against remote GKE cluster.
 	// _ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"knative.dev/eventing/pkg/adapter/v2"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	"knative.dev/pkg/injection"
+	"knative.dev/pkg/injection/clients/dynamicclient"
 	"knative.dev/pkg/signals"
 
 	"github.com/vmware-tanzu/sources-for-knative/pkg/vsphere"
@@ -26,7 +28,9 @@ const (
 
 func main() {
 	ctx := signals.NewContext()
-	kc := kubernetes.NewForConfigOrDie(injection.ParseAndGetRESTConfigOrDie())
-	ctx = context.WithValue(ctx, kubeclient.Key{}, kc)
+	cfg := injection.ParseAndGetRESTConfigOrDie()
+	ctx = context.WithValue(ctx, kubeclient.Key{}, kubernetes.NewForConfigOrDie(cfg))
+	// used by the status checkpoint store
+	ctx = context.WithValue(ctx, dynamicclient.Key{}, dynamic.NewForConfigOrDie(cfg))
 	adapter.MainWithContext(ctx, adapterName, vsphere.NewEnvConfig, vsphere.NewAdapter)
 }
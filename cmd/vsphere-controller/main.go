@@ -26,6 +26,7 @@ import (
 	"knative.dev/pkg/webhook/resourcesemantics/validation"
 
 	"github.com/vmware-tanzu/sources-for-knative/pkg/apis/sources/v1alpha1"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/reconciler/vsphereactionsink"
 	"github.com/vmware-tanzu/sources-for-knative/pkg/reconciler/vspherebinding"
 	"github.com/vmware-tanzu/sources-for-knative/pkg/reconciler/vspheresource"
 )
@@ -34,6 +35,8 @@ var types = map[schema.GroupVersionKind]resourcesemantics.GenericCRD{
 	// List the types to validate.
 	v1alpha1.SchemeGroupVersion.WithKind("VSphereSource"):  &v1alpha1.VSphereSource{},
 	v1alpha1.SchemeGroupVersion.WithKind("VSphereBinding"): &v1alpha1.VSphereBinding{},
+
+	v1alpha1.SchemeGroupVersion.WithKind("VSphereActionSink"): &v1alpha1.VSphereActionSink{},
 }
 
 const (
@@ -149,7 +152,8 @@ func main() {
 		// For each binding we have a controller and a binding webhook.
 		vspherebinding.NewController, NewVSphereBindingWebhook(vsbSelector),
 
-		// Also run our source controller here.
+		// Also run our source and action sink controllers here.
 		vspheresource.NewController,
+		vsphereactionsink.NewController,
 	)
 }
@@ -0,0 +1,63 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"knative.dev/pkg/apis"
+
+	"github.com/vmware-tanzu/sources-for-knative/pkg/cemapping"
+)
+
+// CloudEventMapping configures the CloudEvent type, source and subject of the
+// events produced by a source with Go templates. Unspecified templates use the
+// default mapping of the source.
+type CloudEventMapping struct {
+	// Type is the template of the CloudEvent type. It has access to the
+	// event .Type, .Class, .Entity and .EntityType, and to the lower and
+	// upper functions.
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// Source is the template of the CloudEvent source URI. It has access to
+	// the .Address of the server and the .InstanceUUID of the vCenter.
+	// +optional
+	Source string `json:"source,omitempty"`
+
+	// Subject is the template of the CloudEvent subject, which is not set if
+	// it renders empty. It has access to the same data as Type and defaults
+	// to the ID of the primary entity of the event, e.g. the virtual machine
+	// or the Horizon machine.
+	// +optional
+	Subject string `json:"subject,omitempty"`
+}
+
+// Config returns the mapping of the adapter, the default mapping if m is nil.
+func (m *CloudEventMapping) Config() cemapping.Config {
+	if m == nil {
+		return cemapping.Config{}
+	}
+	return cemapping.Config{
+		Type:    m.Type,
+		Source:  m.Source,
+		Subject: m.Subject,
+	}
+}
+
+// Validate implements apis.Validatable
+func (m *CloudEventMapping) Validate(_ context.Context) (err *apis.FieldError) {
+	if e := cemapping.ValidateTypeTemplate(m.Type); e != nil {
+		err = err.Also(apis.ErrInvalidValue(m.Type, "type", e.Error()))
+	}
+	if e := cemapping.ValidateSourceTemplate(m.Source); e != nil {
+		err = err.Also(apis.ErrInvalidValue(m.Source, "source", e.Error()))
+	}
+	if e := cemapping.ValidateEventTemplate(m.Subject); e != nil {
+		err = err.Also(apis.ErrInvalidValue(m.Subject, "subject", e.Error()))
+	}
+	return err
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/sources-for-knative/pkg/delivery"
+)
+
+// DefaultMaxEventLag is the maximum event lag used when maxEventLagSeconds is
+// not specified.
+const DefaultMaxEventLag = 5 * time.Minute
+
+// EventDeliveryStatus is reported by a source adapter and describes the
+// delivery of events to the sink.
+type EventDeliveryStatus struct {
+	// LastEventTime is the creation time of the last event delivered to the
+	// sink.
+	// +optional
+	LastEventTime *metav1.Time `json:"lastEventTime,omitempty"`
+
+	// LastSentTime is the time the last event was delivered to the sink.
+	// +optional
+	LastSentTime *metav1.Time `json:"lastSentTime,omitempty"`
+
+	// LastPollTime is the time the adapter last read events from its
+	// source, whether or not there were new events. It is reported at least
+	// every 30 seconds while the adapter is reading.
+	// +optional
+	LastPollTime *metav1.Time `json:"lastPollTime,omitempty"`
+
+	// EventLag is the delay between the creation and the delivery of the
+	// last event which was read as it was created, i.e. not replayed from a
+	// checkpoint or backfilled.
+	// +optional
+	EventLag *metav1.Duration `json:"eventLag,omitempty"`
+
+	// EventsSentTotal is the number of events delivered to the sink.
+	// +optional
+	EventsSentTotal int64 `json:"eventsSentTotal,omitempty"`
+
+	// LastError is the error of the last failed delivery. It is cleared
+	// once an event is delivered again.
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+}
+
+// MaxEventLag returns the configured maximum event lag or DefaultMaxEventLag.
+// The reconcilers check the EventsFlowing condition again after this time.
+func MaxEventLag(seconds int64) time.Duration {
+	if seconds <= 0 {
+		return DefaultMaxEventLag
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// heartbeatTolerance is the delay of a reported poll time on top of the
+// maximum event lag before the adapter is considered stalled
+const heartbeatTolerance = 2 * delivery.HeartbeatPeriod
+
+// eventsFlowing returns the status, reason and message of the EventsFlowing
+// condition for the given delivery status at time now. Events are not flowing
+// if the last live event was delivered more than maxLag after its creation, or
+// if the adapter did not read events for more than maxLag, e.g. because it
+// stalled. A source without new events keeps flowing as long as its adapter
+// reads.
+func (eds *EventDeliveryStatus) eventsFlowing(now time.Time, maxLag time.Duration) (corev1.ConditionStatus, string, string) {
+	switch {
+	case eds.LastError != "":
+		return corev1.ConditionFalse, "DeliveryFailing", eds.LastError
+	case eds.LastPollTime == nil:
+		return corev1.ConditionUnknown, "NotPolled", "the adapter has not read events yet"
+	}
+
+	if eds.EventLag != nil && eds.EventLag.Duration > maxLag {
+		return corev1.ConditionFalse, "EventsLagging",
+			fmt.Sprintf("last event was delivered %s after its creation (max %s)", eds.EventLag.Round(time.Second), maxLag)
+	}
+	if idle := now.Sub(eds.LastPollTime.Time); idle > maxLag+heartbeatTolerance {
+		return corev1.ConditionFalse, "EventsStalled",
+			fmt.Sprintf("the adapter did not read events for %s (max %s)", idle.Round(time.Second), maxLag)
+	}
+	return corev1.ConditionTrue, "", ""
+}
@@ -0,0 +1,18 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1alpha1
+
+import (
+	"context"
+)
+
+// SetDefaults implements apis.Defaultable
+func (hb *HorizonBinding) SetDefaults(ctx context.Context) {
+	if hb.Spec.Subject.Namespace == "" {
+		// Default the subject's namespace to our namespace.
+		hb.Spec.Subject.Namespace = hb.Namespace
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	duckv1alpha1 "knative.dev/pkg/apis/duck/v1alpha1"
+	"knative.dev/pkg/tracker"
+)
+
+func TestHorizonBindingDefaulting(t *testing.T) {
+	tests := []struct {
+		name string
+		c    *HorizonBinding
+		want *HorizonBinding
+	}{{
+		name: "no change",
+		c: &HorizonBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: HorizonBindingSpec{
+				BindingSpec:     validBindingSpec,
+				HorizonAuthSpec: validHorizonAuthSpec,
+			},
+		},
+		want: &HorizonBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: HorizonBindingSpec{
+				BindingSpec:     validBindingSpec,
+				HorizonAuthSpec: validHorizonAuthSpec,
+			},
+		},
+	}, {
+		name: "binding gets namespace",
+		c: &HorizonBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "valid",
+				Namespace: "with-namespace",
+			},
+			Spec: HorizonBindingSpec{
+				BindingSpec: duckv1alpha1.BindingSpec{
+					Subject: tracker.Reference{
+						APIVersion: "batch/v1",
+						Kind:       "Job",
+						Name:       "no-namespace",
+					},
+				},
+				HorizonAuthSpec: validHorizonAuthSpec,
+			},
+		},
+		want: &HorizonBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "valid",
+				Namespace: "with-namespace",
+			},
+			Spec: HorizonBindingSpec{
+				BindingSpec: duckv1alpha1.BindingSpec{
+					Subject: tracker.Reference{
+						APIVersion: "batch/v1",
+						Kind:       "Job",
+						Name:       "no-namespace",
+						Namespace:  "with-namespace",
+					},
+				},
+				HorizonAuthSpec: validHorizonAuthSpec,
+			},
+		},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.c.DeepCopy()
+			got.SetDefaults(context.Background())
+			if !cmp.Equal(test.want, got) {
+				t.Errorf("SetDefaults (-want, +got) = %v", cmp.Diff(test.want, got))
+			}
+		})
+	}
+}
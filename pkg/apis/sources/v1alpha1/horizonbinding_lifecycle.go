@@ -0,0 +1,148 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/apis/duck"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/tracker"
+
+	"github.com/vmware-tanzu/sources-for-knative/pkg/horizon"
+)
+
+var hbCondSet = apis.NewLivingConditionSet()
+
+// GetGroupVersionKind returns the GroupVersionKind.
+func (hb *HorizonBinding) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("HorizonBinding")
+}
+
+// GetUntypedSpec implements apis.HasSpec
+func (hb *HorizonBinding) GetUntypedSpec() interface{} {
+	return hb.Spec
+}
+
+// GetSubject implements psbinding.Bindable
+func (hb *HorizonBinding) GetSubject() tracker.Reference {
+	return hb.Spec.Subject
+}
+
+// GetBindingStatus implements psbinding.Bindable
+func (hb *HorizonBinding) GetBindingStatus() duck.BindableStatus {
+	return &hb.Status
+}
+
+// SetObservedGeneration implements psbinding.BindableStatus
+func (hbs *HorizonBindingStatus) SetObservedGeneration(gen int64) {
+	hbs.ObservedGeneration = gen
+}
+
+// InitializeConditions populates the HorizonBindingStatus's conditions field
+// with all of its conditions configured to Unknown.
+func (hbs *HorizonBindingStatus) InitializeConditions() {
+	hbCondSet.Manage(hbs).InitializeConditions()
+}
+
+// MarkBindingUnavailable marks the HorizonBinding's Ready condition to False with
+// the provided reason and message.
+func (hbs *HorizonBindingStatus) MarkBindingUnavailable(reason, message string) {
+	hbCondSet.Manage(hbs).MarkFalse(HorizonBindingConditionReady, reason, message)
+}
+
+// MarkBindingAvailable marks the HorizonBinding's Ready condition to True.
+func (hbs *HorizonBindingStatus) MarkBindingAvailable() {
+	hbCondSet.Manage(hbs).MarkTrue(HorizonBindingConditionReady)
+}
+
+// Do implements psbinding.Bindable
+func (hb *HorizonBinding) Do(ctx context.Context, ps *duckv1.WithPod) {
+	// First undo so that we can just unconditionally append below.
+	hb.Undo(ctx, ps)
+
+	// Make sure the PodSpec has a Volume like this:
+	volume := corev1.Volume{
+		Name: horizon.VolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: hb.Spec.SecretRef.Name,
+			},
+		},
+	}
+	ps.Spec.Template.Spec.Volumes = append(ps.Spec.Template.Spec.Volumes, volume)
+
+	// Make sure that each [init]container in the PodSpec has a VolumeMount like this:
+	volumeMount := corev1.VolumeMount{
+		Name:      horizon.VolumeName,
+		ReadOnly:  true,
+		MountPath: horizon.DefaultSecretMountPath,
+	}
+
+	env := []corev1.EnvVar{{
+		Name:  "HORIZON_URL",
+		Value: hb.Spec.Address.String(),
+	}, {
+		Name:  "HORIZON_INSECURE",
+		Value: fmt.Sprintf("%v", hb.Spec.SkipTLSVerify),
+	}}
+
+	spec := ps.Spec.Template.Spec
+	for i := range spec.InitContainers {
+		spec.InitContainers[i].VolumeMounts = append(spec.InitContainers[i].VolumeMounts, volumeMount)
+		spec.InitContainers[i].Env = append(spec.InitContainers[i].Env, env...)
+	}
+	for i := range spec.Containers {
+		spec.Containers[i].VolumeMounts = append(spec.Containers[i].VolumeMounts, volumeMount)
+		spec.Containers[i].Env = append(spec.Containers[i].Env, env...)
+	}
+}
+
+// Undo implements psbinding.Bindable
+func (hb *HorizonBinding) Undo(ctx context.Context, ps *duckv1.WithPod) {
+	spec := ps.Spec.Template.Spec
+
+	for i, v := range spec.Volumes {
+		if v.Name == horizon.VolumeName {
+			ps.Spec.Template.Spec.Volumes = append(spec.Volumes[:i], spec.Volumes[i+1:]...)
+			break
+		}
+	}
+
+	undo := func(c *corev1.Container) {
+		for j, vm := range c.VolumeMounts {
+			if vm.Name == horizon.VolumeName {
+				c.VolumeMounts = append(c.VolumeMounts[:j], c.VolumeMounts[j+1:]...)
+				break
+			}
+		}
+
+		if len(c.Env) == 0 {
+			return
+		}
+		env := make([]corev1.EnvVar, 0, len(c.Env))
+		for _, ev := range c.Env {
+			switch ev.Name {
+			case "HORIZON_URL", "HORIZON_INSECURE":
+				continue
+			default:
+				env = append(env, ev)
+			}
+		}
+		c.Env = env
+	}
+
+	for i := range spec.InitContainers {
+		undo(&spec.InitContainers[i])
+	}
+	for i := range spec.Containers {
+		undo(&spec.Containers[i])
+	}
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/apis/duck"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	duckv1alpha1 "knative.dev/pkg/apis/duck/v1alpha1"
+	apistest "knative.dev/pkg/apis/testing"
+
+	"github.com/vmware-tanzu/sources-for-knative/pkg/horizon"
+)
+
+func TestHorizonBindingDuckTypes(t *testing.T) {
+	tests := []struct {
+		name string
+		t    duck.Implementable
+	}{{
+		name: "conditions",
+		t:    &duckv1.Conditions{},
+	}, {
+		name: "binding",
+		t:    &duckv1alpha1.Binding{},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := duck.VerifyType(&HorizonBinding{}, test.t)
+			if err != nil {
+				t.Errorf("VerifyType(HorizonBinding, %T) = %v", test.t, err)
+			}
+		})
+	}
+}
+
+func TestHorizonBindingGetGroupVersionKind(t *testing.T) {
+	r := &HorizonBinding{}
+	want := schema.GroupVersionKind{
+		Group:   "sources.tanzu.vmware.com",
+		Version: "v1alpha1",
+		Kind:    "HorizonBinding",
+	}
+	if got := r.GetGroupVersionKind(); got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
+
+func TestHorizonBindingDoUndo(t *testing.T) {
+	url := apis.URL{
+		Scheme: "https",
+		Host:   "horizon.local",
+	}
+	secretName := "horizon-credentials"
+	hb := &HorizonBinding{
+		Spec: HorizonBindingSpec{
+			HorizonAuthSpec: HorizonAuthSpec{
+				Address: url,
+				SecretRef: corev1.LocalObjectReference{
+					Name: secretName,
+				},
+			},
+		},
+	}
+
+	volumeMount := corev1.VolumeMount{
+		Name:      horizon.VolumeName,
+		ReadOnly:  true,
+		MountPath: horizon.DefaultSecretMountPath,
+	}
+	env := []corev1.EnvVar{{
+		Name:  "HORIZON_URL",
+		Value: url.String(),
+	}, {
+		Name:  "HORIZON_INSECURE",
+		Value: "false",
+	}}
+
+	in := &duckv1.WithPod{
+		Spec: duckv1.WithPodSpec{
+			Template: duckv1.PodSpecable{
+				Spec: corev1.PodSpec{
+					InitContainers: []corev1.Container{{
+						Name:  "setup",
+						Image: "busybox",
+					}},
+					Containers: []corev1.Container{{
+						Name:  "blah",
+						Image: "busybox",
+						Env: []corev1.EnvVar{{
+							Name:  "FOO",
+							Value: "BAR",
+						}, {
+							Name:  "HORIZON_URL",
+							Value: "the wrong value",
+						}},
+					}},
+				},
+			},
+		},
+	}
+
+	want := &duckv1.WithPod{
+		Spec: duckv1.WithPodSpec{
+			Template: duckv1.PodSpecable{
+				Spec: corev1.PodSpec{
+					InitContainers: []corev1.Container{{
+						Name:         "setup",
+						Image:        "busybox",
+						Env:          env,
+						VolumeMounts: []corev1.VolumeMount{volumeMount},
+					}},
+					Containers: []corev1.Container{{
+						Name:  "blah",
+						Image: "busybox",
+						Env: append([]corev1.EnvVar{{
+							Name:  "FOO",
+							Value: "BAR",
+						}}, env...),
+						VolumeMounts: []corev1.VolumeMount{volumeMount},
+					}},
+					Volumes: []corev1.Volume{{
+						Name: horizon.VolumeName,
+						VolumeSource: corev1.VolumeSource{
+							Secret: &corev1.SecretVolumeSource{
+								SecretName: secretName,
+							},
+						},
+					}},
+				},
+			},
+		},
+	}
+
+	ctx := context.Background()
+	got := in.DeepCopy()
+	hb.Do(ctx, got)
+	if !cmp.Equal(got, want) {
+		t.Errorf("Do (-want, +got): %s", cmp.Diff(want, got))
+	}
+
+	undone := &duckv1.WithPod{
+		Spec: duckv1.WithPodSpec{
+			Template: duckv1.PodSpecable{
+				Spec: corev1.PodSpec{
+					InitContainers: []corev1.Container{{
+						Name:         "setup",
+						Image:        "busybox",
+						Env:          []corev1.EnvVar{},
+						VolumeMounts: []corev1.VolumeMount{},
+					}},
+					Containers: []corev1.Container{{
+						Name:  "blah",
+						Image: "busybox",
+						Env: []corev1.EnvVar{{
+							Name:  "FOO",
+							Value: "BAR",
+						}},
+						VolumeMounts: []corev1.VolumeMount{},
+					}},
+					Volumes: []corev1.Volume{},
+				},
+			},
+		},
+	}
+
+	hb.Undo(ctx, got)
+	if !cmp.Equal(got, undone) {
+		t.Errorf("Undo (-want, +got): %s", cmp.Diff(undone, got))
+	}
+}
+
+func TestHorizonBindingFlow(t *testing.T) {
+	r := &HorizonBindingStatus{}
+	r.InitializeConditions()
+	apistest.CheckConditionOngoing(r, HorizonBindingConditionReady, t)
+
+	r.MarkBindingUnavailable("Foo", "Bar")
+	apistest.CheckConditionFailed(r, HorizonBindingConditionReady, t)
+
+	r.MarkBindingAvailable()
+	apistest.CheckConditionSucceeded(r, HorizonBindingConditionReady, t)
+}
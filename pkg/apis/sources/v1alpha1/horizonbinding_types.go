@@ -0,0 +1,65 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	duckv1alpha1 "knative.dev/pkg/apis/duck/v1alpha1"
+	"knative.dev/pkg/kmeta"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:defaulter-gen=true
+
+// HorizonBinding describes a Binding that makes authenticating against
+// a Horizon API simple.
+type HorizonBinding struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   HorizonBindingSpec   `json:"spec"`
+	Status HorizonBindingStatus `json:"status"`
+}
+
+// Check the interfaces that HorizonBinding should be implementing.
+var (
+	_ runtime.Object     = (*HorizonBinding)(nil)
+	_ kmeta.OwnerRefable = (*HorizonBinding)(nil)
+	_ apis.Validatable   = (*HorizonBinding)(nil)
+	_ apis.Defaultable   = (*HorizonBinding)(nil)
+	_ apis.HasSpec       = (*HorizonBinding)(nil)
+)
+
+// HorizonBindingSpec holds the desired state of the HorizonBinding (from the client).
+type HorizonBindingSpec struct {
+	duckv1alpha1.BindingSpec `json:",inline"`
+
+	HorizonAuthSpec `json:",inline"`
+}
+
+const (
+	// HorizonBindingConditionReady is configured to indicate whether the Binding
+	// has been configured for resources subject to its runtime contract.
+	HorizonBindingConditionReady = apis.ConditionReady
+)
+
+// HorizonBindingStatus communicates the observed state of the HorizonBinding (from the controller).
+type HorizonBindingStatus struct {
+	duckv1.Status `json:",inline"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// HorizonBindingList contains a list of HorizonBinding
+type HorizonBindingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []HorizonBinding `json:"items"`
+}
@@ -0,0 +1,26 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"knative.dev/pkg/apis"
+)
+
+// Validate implements apis.Validatable
+func (hb *HorizonBinding) Validate(ctx context.Context) *apis.FieldError {
+	err := hb.Spec.Validate(ctx).ViaField("spec")
+	if hb.Spec.Subject.Namespace != "" && hb.Namespace != hb.Spec.Subject.Namespace {
+		err = err.Also(apis.ErrInvalidValue(hb.Spec.Subject.Namespace, "spec.subject.namespace"))
+	}
+	return err
+}
+
+// Validate implements apis.Validatable
+func (hbs *HorizonBindingSpec) Validate(ctx context.Context) *apis.FieldError {
+	return hbs.Subject.Validate(ctx).ViaField("subject").Also(hbs.HorizonAuthSpec.Validate(ctx))
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1alpha1 "knative.dev/pkg/apis/duck/v1alpha1"
+	"knative.dev/pkg/tracker"
+)
+
+var validHorizonAuthSpec = HorizonAuthSpec{
+	Address: apis.URL{
+		Scheme: "https",
+		Host:   "horizon.local",
+	},
+	SecretRef: corev1.LocalObjectReference{
+		Name: "horizon-credentials",
+	},
+}
+
+func TestHorizonBindingValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		c    *HorizonBinding
+		want *apis.FieldError
+	}{{
+		name: "valid",
+		c: &HorizonBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "valid",
+				Namespace: validBindingSpec.Subject.Namespace,
+			},
+			Spec: HorizonBindingSpec{
+				BindingSpec:     validBindingSpec,
+				HorizonAuthSpec: validHorizonAuthSpec,
+			},
+		},
+		want: nil,
+	}, {
+		name: "subject in different namespace",
+		c: &HorizonBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "valid",
+				Namespace: validBindingSpec.Subject.Namespace,
+			},
+			Spec: HorizonBindingSpec{
+				BindingSpec: duckv1alpha1.BindingSpec{
+					Subject: tracker.Reference{
+						APIVersion: "batch/v1",
+						Kind:       "Job",
+						Namespace:  "different-namespace",
+						Name:       "report",
+					},
+				},
+				HorizonAuthSpec: validHorizonAuthSpec,
+			},
+		},
+		want: apis.ErrInvalidValue("different-namespace", "spec.subject.namespace"),
+	}, {
+		name: "missing SecretRef",
+		c: &HorizonBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "valid",
+				Namespace: validBindingSpec.Subject.Namespace,
+			},
+			Spec: HorizonBindingSpec{
+				BindingSpec: validBindingSpec,
+				HorizonAuthSpec: HorizonAuthSpec{
+					Address: validHorizonAuthSpec.Address,
+				},
+			},
+		},
+		want: apis.ErrMissingField("spec.secretRef.name"),
+	}, {
+		name: "missing host address",
+		c: &HorizonBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "valid",
+				Namespace: validBindingSpec.Subject.Namespace,
+			},
+			Spec: HorizonBindingSpec{
+				BindingSpec: validBindingSpec,
+				HorizonAuthSpec: HorizonAuthSpec{
+					Address: apis.URL{
+						Scheme: "https",
+					},
+					SecretRef: validHorizonAuthSpec.SecretRef,
+				},
+			},
+		},
+		want: apis.ErrMissingField("spec.address.host"),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.c.Validate(context.Background())
+			if !cmp.Equal(test.want.Error(), got.Error()) {
+				t.Errorf("Validate (-want, +got) = %v",
+					cmp.Diff(test.want.Error(), got.Error()))
+			}
+		})
+	}
+}
@@ -9,6 +9,9 @@ import (
 	"context"
 
 	"knative.dev/pkg/apis"
+	"knative.dev/pkg/ptr"
+
+	"github.com/vmware-tanzu/sources-for-knative/pkg/horizon"
 )
 
 // SetDefaults mutates HorizonSource.
@@ -20,4 +23,22 @@ func (hs *HorizonSource) SetDefaults(ctx context.Context) {
 	// call SetDefaults against duckv1.Destination with a context of ObjectMeta of HorizonSource.
 	withNS := apis.WithinParent(ctx, hs.ObjectMeta)
 	hs.Spec.Sink.SetDefaults(withNS)
+
+	hs.Spec.Polling.SetDefaults(ctx)
+}
+
+// SetDefaults mutates HorizonPollingSpec.
+func (hps *HorizonPollingSpec) SetDefaults(_ context.Context) {
+	if hps.IntervalSeconds == 0 {
+		hps.IntervalSeconds = int64(horizon.DefaultPollInterval.Seconds())
+	}
+	if hps.MinBackoffSeconds == 0 {
+		hps.MinBackoffSeconds = int64(horizon.DefaultMinBackoff.Seconds())
+	}
+	if hps.MaxBackoffSeconds == 0 {
+		hps.MaxBackoffSeconds = int64(horizon.DefaultMaxBackoff.Seconds())
+	}
+	if hps.SendRetries == nil {
+		hps.SendRetries = ptr.Int32(horizon.DefaultSendRetries)
+	}
 }
@@ -13,6 +13,9 @@ import (
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 
 	"github.com/google/go-cmp/cmp"
+	"knative.dev/pkg/ptr"
+
+	"github.com/vmware-tanzu/sources-for-knative/pkg/horizon"
 )
 
 func TestHorizonSourceDefaults(t *testing.T) {
@@ -25,6 +28,7 @@ func TestHorizonSourceDefaults(t *testing.T) {
 			expected: HorizonSource{
 				Spec: HorizonSourceSpec{
 					ServiceAccountName: "default",
+					Polling:            defaultHorizonPolling(),
 				},
 			},
 		},
@@ -55,6 +59,29 @@ func TestHorizonSourceDefaults(t *testing.T) {
 							},
 						},
 					},
+					Polling: defaultHorizonPolling(),
+				},
+			},
+		},
+		"custom polling": {
+			initial: HorizonSource{
+				Spec: HorizonSourceSpec{
+					ServiceAccountName: "default",
+					Polling: HorizonPollingSpec{
+						IntervalSeconds: 10,
+						SendRetries:     ptr.Int32(0),
+					},
+				},
+			},
+			expected: HorizonSource{
+				Spec: HorizonSourceSpec{
+					ServiceAccountName: "default",
+					Polling: HorizonPollingSpec{
+						IntervalSeconds:   10,
+						MinBackoffSeconds: int64(horizon.DefaultMinBackoff.Seconds()),
+						MaxBackoffSeconds: int64(horizon.DefaultMaxBackoff.Seconds()),
+						SendRetries:       ptr.Int32(0),
+					},
 				},
 			},
 		},
@@ -68,3 +95,12 @@ func TestHorizonSourceDefaults(t *testing.T) {
 		})
 	}
 }
+
+func defaultHorizonPolling() HorizonPollingSpec {
+	return HorizonPollingSpec{
+		IntervalSeconds:   int64(horizon.DefaultPollInterval.Seconds()),
+		MinBackoffSeconds: int64(horizon.DefaultMinBackoff.Seconds()),
+		MaxBackoffSeconds: int64(horizon.DefaultMaxBackoff.Seconds()),
+		SendRetries:       ptr.Int32(horizon.DefaultSendRetries),
+	}
+}
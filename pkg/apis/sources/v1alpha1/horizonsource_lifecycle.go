@@ -7,9 +7,13 @@ SPDX-License-Identifier: Apache-2.0
 package v1alpha1
 
 import (
+	"time"
+
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	"knative.dev/eventing/pkg/apis/duck"
 	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 )
 
 const (
@@ -19,12 +23,40 @@ const (
 	// HorizonSourceConditionSinkProvided has status True when the HorizonSource has been configured with a sink target.
 	HorizonSourceConditionSinkProvided apis.ConditionType = "SinkProvided"
 
+	// HorizonSourceConditionDeadLetterSinkResolved has status True when spec.delivery.deadLetterSink
+	// resolved to an address or when no dead letter sink is configured.
+	HorizonSourceConditionDeadLetterSinkResolved apis.ConditionType = "DeadLetterSinkResolved"
+
 	// HorizonSourceConditionDeployed has status True when the HorizonSource has had it's adapter deployment created.
 	HorizonSourceConditionDeployed apis.ConditionType = "Deployed"
+
+	// HorizonSourceConditionSecretReady has status True when the secret referenced by the HorizonSource
+	// exists and holds the domain, username and password keys.
+	HorizonSourceConditionSecretReady apis.ConditionType = "SecretReady"
+
+	// HorizonSourceConditionAuthReady has status True when the Horizon API accepted a login with the
+	// credentials of the HorizonSource. It is only set if the controller probes the login and does not
+	// affect the Ready condition.
+	HorizonSourceConditionAuthReady apis.ConditionType = "AuthReady"
+
+	// HorizonSourceConditionEventsFlowing has status True when events are delivered to the sink
+	// without errors and lag. It does not affect the Ready condition.
+	HorizonSourceConditionEventsFlowing apis.ConditionType = "EventsFlowing"
+
+	// HorizonSourceConditionSuspended has status True while the adapter of the HorizonSource is
+	// scaled to zero. It does not affect the Ready condition.
+	HorizonSourceConditionSuspended apis.ConditionType = "Suspended"
+
+	// HorizonSourceConditionEventTypesRegistered has status True when the event types of the
+	// HorizonSource are registered as EventTypes of its sink broker. It is only set if the controller
+	// registers event types and does not affect the Ready condition.
+	HorizonSourceConditionEventTypesRegistered apis.ConditionType = "EventTypesRegistered"
 )
 
 var HorizonSourceCondSet = apis.NewLivingConditionSet(
 	HorizonSourceConditionSinkProvided,
+	HorizonSourceConditionDeadLetterSinkResolved,
+	HorizonSourceConditionSecretReady,
 	HorizonSourceConditionDeployed,
 )
 
@@ -53,11 +85,84 @@ func (hss *HorizonSourceStatus) MarkSink(uri *apis.URL) {
 	}
 }
 
+// MarkSinkAddress sets the condition that the source has a sink configured
+// and records the CA certificates and OIDC audience of the sink.
+func (hss *HorizonSourceStatus) MarkSinkAddress(addr *duckv1.Addressable) {
+	hss.SinkCACerts = addr.CACerts
+	hss.SinkAudience = addr.Audience
+	hss.MarkSink(addr.URL)
+}
+
 // MarkNoSink sets the condition that the source does not have a sink configured.
 func (hss *HorizonSourceStatus) MarkNoSink(reason, messageFormat string, messageA ...interface{}) {
 	HorizonSourceCondSet.Manage(hss).MarkFalse(HorizonSourceConditionSinkProvided, reason, messageFormat, messageA...)
 }
 
+// MarkDeadLetterSink sets the condition that the dead letter sink of the source resolved to uri.
+func (hss *HorizonSourceStatus) MarkDeadLetterSink(uri *apis.URL) {
+	hss.DeadLetterSinkURI = uri
+	HorizonSourceCondSet.Manage(hss).MarkTrue(HorizonSourceConditionDeadLetterSinkResolved)
+}
+
+// MarkDeadLetterSinkNotConfigured sets the condition that the source does not have a dead letter
+// sink configured.
+func (hss *HorizonSourceStatus) MarkDeadLetterSinkNotConfigured() {
+	hss.DeadLetterSinkURI = nil
+	HorizonSourceCondSet.Manage(hss).MarkTrueWithReason(HorizonSourceConditionDeadLetterSinkResolved,
+		"DeadLetterSinkNotConfigured", "No dead letter sink is configured.")
+}
+
+// MarkNoDeadLetterSink sets the condition that the dead letter sink of the source could not be
+// resolved.
+func (hss *HorizonSourceStatus) MarkNoDeadLetterSink(reason, messageFormat string, messageA ...interface{}) {
+	HorizonSourceCondSet.Manage(hss).MarkFalse(HorizonSourceConditionDeadLetterSinkResolved, reason, messageFormat, messageA...)
+}
+
+// MarkSecretReady sets the condition that the secret of the source is valid.
+func (hss *HorizonSourceStatus) MarkSecretReady() {
+	HorizonSourceCondSet.Manage(hss).MarkTrue(HorizonSourceConditionSecretReady)
+}
+
+// MarkSecretNotReady sets the condition that the secret of the source is missing or invalid.
+func (hss *HorizonSourceStatus) MarkSecretNotReady(reason, messageFormat string, messageA ...interface{}) {
+	HorizonSourceCondSet.Manage(hss).MarkFalse(HorizonSourceConditionSecretReady, reason, messageFormat, messageA...)
+}
+
+// MarkAuthReady sets the condition that the Horizon API accepted the credentials of the source.
+func (hss *HorizonSourceStatus) MarkAuthReady() {
+	HorizonSourceCondSet.Manage(hss).MarkTrue(HorizonSourceConditionAuthReady)
+}
+
+// MarkAuthUnknown sets the condition that the credentials of the source have not been verified yet.
+func (hss *HorizonSourceStatus) MarkAuthUnknown(reason, messageFormat string, messageA ...interface{}) {
+	HorizonSourceCondSet.Manage(hss).MarkUnknown(HorizonSourceConditionAuthReady, reason, messageFormat, messageA...)
+}
+
+// MarkAuthFailed sets the condition that the Horizon API login with the credentials of the source failed.
+func (hss *HorizonSourceStatus) MarkAuthFailed(reason, messageFormat string, messageA ...interface{}) {
+	HorizonSourceCondSet.Manage(hss).MarkFalse(HorizonSourceConditionAuthReady, reason, messageFormat, messageA...)
+}
+
+// MarkSuspended sets the condition that the adapter of the source is scaled to zero.
+func (hss *HorizonSourceStatus) MarkSuspended() {
+	HorizonSourceCondSet.Manage(hss).MarkTrue(HorizonSourceConditionSuspended)
+}
+
+// ClearSuspended removes the Suspended condition once the source is resumed.
+func (hss *HorizonSourceStatus) ClearSuspended() {
+	_ = HorizonSourceCondSet.Manage(hss).ClearCondition(HorizonSourceConditionSuspended)
+}
+
+// MarkEventTypesRegistered sets the condition that the event types of the source are registered.
+func (hss *HorizonSourceStatus) MarkEventTypesRegistered() {
+	HorizonSourceCondSet.Manage(hss).MarkTrue(HorizonSourceConditionEventTypesRegistered)
+}
+
+// MarkEventTypesNotRegistered sets the condition that the event types of the source could not be registered.
+func (hss *HorizonSourceStatus) MarkEventTypesNotRegistered(reason, messageFormat string, messageA ...interface{}) {
+	HorizonSourceCondSet.Manage(hss).MarkFalse(HorizonSourceConditionEventTypesRegistered, reason, messageFormat, messageA...)
+}
+
 // PropagateDeploymentAvailability uses the availability of the provided Deployment to determine if
 // HorizonSourceConditionDeployed should be marked as true or false.
 func (hss *HorizonSourceStatus) PropagateDeploymentAvailability(d *appsv1.Deployment) {
@@ -70,7 +175,31 @@ func (hss *HorizonSourceStatus) PropagateDeploymentAvailability(d *appsv1.Deploy
 	}
 }
 
+// PropagateEventsFlowing sets the EventsFlowing condition from the delivery status reported by the
+// adapter. A maxLagSeconds of 0 uses DefaultMaxEventLag.
+func (hss *HorizonSourceStatus) PropagateEventsFlowing(maxLagSeconds int64) {
+	status, reason, message := hss.eventsFlowing(time.Now(), MaxEventLag(maxLagSeconds))
+	switch status {
+	case corev1.ConditionUnknown:
+		HorizonSourceCondSet.Manage(hss).MarkUnknown(HorizonSourceConditionEventsFlowing, reason, message)
+	case corev1.ConditionFalse:
+		HorizonSourceCondSet.Manage(hss).MarkFalse(HorizonSourceConditionEventsFlowing, reason, message)
+	case corev1.ConditionTrue:
+		HorizonSourceCondSet.Manage(hss).MarkTrue(HorizonSourceConditionEventsFlowing)
+	}
+}
+
 // IsReady returns true if the resource is ready overall.
 func (hss *HorizonSourceStatus) IsReady() bool {
 	return HorizonSourceCondSet.Manage(hss).IsHappy()
 }
+
+// CopyAdapterStatus copies the fields the adapter reports in the status of its
+// source from the given status. The reconciler takes them from a fresh read of
+// the source when updating the status, so that it never rolls back a report
+// of the adapter with the copy it reconciled.
+func (hss *HorizonSourceStatus) CopyAdapterStatus(from *HorizonSourceStatus) {
+	from.EventDeliveryStatus.DeepCopyInto(&hss.EventDeliveryStatus)
+	hss.LastEventID = from.LastEventID
+	hss.LastEventPosition = from.LastEventPosition.DeepCopy()
+}
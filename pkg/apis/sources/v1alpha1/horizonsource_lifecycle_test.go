@@ -7,14 +7,17 @@ package v1alpha1
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/apis/duck"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/ptr"
 )
 
 var availableDeployment = &appsv1.Deployment{
@@ -76,6 +79,7 @@ func TestRabbitmqSourceStatusGetCondition(t *testing.T) {
 				s := &HorizonSourceStatus{}
 				s.InitializeConditions()
 				s.MarkSink(apis.HTTP("uri://example"))
+				s.MarkDeadLetterSinkNotConfigured()
 				return s
 			}(),
 			condQuery: HorizonSourceConditionReady,
@@ -90,6 +94,8 @@ func TestRabbitmqSourceStatusGetCondition(t *testing.T) {
 				s := &HorizonSourceStatus{}
 				s.InitializeConditions()
 				s.MarkSink(apis.HTTP("uri://example"))
+				s.MarkDeadLetterSinkNotConfigured()
+				s.MarkSecretReady()
 				s.PropagateDeploymentAvailability(availableDeployment)
 				return s
 			}(),
@@ -105,6 +111,7 @@ func TestRabbitmqSourceStatusGetCondition(t *testing.T) {
 				s := &HorizonSourceStatus{}
 				s.InitializeConditions()
 				s.MarkSink(apis.HTTP("uri://example"))
+				s.MarkDeadLetterSinkNotConfigured()
 				s.PropagateDeploymentAvailability(availableDeployment)
 				s.MarkNoSink("Testing", "hi%s", "")
 				return s
@@ -117,6 +124,297 @@ func TestRabbitmqSourceStatusGetCondition(t *testing.T) {
 				Message: "hi",
 			},
 		},
+		{
+			name: "mark sink and adapter deployed then secret not found",
+			s: func() *HorizonSourceStatus {
+				s := &HorizonSourceStatus{}
+				s.InitializeConditions()
+				s.MarkSink(apis.HTTP("uri://example"))
+				s.MarkDeadLetterSinkNotConfigured()
+				s.MarkSecretReady()
+				s.PropagateDeploymentAvailability(availableDeployment)
+				s.MarkSecretNotReady("SecretNotFound", "secret %q not found", "creds")
+				return s
+			}(),
+			condQuery: HorizonSourceConditionReady,
+			want: &apis.Condition{
+				Type:    HorizonSourceConditionReady,
+				Status:  corev1.ConditionFalse,
+				Reason:  "SecretNotFound",
+				Message: `secret "creds" not found`,
+			},
+		},
+		{
+			name: "mark sink and adapter deployed then dead letter sink not found",
+			s: func() *HorizonSourceStatus {
+				s := &HorizonSourceStatus{}
+				s.InitializeConditions()
+				s.MarkSink(apis.HTTP("uri://example"))
+				s.MarkDeadLetterSink(apis.HTTP("uri://dls"))
+				s.MarkSecretReady()
+				s.PropagateDeploymentAvailability(availableDeployment)
+				s.MarkNoDeadLetterSink("NotFound", "not found")
+				return s
+			}(),
+			condQuery: HorizonSourceConditionReady,
+			want: &apis.Condition{
+				Type:    HorizonSourceConditionReady,
+				Status:  corev1.ConditionFalse,
+				Reason:  "NotFound",
+				Message: "not found",
+			},
+		},
+		{
+			name: "dead letter sink not found keeps sink provided",
+			s: func() *HorizonSourceStatus {
+				s := &HorizonSourceStatus{}
+				s.InitializeConditions()
+				s.MarkSink(apis.HTTP("uri://example"))
+				s.MarkNoDeadLetterSink("NotFound", "not found")
+				return s
+			}(),
+			condQuery: HorizonSourceConditionSinkProvided,
+			want: &apis.Condition{
+				Type:   HorizonSourceConditionSinkProvided,
+				Status: corev1.ConditionTrue,
+			},
+		},
+		{
+			name: "auth failed does not affect ready",
+			s: func() *HorizonSourceStatus {
+				s := &HorizonSourceStatus{}
+				s.InitializeConditions()
+				s.MarkSink(apis.HTTP("uri://example"))
+				s.MarkDeadLetterSinkNotConfigured()
+				s.MarkSecretReady()
+				s.PropagateDeploymentAvailability(availableDeployment)
+				s.MarkAuthFailed("LoginFailed", "rejected")
+				return s
+			}(),
+			condQuery: HorizonSourceConditionReady,
+			want: &apis.Condition{
+				Type:   HorizonSourceConditionReady,
+				Status: corev1.ConditionTrue,
+			},
+		},
+		{
+			name: "auth failed",
+			s: func() *HorizonSourceStatus {
+				s := &HorizonSourceStatus{}
+				s.InitializeConditions()
+				s.MarkAuthFailed("TLSError", "untrusted certificate")
+				return s
+			}(),
+			condQuery: HorizonSourceConditionAuthReady,
+			want: &apis.Condition{
+				Type:    HorizonSourceConditionAuthReady,
+				Status:  corev1.ConditionFalse,
+				Reason:  "TLSError",
+				Message: "untrusted certificate",
+			},
+		},
+		{
+			name: "events lagging does not affect ready",
+			s: func() *HorizonSourceStatus {
+				s := &HorizonSourceStatus{}
+				s.InitializeConditions()
+				s.MarkSink(apis.HTTP("uri://example"))
+				s.MarkDeadLetterSinkNotConfigured()
+				s.MarkSecretReady()
+				s.PropagateDeploymentAvailability(availableDeployment)
+				s.LastPollTime = &metav1.Time{Time: time.Now()}
+				s.EventLag = &metav1.Duration{Duration: 10 * time.Minute}
+				s.PropagateEventsFlowing(0)
+				return s
+			}(),
+			condQuery: HorizonSourceConditionReady,
+			want: &apis.Condition{
+				Type:   HorizonSourceConditionReady,
+				Status: corev1.ConditionTrue,
+			},
+		},
+		{
+			name: "events lagging",
+			s: func() *HorizonSourceStatus {
+				s := &HorizonSourceStatus{}
+				s.InitializeConditions()
+				s.LastPollTime = &metav1.Time{Time: time.Now()}
+				s.EventLag = &metav1.Duration{Duration: 2 * time.Minute}
+				s.PropagateEventsFlowing(60)
+				return s
+			}(),
+			condQuery: HorizonSourceConditionEventsFlowing,
+			want: &apis.Condition{
+				Type:    HorizonSourceConditionEventsFlowing,
+				Status:  corev1.ConditionFalse,
+				Reason:  "EventsLagging",
+				Message: "last event was delivered 2m0s after its creation (max 1m0s)",
+			},
+		},
+		{
+			name: "events stalled",
+			s: func() *HorizonSourceStatus {
+				s := &HorizonSourceStatus{}
+				s.InitializeConditions()
+				s.LastPollTime = &metav1.Time{Time: time.Now().Add(-10 * time.Minute)}
+				s.PropagateEventsFlowing(0)
+				return s
+			}(),
+			condQuery: HorizonSourceConditionEventsFlowing,
+			want: &apis.Condition{
+				Type:    HorizonSourceConditionEventsFlowing,
+				Status:  corev1.ConditionFalse,
+				Reason:  "EventsStalled",
+				Message: "the adapter did not read events for 10m0s (max 5m0s)",
+			},
+		},
+		{
+			name: "quiet source",
+			s: func() *HorizonSourceStatus {
+				s := &HorizonSourceStatus{}
+				s.InitializeConditions()
+				now := time.Now()
+				s.LastSentTime = &metav1.Time{Time: now.Add(-time.Hour)}
+				s.LastPollTime = &metav1.Time{Time: now}
+				s.PropagateEventsFlowing(0)
+				return s
+			}(),
+			condQuery: HorizonSourceConditionEventsFlowing,
+			want: &apis.Condition{
+				Type:   HorizonSourceConditionEventsFlowing,
+				Status: corev1.ConditionTrue,
+			},
+		},
+		{
+			name: "replayed events",
+			s: func() *HorizonSourceStatus {
+				s := &HorizonSourceStatus{}
+				s.InitializeConditions()
+				now := time.Now()
+				// replayed events do not report their lag
+				s.LastEventTime = &metav1.Time{Time: now.Add(-time.Hour)}
+				s.LastSentTime = &metav1.Time{Time: now}
+				s.LastPollTime = &metav1.Time{Time: now}
+				s.PropagateEventsFlowing(0)
+				return s
+			}(),
+			condQuery: HorizonSourceConditionEventsFlowing,
+			want: &apis.Condition{
+				Type:   HorizonSourceConditionEventsFlowing,
+				Status: corev1.ConditionTrue,
+			},
+		},
+		{
+			name: "events flowing",
+			s: func() *HorizonSourceStatus {
+				s := &HorizonSourceStatus{}
+				s.InitializeConditions()
+				s.LastPollTime = &metav1.Time{Time: time.Now()}
+				s.EventLag = &metav1.Duration{Duration: 2 * time.Minute}
+				s.PropagateEventsFlowing(0)
+				return s
+			}(),
+			condQuery: HorizonSourceConditionEventsFlowing,
+			want: &apis.Condition{
+				Type:   HorizonSourceConditionEventsFlowing,
+				Status: corev1.ConditionTrue,
+			},
+		},
+		{
+			name: "suspended does not affect ready",
+			s: func() *HorizonSourceStatus {
+				s := &HorizonSourceStatus{}
+				s.InitializeConditions()
+				s.MarkSink(apis.HTTP("uri://example"))
+				s.MarkDeadLetterSinkNotConfigured()
+				s.MarkSecretReady()
+				s.PropagateDeploymentAvailability(availableDeployment)
+				s.MarkSuspended()
+				return s
+			}(),
+			condQuery: HorizonSourceConditionReady,
+			want: &apis.Condition{
+				Type:   HorizonSourceConditionReady,
+				Status: corev1.ConditionTrue,
+			},
+		},
+		{
+			name: "suspended",
+			s: func() *HorizonSourceStatus {
+				s := &HorizonSourceStatus{}
+				s.InitializeConditions()
+				s.MarkSuspended()
+				return s
+			}(),
+			condQuery: HorizonSourceConditionSuspended,
+			want: &apis.Condition{
+				Type:   HorizonSourceConditionSuspended,
+				Status: corev1.ConditionTrue,
+			},
+		},
+		{
+			name: "resumed",
+			s: func() *HorizonSourceStatus {
+				s := &HorizonSourceStatus{}
+				s.InitializeConditions()
+				s.MarkSuspended()
+				s.ClearSuspended()
+				return s
+			}(),
+			condQuery: HorizonSourceConditionSuspended,
+			want:      nil,
+		},
+		{
+			name: "event types not registered does not affect ready",
+			s: func() *HorizonSourceStatus {
+				s := &HorizonSourceStatus{}
+				s.InitializeConditions()
+				s.MarkSink(apis.HTTP("uri://example"))
+				s.MarkDeadLetterSinkNotConfigured()
+				s.MarkSecretReady()
+				s.PropagateDeploymentAvailability(availableDeployment)
+				s.MarkEventTypesNotRegistered("RegistrationFailed", "no EventType API")
+				return s
+			}(),
+			condQuery: HorizonSourceConditionReady,
+			want: &apis.Condition{
+				Type:   HorizonSourceConditionReady,
+				Status: corev1.ConditionTrue,
+			},
+		},
+		{
+			name: "event types not registered",
+			s: func() *HorizonSourceStatus {
+				s := &HorizonSourceStatus{}
+				s.InitializeConditions()
+				s.MarkEventTypesNotRegistered("RegistrationFailed", "no EventType API")
+				return s
+			}(),
+			condQuery: HorizonSourceConditionEventTypesRegistered,
+			want: &apis.Condition{
+				Type:    HorizonSourceConditionEventTypesRegistered,
+				Status:  corev1.ConditionFalse,
+				Reason:  "RegistrationFailed",
+				Message: "no EventType API",
+			},
+		},
+		{
+			name: "delivery failing",
+			s: func() *HorizonSourceStatus {
+				s := &HorizonSourceStatus{}
+				s.InitializeConditions()
+				s.LastError = "sink unavailable"
+				s.PropagateEventsFlowing(0)
+				return s
+			}(),
+			condQuery: HorizonSourceConditionEventsFlowing,
+			want: &apis.Condition{
+				Type:    HorizonSourceConditionEventsFlowing,
+				Status:  corev1.ConditionFalse,
+				Reason:  "DeliveryFailing",
+				Message: "sink unavailable",
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -130,3 +428,26 @@ func TestRabbitmqSourceStatusGetCondition(t *testing.T) {
 		})
 	}
 }
+
+func TestHorizonSourceStatus_MarkSinkAddress(t *testing.T) {
+	s := &HorizonSourceStatus{}
+	s.InitializeConditions()
+	s.MarkSinkAddress(&duckv1.Addressable{
+		URL:      apis.HTTPS("broker.example.com"),
+		CACerts:  ptr.String("cert"),
+		Audience: ptr.String("broker-audience"),
+	})
+
+	if got := s.GetCondition(HorizonSourceConditionSinkProvided); !got.IsTrue() {
+		t.Errorf("sink condition = %v, want true", got)
+	}
+	if s.SinkURI.String() != "https://broker.example.com" || *s.SinkCACerts != "cert" || *s.SinkAudience != "broker-audience" {
+		t.Errorf("sink status = %v, %v, %v, want address", s.SinkURI, s.SinkCACerts, s.SinkAudience)
+	}
+
+	// certs and audience are cleared when the sink no longer has them
+	s.MarkSinkAddress(&duckv1.Addressable{URL: apis.HTTP("sink.example.com")})
+	if s.SinkCACerts != nil || s.SinkAudience != nil {
+		t.Errorf("sink status = %v, %v, want cleared", s.SinkCACerts, s.SinkAudience)
+	}
+}
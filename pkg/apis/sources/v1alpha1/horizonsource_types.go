@@ -10,6 +10,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/apis/duck"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
@@ -86,6 +87,85 @@ type HorizonSourceSpec struct {
 	ServiceAccountName string `json:"serviceAccountName,omitempty"`
 
 	HorizonAuthSpec `json:",inline"`
+
+	// MaxEventLagSeconds is the maximum time between the creation of an event
+	// in Horizon and its delivery to the sink before the EventsFlowing
+	// condition becomes False. The condition also becomes False if no event
+	// was delivered for this time. Defaults to 300 seconds.
+	// +optional
+	MaxEventLagSeconds int64 `json:"maxEventLagSeconds,omitempty"`
+
+	// Filter selects the Horizon events to retrieve. If unspecified, all
+	// events are retrieved.
+	// +optional
+	Filter *HorizonEventFilter `json:"filter,omitempty"`
+
+	// Delivery configures how events which could not be delivered to the
+	// sink are handled. Retry is the number of subsequent polls in which
+	// delivery is retried (defaults to 3) before the event is sent to
+	// DeadLetterSink. Without a DeadLetterSink such events are discarded.
+	// Other delivery options are not supported.
+	// +optional
+	Delivery *eventingduckv1.DeliverySpec `json:"delivery,omitempty"`
+
+	// Polling configures how Horizon events are retrieved.
+	// +optional
+	Polling HorizonPollingSpec `json:"polling"`
+
+	// Suspend scales the adapter to zero to pause event delivery. When the
+	// source is resumed, the adapter retrieves the events created after
+	// status.lastEventPosition.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// CloudEventMapping configures the CloudEvent type, source and subject
+	// of the Horizon events.
+	// +optional
+	CloudEventMapping *CloudEventMapping `json:"cloudEventMapping,omitempty"`
+}
+
+// HorizonPollingSpec configures how Horizon events are retrieved. The Horizon
+// API is polled every IntervalSeconds, when no new events are received the
+// delay between polls is doubled from MinBackoffSeconds up to
+// MaxBackoffSeconds.
+type HorizonPollingSpec struct {
+	// IntervalSeconds defaults to 1 second.
+	// +optional
+	IntervalSeconds int64 `json:"intervalSeconds,omitempty"`
+	// MinBackoffSeconds defaults to 1 second. It is also the initial delay
+	// between send retries.
+	// +optional
+	MinBackoffSeconds int64 `json:"minBackoffSeconds,omitempty"`
+	// MaxBackoffSeconds defaults to 5 seconds.
+	// +optional
+	MaxBackoffSeconds int64 `json:"maxBackoffSeconds,omitempty"`
+	// SendRetries is the number of times a failed send is retried within a
+	// poll before it is retried in the next poll. Defaults to 5.
+	// +optional
+	SendRetries *int32 `json:"sendRetries,omitempty"`
+}
+
+// HorizonEventFilter selects Horizon events by their properties. An event
+// matches if, for every specified property, it equals one of the given
+// values.
+type HorizonEventFilter struct {
+	// Types are Horizon event types, e.g. VLSI_USERLOGGEDIN.
+	// +optional
+	Types []string `json:"types,omitempty"`
+	// Severities are Horizon event severities: INFO, WARNING, ERROR,
+	// AUDIT_SUCCESS, AUDIT_FAIL or UNKNOWN.
+	// +optional
+	Severities []string `json:"severities,omitempty"`
+	// Modules are the Horizon components logging events, e.g. Broker.
+	// +optional
+	Modules []string `json:"modules,omitempty"`
+	// DesktopPoolNames are names of the desktop pools associated with events.
+	// +optional
+	DesktopPoolNames []string `json:"desktopPoolNames,omitempty"`
+	// ApplicationPoolNames are names of the application pools associated
+	// with events.
+	// +optional
+	ApplicationPoolNames []string `json:"applicationPoolNames,omitempty"`
 }
 
 // HorizonSourceStatus communicates the observed state of the HorizonSource (from the controller).
@@ -97,7 +177,37 @@ type HorizonSourceStatus struct {
 	//   state.
 	// * SinkURI - the current active sink URI that has been configured for the
 	//   Source.
+	// * SinkCACerts, SinkAudience - the CA certificates and OIDC audience of
+	//   the sink, if any.
 	duckv1.SourceStatus `json:",inline"`
+
+	// EventDeliveryStatus is reported by the adapter.
+	EventDeliveryStatus `json:",inline"`
+
+	// LastEventID is the ID of the last Horizon event delivered to the sink.
+	// +optional
+	LastEventID string `json:"lastEventID,omitempty"`
+
+	// LastEventPosition is the position of the adapter in the Horizon event
+	// stream, reported by the adapter to resume after the events processed
+	// before it was restarted or suspended.
+	// +optional
+	LastEventPosition *HorizonEventPosition `json:"lastEventPosition,omitempty"`
+
+	// DeadLetterSinkURI is the resolved URI of spec.delivery.deadLetterSink.
+	// +optional
+	DeadLetterSinkURI *apis.URL `json:"deadLetterSinkUri,omitempty"`
+}
+
+// HorizonEventPosition is a position in the Horizon event stream.
+type HorizonEventPosition struct {
+	// Time is the creation time of the last processed events in
+	// milliseconds since the Unix epoch.
+	Time int64 `json:"time"`
+
+	// EventIDs are the IDs of the processed events created at Time.
+	// +optional
+	EventIDs []int64 `json:"eventIDs,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
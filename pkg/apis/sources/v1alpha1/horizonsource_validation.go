@@ -7,11 +7,23 @@ package v1alpha1
 
 import (
 	"context"
+	"fmt"
 
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/pkg/apis"
-	"knative.dev/pkg/kmp"
+
+	"github.com/vmware-tanzu/sources-for-knative/pkg/horizon"
 )
 
+var validSeverities = map[string]bool{
+	horizon.SeverityInfo:         true,
+	horizon.SeverityWarning:      true,
+	horizon.SeverityError:        true,
+	horizon.SeverityAuditSuccess: true,
+	horizon.SeverityAuditFail:    true,
+	horizon.SeverityUnknown:      true,
+}
+
 // Validate validates HorizonSource.
 func (src *HorizonSource) Validate(ctx context.Context) *apis.FieldError {
 	var errs *apis.FieldError
@@ -19,19 +31,14 @@ func (src *HorizonSource) Validate(ctx context.Context) *apis.FieldError {
 	if apis.IsInUpdate(ctx) {
 		original := apis.GetBaseline(ctx).(*HorizonSource)
 
-		// all fields immutable
-		if diff, err := kmp.ShortDiff(original.Spec, src.Spec); err != nil {
-			return &apis.FieldError{
-				Message: "Failed to diff HorizonSource",
-				Paths:   []string{"spec"},
-				Details: err.Error(),
-			}
-		} else if diff != "" {
-			return &apis.FieldError{
+		// the adapter service account is bound to the adapter role, all other
+		// fields are rolled out to the adapter deployment
+		if original.Spec.ServiceAccountName != src.Spec.ServiceAccountName {
+			errs = errs.Also(&apis.FieldError{
 				Message: "Immutable fields changed (-old +new)",
-				Paths:   []string{"spec"},
-				Details: diff,
-			}
+				Paths:   []string{"spec.serviceAccountName"},
+				Details: fmt.Sprintf("-%q +%q", original.Spec.ServiceAccountName, src.Spec.ServiceAccountName),
+			})
 		}
 	}
 
@@ -50,6 +57,24 @@ func (spec *HorizonSourceSpec) Validate(ctx context.Context) *apis.FieldError {
 		errs = errs.Also(apis.ErrMissingField("serviceAccountName"))
 	}
 
+	if spec.MaxEventLagSeconds < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(spec.MaxEventLagSeconds, "maxEventLagSeconds"))
+	}
+
+	if spec.Filter != nil {
+		errs = errs.Also(spec.Filter.Validate(ctx).ViaField("filter"))
+	}
+
+	if spec.Delivery != nil {
+		errs = errs.Also(validateDelivery(ctx, spec.Delivery).ViaField("delivery"))
+	}
+
+	errs = errs.Also(spec.Polling.Validate(ctx).ViaField("polling"))
+
+	if spec.CloudEventMapping != nil {
+		errs = errs.Also(spec.CloudEventMapping.Validate(ctx).ViaField("cloudEventMapping"))
+	}
+
 	return errs
 }
 
@@ -63,3 +88,58 @@ func (auth *HorizonAuthSpec) Validate(ctx context.Context) (err *apis.FieldError
 	}
 	return err
 }
+
+// Validate implements apis.Validatable
+func (f *HorizonEventFilter) Validate(_ context.Context) (err *apis.FieldError) {
+	for field, values := range map[string][]string{
+		"types":                f.Types,
+		"severities":           f.Severities,
+		"modules":              f.Modules,
+		"desktopPoolNames":     f.DesktopPoolNames,
+		"applicationPoolNames": f.ApplicationPoolNames,
+	} {
+		for i, v := range values {
+			if v == "" {
+				err = err.Also(apis.ErrInvalidArrayValue(v, field, i))
+			}
+		}
+	}
+
+	for i, s := range f.Severities {
+		if s != "" && !validSeverities[s] {
+			err = err.Also(apis.ErrInvalidArrayValue(s, "severities", i))
+		}
+	}
+	return err
+}
+
+// Validate implements apis.Validatable
+func (p *HorizonPollingSpec) Validate(_ context.Context) (err *apis.FieldError) {
+	if p.IntervalSeconds < 0 {
+		err = err.Also(apis.ErrInvalidValue(p.IntervalSeconds, "intervalSeconds"))
+	}
+
+	err = err.Also(validateBackoff(p.MinBackoffSeconds, p.MaxBackoffSeconds))
+
+	if p.SendRetries != nil && *p.SendRetries < 0 {
+		err = err.Also(apis.ErrInvalidValue(*p.SendRetries, "sendRetries"))
+	}
+	return err
+}
+
+// validateDelivery validates the delivery options supported by the Horizon
+// adapter
+func validateDelivery(ctx context.Context, ds *eventingduckv1.DeliverySpec) *apis.FieldError {
+	errs := ds.Validate(ctx)
+
+	if ds.Timeout != nil {
+		errs = errs.Also(apis.ErrDisallowedFields("timeout"))
+	}
+	if ds.BackoffPolicy != nil {
+		errs = errs.Also(apis.ErrDisallowedFields("backoffPolicy"))
+	}
+	if ds.BackoffDelay != nil {
+		errs = errs.Also(apis.ErrDisallowedFields("backoffDelay"))
+	}
+	return errs
+}
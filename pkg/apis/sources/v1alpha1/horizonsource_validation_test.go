@@ -12,10 +12,14 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	corev1 "k8s.io/api/core/v1"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/ptr"
 	"knative.dev/pkg/webhook/resourcesemantics"
 
 	"knative.dev/pkg/apis"
+
+	"github.com/vmware-tanzu/sources-for-knative/pkg/cemapping"
 )
 
 var (
@@ -43,7 +47,7 @@ var (
 	}
 )
 
-func TestHorizonSourceImmutableFields(t *testing.T) {
+func TestHorizonSourceUpdate(t *testing.T) {
 	testCases := map[string]struct {
 		orig    *HorizonSourceSpec
 		updated HorizonSourceSpec
@@ -69,7 +73,7 @@ func TestHorizonSourceImmutableFields(t *testing.T) {
 				ServiceAccountName: fullSpec.ServiceAccountName,
 				HorizonAuthSpec:    fullSpec.HorizonAuthSpec,
 			},
-			allowed: false,
+			allowed: true,
 		},
 		"Sink.Name changed": {
 			orig: &fullSpec,
@@ -87,7 +91,7 @@ func TestHorizonSourceImmutableFields(t *testing.T) {
 				ServiceAccountName: fullSpec.ServiceAccountName,
 				HorizonAuthSpec:    fullSpec.HorizonAuthSpec,
 			},
-			allowed: false,
+			allowed: true,
 		},
 		"Sink.ApiVersion changed": {
 			orig: &fullSpec,
@@ -105,7 +109,7 @@ func TestHorizonSourceImmutableFields(t *testing.T) {
 				ServiceAccountName: fullSpec.ServiceAccountName,
 				HorizonAuthSpec:    fullSpec.HorizonAuthSpec,
 			},
-			allowed: false,
+			allowed: true,
 		},
 		"ServiceAccount changed": {
 			orig: &fullSpec,
@@ -130,7 +134,7 @@ func TestHorizonSourceImmutableFields(t *testing.T) {
 					SecretRef:     fullSpec.SecretRef,
 				},
 			},
-			allowed: false,
+			allowed: true,
 		},
 		"Auth.SkipTLSVerify changed": {
 			orig: &fullSpec,
@@ -143,7 +147,7 @@ func TestHorizonSourceImmutableFields(t *testing.T) {
 					SecretRef:     fullSpec.SecretRef,
 				},
 			},
-			allowed: false,
+			allowed: true,
 		},
 		"Auth.SecretRef changed": {
 			orig: &fullSpec,
@@ -156,8 +160,65 @@ func TestHorizonSourceImmutableFields(t *testing.T) {
 					SecretRef:     corev1.LocalObjectReference{Name: "changed"},
 				},
 			},
+			allowed: true,
+		},
+		"CloudEventOverrides changed": {
+			orig: &fullSpec,
+			updated: func() HorizonSourceSpec {
+				spec := *fullSpec.DeepCopy()
+				spec.CloudEventOverrides = &duckv1.CloudEventOverrides{
+					Extensions: map[string]string{"environment": "changed"},
+				}
+				return spec
+			}(),
+			allowed: true,
+		},
+		"Delivery changed": {
+			orig: &fullSpec,
+			updated: func() HorizonSourceSpec {
+				spec := *fullSpec.DeepCopy()
+				spec.Delivery = &eventingduckv1.DeliverySpec{
+					DeadLetterSink: &duckv1.Destination{URI: apis.HTTP("dls.example.com")},
+					Retry:          ptr.Int32(3),
+				}
+				return spec
+			}(),
+			allowed: true,
+		},
+		"Polling changed": {
+			orig: &fullSpec,
+			updated: func() HorizonSourceSpec {
+				spec := *fullSpec.DeepCopy()
+				spec.Polling = HorizonPollingSpec{
+					IntervalSeconds:   30,
+					MinBackoffSeconds: 2,
+					MaxBackoffSeconds: 60,
+				}
+				return spec
+			}(),
+			allowed: true,
+		},
+		"Polling changed to invalid": {
+			orig: &fullSpec,
+			updated: func() HorizonSourceSpec {
+				spec := *fullSpec.DeepCopy()
+				spec.Polling = HorizonPollingSpec{IntervalSeconds: -1}
+				return spec
+			}(),
 			allowed: false,
 		},
+		"Filter changed": {
+			orig: &fullSpec,
+			updated: func() HorizonSourceSpec {
+				spec := *fullSpec.DeepCopy()
+				spec.Filter = &HorizonEventFilter{
+					Severities:       []string{"ERROR"},
+					DesktopPoolNames: []string{"pool-01"},
+				}
+				return spec
+			}(),
+			allowed: true,
+		},
 	}
 
 	for n, tc := range testCases {
@@ -175,7 +236,7 @@ func TestHorizonSourceImmutableFields(t *testing.T) {
 			}
 			err := updated.Validate(ctx)
 			if tc.allowed != (err == nil) {
-				t.Fatalf("Unexpected immutable field check. Expected %v. Actual %v", tc.allowed, err)
+				t.Fatalf("Unexpected update check. Expected %v. Actual %v", tc.allowed, err)
 			}
 		})
 	}
@@ -256,6 +317,130 @@ func TestHorizonSourceValidation(t *testing.T) {
 				return errs
 			}(),
 		},
+		"invalid filter": {
+			cr: &HorizonSource{
+				Spec: HorizonSourceSpec{
+					SourceSpec: duckv1.SourceSpec{
+						Sink: newDestination(),
+					},
+					ServiceAccountName: "default",
+					HorizonAuthSpec: HorizonAuthSpec{
+						Address:   newHorizonAddress(),
+						SecretRef: newSecretRef(),
+					},
+					Filter: &HorizonEventFilter{
+						Severities:       []string{"AUDIT_FAIL", "CRITICAL"},
+						DesktopPoolNames: []string{""},
+					},
+				},
+			},
+			want: func() *apis.FieldError {
+				var errs *apis.FieldError
+
+				errs = errs.Also(apis.ErrInvalidArrayValue("CRITICAL", "severities", 1).ViaField("filter").ViaField("spec"))
+				errs = errs.Also(apis.ErrInvalidArrayValue("", "desktopPoolNames", 0).ViaField("filter").ViaField("spec"))
+
+				return errs
+			}(),
+		},
+		"unsupported delivery options": {
+			cr: &HorizonSource{
+				Spec: HorizonSourceSpec{
+					SourceSpec: duckv1.SourceSpec{
+						Sink: newDestination(),
+					},
+					ServiceAccountName: "default",
+					HorizonAuthSpec: HorizonAuthSpec{
+						Address:   newHorizonAddress(),
+						SecretRef: newSecretRef(),
+					},
+					Delivery: &eventingduckv1.DeliverySpec{
+						DeadLetterSink: &duckv1.Destination{URI: apis.HTTP("dls.example.com")},
+						Retry:          ptr.Int32(-1),
+						BackoffDelay:   ptr.String("PT1S"),
+					},
+				},
+			},
+			want: func() *apis.FieldError {
+				var errs *apis.FieldError
+
+				errs = errs.Also(apis.ErrInvalidValue(-1, "retry").ViaField("delivery").ViaField("spec"))
+				errs = errs.Also(apis.ErrDisallowedFields("backoffDelay").ViaField("delivery").ViaField("spec"))
+
+				return errs
+			}(),
+		},
+		"invalid polling": {
+			cr: &HorizonSource{
+				Spec: HorizonSourceSpec{
+					SourceSpec: duckv1.SourceSpec{
+						Sink: newDestination(),
+					},
+					ServiceAccountName: "default",
+					HorizonAuthSpec: HorizonAuthSpec{
+						Address:   newHorizonAddress(),
+						SecretRef: newSecretRef(),
+					},
+					Polling: HorizonPollingSpec{
+						IntervalSeconds:   -1,
+						MinBackoffSeconds: -1,
+						SendRetries:       ptr.Int32(-1),
+					},
+				},
+			},
+			want: func() *apis.FieldError {
+				var errs *apis.FieldError
+
+				errs = errs.Also(apis.ErrInvalidValue(-1, "intervalSeconds").ViaField("polling").ViaField("spec"))
+				errs = errs.Also(apis.ErrInvalidValue(-1, "minBackoffSeconds").ViaField("polling").ViaField("spec"))
+				errs = errs.Also(apis.ErrInvalidValue(-1, "sendRetries").ViaField("polling").ViaField("spec"))
+
+				return errs
+			}(),
+		},
+		"valid filter": {
+			cr: &HorizonSource{
+				Spec: HorizonSourceSpec{
+					SourceSpec: duckv1.SourceSpec{
+						Sink: newDestination(),
+					},
+					ServiceAccountName: "default",
+					HorizonAuthSpec: HorizonAuthSpec{
+						Address:   newHorizonAddress(),
+						SecretRef: newSecretRef(),
+					},
+					Filter: &HorizonEventFilter{
+						Severities:       []string{"AUDIT_FAIL", "ERROR"},
+						DesktopPoolNames: []string{"pool-01"},
+					},
+				},
+			},
+			want: func() *apis.FieldError {
+				return nil
+			}(),
+		},
+		"invalid cloudEventMapping": {
+			cr: &HorizonSource{
+				Spec: HorizonSourceSpec{
+					SourceSpec: duckv1.SourceSpec{
+						Sink: newDestination(),
+					},
+					ServiceAccountName: "default",
+					HorizonAuthSpec: HorizonAuthSpec{
+						Address:   newHorizonAddress(),
+						SecretRef: newSecretRef(),
+					},
+					CloudEventMapping: &CloudEventMapping{
+						Type:    "com.example.{{ lower .Type }}",
+						Subject: "{{ .Entity",
+					},
+				},
+			},
+			want: func() *apis.FieldError {
+				return apis.ErrInvalidValue("{{ .Entity", "spec.cloudEventMapping.subject",
+					cemapping.ValidateEventTemplate("{{ .Entity").Error())
+			}(),
+		},
 		"valid spec": {
 			cr: &HorizonSource{
 				Spec: HorizonSourceSpec{
@@ -40,6 +40,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&VSphereBindingList{},
 		&HorizonSource{},
 		&HorizonSourceList{},
+		&HorizonBinding{},
+		&HorizonBindingList{},
+		&VSphereActionSink{},
+		&VSphereActionSinkList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
@@ -0,0 +1,21 @@
+/*
+Copyright 2020 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+)
+
+// SetDefaults implements apis.Defaultable
+func (as *VSphereActionSink) SetDefaults(ctx context.Context) {
+	for i := range as.Spec.Actions {
+		action := &as.Spec.Actions[i]
+		if action.Name == "" {
+			action.Name = fmt.Sprintf("%s-%d", action.Operation, i)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+/*
+Copyright 2020 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestVSphereActionSinkDefaulting(t *testing.T) {
+	as := &VSphereActionSink{
+		Spec: VSphereActionSinkSpec{
+			Actions: []VSphereAction{{
+				Operation: VSphereActionPowerOn,
+			}, {
+				Name:      "reset",
+				Operation: VSphereActionReset,
+			}},
+		},
+	}
+	as.SetDefaults(context.Background())
+
+	want := []VSphereAction{{
+		Name:      "vm.powerOn-0",
+		Operation: VSphereActionPowerOn,
+	}, {
+		Name:      "reset",
+		Operation: VSphereActionReset,
+	}}
+	if diff := cmp.Diff(want, as.Spec.Actions); diff != "" {
+		t.Errorf("SetDefaults (-want, +got) = %v", diff)
+	}
+}
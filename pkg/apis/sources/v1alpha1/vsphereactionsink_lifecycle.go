@@ -0,0 +1,81 @@
+/*
+Copyright 2020 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1alpha1
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+var actionSinkCondSet = apis.NewLivingConditionSet(
+	VSphereActionSinkConditionAuthReady,
+	VSphereActionSinkConditionAdapterReady,
+	VSphereActionSinkConditionAddressable,
+)
+
+// GetConditionSet retrieves the condition set for this resource.
+// Implements the KRShaped interface.
+func (*VSphereActionSink) GetConditionSet() apis.ConditionSet {
+	return actionSinkCondSet
+}
+
+// GetGroupVersionKind implements kmeta.OwnerRefable
+func (as *VSphereActionSink) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("VSphereActionSink")
+}
+
+func (ass *VSphereActionSinkStatus) InitializeConditions() {
+	actionSinkCondSet.Manage(ass).InitializeConditions()
+}
+
+func (ass *VSphereActionSinkStatus) PropagateAuthStatus(status duckv1.Status) {
+	cond := status.GetCondition(apis.ConditionReady)
+	switch {
+	case cond == nil:
+		actionSinkCondSet.Manage(ass).MarkUnknown(VSphereActionSinkConditionAuthReady, "", "")
+	case cond.Status == corev1.ConditionUnknown:
+		actionSinkCondSet.Manage(ass).MarkUnknown(VSphereActionSinkConditionAuthReady, cond.Reason, cond.Message)
+	case cond.Status == corev1.ConditionFalse:
+		actionSinkCondSet.Manage(ass).MarkFalse(VSphereActionSinkConditionAuthReady, cond.Reason, cond.Message)
+	case cond.Status == corev1.ConditionTrue:
+		actionSinkCondSet.Manage(ass).MarkTrue(VSphereActionSinkConditionAuthReady)
+	}
+}
+
+func (ass *VSphereActionSinkStatus) PropagateAdapterStatus(d appsv1.DeploymentStatus) {
+	// Check if the Deployment is available.
+	for _, cond := range d.Conditions {
+		if cond.Type == appsv1.DeploymentAvailable {
+			switch {
+			case cond.Status == corev1.ConditionUnknown:
+				actionSinkCondSet.Manage(ass).MarkUnknown(VSphereActionSinkConditionAdapterReady, cond.Reason, cond.Message)
+			case cond.Status == corev1.ConditionFalse:
+				actionSinkCondSet.Manage(ass).MarkFalse(VSphereActionSinkConditionAdapterReady, cond.Reason, cond.Message)
+			case cond.Status == corev1.ConditionTrue:
+				actionSinkCondSet.Manage(ass).MarkTrue(VSphereActionSinkConditionAdapterReady)
+			}
+			return
+		}
+	}
+
+	actionSinkCondSet.Manage(ass).MarkUnknown(VSphereActionSinkConditionAdapterReady, "", "")
+}
+
+// MarkAddress sets the address of the VSphereActionSink and marks it
+// Addressable when the URL is not empty.
+func (ass *VSphereActionSinkStatus) MarkAddress(url *apis.URL) {
+	if url == nil || url.Host == "" {
+		ass.Address = nil
+		actionSinkCondSet.Manage(ass).MarkFalse(VSphereActionSinkConditionAddressable, "EmptyHostname", "Hostname is empty.")
+		return
+	}
+
+	ass.Address = &duckv1.Addressable{URL: url}
+	actionSinkCondSet.Manage(ass).MarkTrue(VSphereActionSinkConditionAddressable)
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2020 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/apis/duck"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	apistest "knative.dev/pkg/apis/testing"
+)
+
+func TestVSphereActionSinkDuckTypes(t *testing.T) {
+	tests := []struct {
+		name string
+		t    duck.Implementable
+	}{{
+		name: "conditions",
+		t:    &duckv1.Conditions{},
+	}, {
+		name: "addressable",
+		t:    &duckv1.Addressable{},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := duck.VerifyType(&VSphereActionSink{}, test.t)
+			if err != nil {
+				t.Errorf("VerifyType(VSphereActionSink, %T) = %v", test.t, err)
+			}
+		})
+	}
+}
+
+func TestVSphereActionSinkGetGroupVersionKind(t *testing.T) {
+	r := &VSphereActionSink{}
+	want := schema.GroupVersionKind{
+		Group:   "sources.tanzu.vmware.com",
+		Version: "v1alpha1",
+		Kind:    "VSphereActionSink",
+	}
+	if got := r.GetGroupVersionKind(); got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
+
+func TestTypicalActionSinkFlow(t *testing.T) {
+	r := &VSphereActionSinkStatus{}
+	r.InitializeConditions()
+	apistest.CheckConditionOngoing(r, VSphereActionSinkConditionReady, t)
+
+	r.PropagateAuthStatus(duckv1.Status{
+		Conditions: []apis.Condition{{
+			Type:   apis.ConditionReady,
+			Status: corev1.ConditionFalse,
+		}},
+	})
+	apistest.CheckConditionFailed(r, VSphereActionSinkConditionAuthReady, t)
+	apistest.CheckConditionFailed(r, VSphereActionSinkConditionReady, t)
+	r.PropagateAuthStatus(duckv1.Status{
+		Conditions: []apis.Condition{{
+			Type:   apis.ConditionReady,
+			Status: corev1.ConditionTrue,
+		}},
+	})
+	apistest.CheckConditionSucceeded(r, VSphereActionSinkConditionAuthReady, t)
+
+	r.PropagateAdapterStatus(appsv1.DeploymentStatus{})
+	apistest.CheckConditionOngoing(r, VSphereActionSinkConditionAdapterReady, t)
+	r.PropagateAdapterStatus(appsv1.DeploymentStatus{
+		Conditions: []appsv1.DeploymentCondition{{
+			Type:   appsv1.DeploymentAvailable,
+			Status: corev1.ConditionTrue,
+		}},
+	})
+	apistest.CheckConditionSucceeded(r, VSphereActionSinkConditionAdapterReady, t)
+	apistest.CheckConditionOngoing(r, VSphereActionSinkConditionReady, t)
+
+	// An empty host is not addressable.
+	r.MarkAddress(&apis.URL{Scheme: "http"})
+	apistest.CheckConditionFailed(r, VSphereActionSinkConditionAddressable, t)
+	if r.Address != nil {
+		t.Errorf("Address = %v, wanted nil", r.Address)
+	}
+
+	r.MarkAddress(&apis.URL{Scheme: "http", Host: "sink.default.svc.cluster.local"})
+	apistest.CheckConditionSucceeded(r, VSphereActionSinkConditionAddressable, t)
+
+	// After all of that, we're finally ready!
+	apistest.CheckConditionSucceeded(r, VSphereActionSinkConditionReady, t)
+}
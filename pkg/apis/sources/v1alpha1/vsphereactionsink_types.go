@@ -0,0 +1,165 @@
+/*
+Copyright 2020 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/kmeta"
+)
+
+// +genclient
+// +genreconciler
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// VSphereActionSink is an addressable sink which performs vSphere operations
+// for the CloudEvents it receives and replies with their result.
+type VSphereActionSink struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec holds the desired state of the VSphereActionSink (from the client).
+	// +optional
+	Spec VSphereActionSinkSpec `json:"spec,omitempty"`
+
+	// Status communicates the observed state of the VSphereActionSink (from the controller).
+	// +optional
+	Status VSphereActionSinkStatus `json:"status,omitempty"`
+}
+
+// Check that VSphereActionSink can be validated, defaulted and addressed.
+var _ apis.Validatable = (*VSphereActionSink)(nil)
+var _ apis.Defaultable = (*VSphereActionSink)(nil)
+var _ kmeta.OwnerRefable = (*VSphereActionSink)(nil)
+var _ duckv1.KRShaped = (*VSphereActionSink)(nil)
+
+// VSphereActionSinkSpec holds the desired state of the VSphereActionSink (from the client).
+type VSphereActionSinkSpec struct {
+	VAuthSpec `json:",inline"`
+
+	// Actions are performed in order for every received CloudEvent they match.
+	Actions []VSphereAction `json:"actions"`
+
+	// ServiceAccountName holds the name of the Kubernetes service account
+	// as which the underlying K8s resources should be run. If unspecified
+	// this will default to the "default" service account for the namespace
+	// in which the VSphereActionSink exists.
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+}
+
+// VSphereActionOperation is a vSphere operation performed by a VSphereAction.
+type VSphereActionOperation string
+
+const (
+	// VSphereActionTagAttach attaches the tag to the virtual machine.
+	VSphereActionTagAttach VSphereActionOperation = "tag.attach"
+	// VSphereActionTagDetach detaches the tag from the virtual machine.
+	VSphereActionTagDetach VSphereActionOperation = "tag.detach"
+	// VSphereActionPowerOn powers on the virtual machine.
+	VSphereActionPowerOn VSphereActionOperation = "vm.powerOn"
+	// VSphereActionPowerOff powers off the virtual machine.
+	VSphereActionPowerOff VSphereActionOperation = "vm.powerOff"
+	// VSphereActionSuspend suspends the virtual machine.
+	VSphereActionSuspend VSphereActionOperation = "vm.suspend"
+	// VSphereActionReset resets the virtual machine.
+	VSphereActionReset VSphereActionOperation = "vm.reset"
+	// VSphereActionSnapshotCreate creates a snapshot of the virtual machine.
+	VSphereActionSnapshotCreate VSphereActionOperation = "snapshot.create"
+	// VSphereActionCustomAttributeSet sets a custom attribute on the virtual
+	// machine.
+	VSphereActionCustomAttributeSet VSphereActionOperation = "customAttribute.set"
+)
+
+// VSphereAction is a vSphere operation performed on the virtual machine of
+// a received vSphere event.
+type VSphereAction struct {
+	// Name identifies the action in replies.
+	Name string `json:"name"`
+
+	// Type restricts the action to CloudEvents of this type, e.g.
+	// com.vmware.vsphere.VmCreatedEvent.v0. If empty, all events match.
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// Operation is the vSphere operation to perform.
+	Operation VSphereActionOperation `json:"operation"`
+
+	// Tag is the name or ID of the tag for tag operations.
+	// +optional
+	Tag string `json:"tag,omitempty"`
+
+	// Snapshot configures the snapshot.create operation.
+	// +optional
+	Snapshot *VSphereSnapshotAction `json:"snapshot,omitempty"`
+
+	// CustomAttribute configures the customAttribute.set operation.
+	// +optional
+	CustomAttribute *VSphereCustomAttributeAction `json:"customAttribute,omitempty"`
+}
+
+// VSphereSnapshotAction configures the snapshot.create operation.
+type VSphereSnapshotAction struct {
+	// Name of the snapshot.
+	Name string `json:"name"`
+	// Description of the snapshot.
+	// +optional
+	Description string `json:"description,omitempty"`
+	// Memory includes the memory of the virtual machine in the snapshot.
+	// +optional
+	Memory bool `json:"memory,omitempty"`
+	// Quiesce quiesces the guest file system before taking the snapshot.
+	// +optional
+	Quiesce bool `json:"quiesce,omitempty"`
+}
+
+// VSphereCustomAttributeAction configures the customAttribute.set operation.
+type VSphereCustomAttributeAction struct {
+	// Name of the custom attribute, which must exist.
+	Name string `json:"name"`
+	// Value to set.
+	Value string `json:"value"`
+}
+
+const (
+	// VSphereActionSinkConditionReady is set to reflect the overall state of the resource.
+	VSphereActionSinkConditionReady = apis.ConditionReady
+
+	// VSphereActionSinkConditionAuthReady is set to reflect the state of the auth part of the VSphereActionSink.
+	VSphereActionSinkConditionAuthReady = "AuthReady"
+
+	// VSphereActionSinkConditionAdapterReady is set to reflect the state of the adapter part of the VSphereActionSink.
+	VSphereActionSinkConditionAdapterReady = "AdapterReady"
+
+	// VSphereActionSinkConditionAddressable is set to reflect whether the VSphereActionSink has an address.
+	VSphereActionSinkConditionAddressable = "Addressable"
+)
+
+// VSphereActionSinkStatus communicates the observed state of the VSphereActionSink (from the controller).
+type VSphereActionSinkStatus struct {
+	duckv1.Status `json:",inline"`
+
+	// AddressStatus is the address at which the VSphereActionSink receives
+	// CloudEvents.
+	duckv1.AddressStatus `json:",inline"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// VSphereActionSinkList is a list of VSphereActionSink resources
+type VSphereActionSinkList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []VSphereActionSink `json:"items"`
+}
+
+// GetStatus retrieves the status of the VSphereActionSink. Implements the KRShaped interface.
+func (as *VSphereActionSink) GetStatus() *duckv1.Status {
+	return &as.Status.Status
+}
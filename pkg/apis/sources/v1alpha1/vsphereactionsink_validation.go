@@ -0,0 +1,79 @@
+/*
+Copyright 2020 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"knative.dev/pkg/apis"
+)
+
+// Validate implements apis.Validatable
+func (as *VSphereActionSink) Validate(ctx context.Context) *apis.FieldError {
+	return as.Spec.Validate(ctx).ViaField("spec")
+}
+
+// Validate implements apis.Validatable
+func (ass *VSphereActionSinkSpec) Validate(ctx context.Context) *apis.FieldError {
+	errs := ass.VAuthSpec.Validate(ctx)
+
+	if len(ass.Actions) == 0 {
+		errs = errs.Also(apis.ErrMissingField("actions"))
+	}
+
+	names := make(map[string]struct{}, len(ass.Actions))
+	for i, action := range ass.Actions {
+		errs = errs.Also(action.Validate(ctx).ViaFieldIndex("actions", i))
+
+		if _, ok := names[action.Name]; ok {
+			errs = errs.Also(apis.ErrGeneric("duplicate action name", "name").ViaFieldIndex("actions", i))
+		}
+		names[action.Name] = struct{}{}
+	}
+	return errs
+}
+
+// Validate implements apis.Validatable
+func (va *VSphereAction) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if va.Name == "" {
+		errs = errs.Also(apis.ErrMissingField("name"))
+	}
+
+	switch va.Operation {
+	case VSphereActionTagAttach, VSphereActionTagDetach:
+		if va.Tag == "" {
+			errs = errs.Also(apis.ErrMissingField("tag"))
+		}
+	case VSphereActionPowerOn, VSphereActionPowerOff, VSphereActionSuspend, VSphereActionReset:
+	case VSphereActionSnapshotCreate:
+		if va.Snapshot == nil {
+			errs = errs.Also(apis.ErrMissingField("snapshot"))
+		} else if va.Snapshot.Name == "" {
+			errs = errs.Also(apis.ErrMissingField("snapshot.name"))
+		}
+	case VSphereActionCustomAttributeSet:
+		if va.CustomAttribute == nil {
+			errs = errs.Also(apis.ErrMissingField("customAttribute"))
+		} else if va.CustomAttribute.Name == "" {
+			errs = errs.Also(apis.ErrMissingField("customAttribute.name"))
+		}
+	case "":
+		errs = errs.Also(apis.ErrMissingField("operation"))
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(va.Operation, "operation"))
+	}
+
+	if va.Tag != "" && va.Operation != VSphereActionTagAttach && va.Operation != VSphereActionTagDetach {
+		errs = errs.Also(apis.ErrDisallowedFields("tag"))
+	}
+	if va.Snapshot != nil && va.Operation != VSphereActionSnapshotCreate {
+		errs = errs.Also(apis.ErrDisallowedFields("snapshot"))
+	}
+	if va.CustomAttribute != nil && va.Operation != VSphereActionCustomAttributeSet {
+		errs = errs.Also(apis.ErrDisallowedFields("customAttribute"))
+	}
+	return errs
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2020 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestVSphereActionSinkValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		actions []VSphereAction
+		want    *apis.FieldError
+	}{{
+		name: "valid",
+		actions: []VSphereAction{{
+			Name:      "tag",
+			Type:      "com.vmware.vsphere.VmCreatedEvent.v0",
+			Operation: VSphereActionTagAttach,
+			Tag:       "created",
+		}, {
+			Name:      "power-on",
+			Operation: VSphereActionPowerOn,
+		}, {
+			Name:      "snapshot",
+			Operation: VSphereActionSnapshotCreate,
+			Snapshot:  &VSphereSnapshotAction{Name: "backup"},
+		}, {
+			Name:            "owner",
+			Operation:       VSphereActionCustomAttributeSet,
+			CustomAttribute: &VSphereCustomAttributeAction{Name: "owner", Value: "knative"},
+		}},
+		want: nil,
+	}, {
+		name: "missing actions",
+		want: apis.ErrMissingField("spec.actions"),
+	}, {
+		name: "missing operation",
+		actions: []VSphereAction{{
+			Name: "nothing",
+		}},
+		want: apis.ErrMissingField("spec.actions[0].operation"),
+	}, {
+		name: "unknown operation",
+		actions: []VSphereAction{{
+			Name:      "destroy",
+			Operation: "vm.destroy",
+		}},
+		want: apis.ErrInvalidValue("vm.destroy", "spec.actions[0].operation"),
+	}, {
+		name: "missing tag",
+		actions: []VSphereAction{{
+			Name:      "tag",
+			Operation: VSphereActionTagDetach,
+		}},
+		want: apis.ErrMissingField("spec.actions[0].tag"),
+	}, {
+		name: "missing snapshot name",
+		actions: []VSphereAction{{
+			Name:      "snapshot",
+			Operation: VSphereActionSnapshotCreate,
+			Snapshot:  &VSphereSnapshotAction{},
+		}},
+		want: apis.ErrMissingField("spec.actions[0].snapshot.name"),
+	}, {
+		name: "missing custom attribute",
+		actions: []VSphereAction{{
+			Name:      "owner",
+			Operation: VSphereActionCustomAttributeSet,
+		}},
+		want: apis.ErrMissingField("spec.actions[0].customAttribute"),
+	}, {
+		name: "settings of another operation",
+		actions: []VSphereAction{{
+			Name:      "power-off",
+			Operation: VSphereActionPowerOff,
+			Tag:       "off",
+		}},
+		want: apis.ErrDisallowedFields("spec.actions[0].tag"),
+	}, {
+		name: "duplicate names",
+		actions: []VSphereAction{{
+			Name:      "power",
+			Operation: VSphereActionPowerOn,
+		}, {
+			Name:      "power",
+			Operation: VSphereActionReset,
+		}},
+		want: apis.ErrGeneric("duplicate action name", "spec.actions[1].name"),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &VSphereActionSink{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "valid",
+					Namespace: "default",
+				},
+				Spec: VSphereActionSinkSpec{
+					VAuthSpec: validVAuthSpec,
+					Actions:   test.actions,
+				},
+			}
+			got := c.Validate(context.Background())
+			if !cmp.Equal(test.want.Error(), got.Error()) {
+				t.Errorf("Validate (-want, +got) = %v",
+					cmp.Diff(test.want.Error(), got.Error()))
+			}
+		})
+	}
+}
@@ -8,9 +8,11 @@ package v1alpha1
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/apis/duck"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
@@ -21,6 +23,11 @@ import (
 
 var vsbCondSet = apis.NewLivingConditionSet()
 
+// VSphereBindingEnvAnnotation lists the environment variables a VSphereBinding
+// injected into the bound containers, so that Undo removes them even after the
+// binding's env names changed.
+const VSphereBindingEnvAnnotation = "vspherebindings.sources.tanzu.vmware.com/env"
+
 // GetGroupVersionKind returns the GroupVersionKind.
 func (vsb *VSphereBinding) GetGroupVersionKind() schema.GroupVersionKind {
 	return SchemeGroupVersion.WithKind("VSphereBinding")
@@ -63,6 +70,147 @@ func (sbs *VSphereBindingStatus) MarkBindingAvailable() {
 	vsbCondSet.Manage(sbs).MarkTrue(VSphereBindingConditionReady)
 }
 
+// vsbEnvNames holds the resolved names of the environment variables injected
+// by a VSphereBinding. Empty names are not injected.
+type vsbEnvNames struct {
+	URL        string
+	Insecure   string
+	Username   string
+	Password   string
+	SecretPath string
+
+	// hostOnly injects only the host of the address instead of the full URL.
+	hostOnly bool
+}
+
+var vsbEnvPresets = map[VSphereBindingEnvPreset]vsbEnvNames{
+	VSphereBindingEnvPresetDefault: {
+		URL:      "VC_URL",
+		Insecure: "VC_INSECURE",
+		Username: "VC_USERNAME",
+		Password: "VC_PASSWORD",
+	},
+	VSphereBindingEnvPresetGovc: {
+		URL:      "GOVC_URL",
+		Insecure: "GOVC_INSECURE",
+		Username: "GOVC_USERNAME",
+		Password: "GOVC_PASSWORD",
+	},
+	VSphereBindingEnvPresetPowerCLI: {
+		URL:      "VI_SERVER",
+		Insecure: "VI_INSECURE",
+		Username: "VI_USERNAME",
+		Password: "VI_PASSWORD",
+	},
+	VSphereBindingEnvPresetTerraform: {
+		URL:      "VSPHERE_SERVER",
+		Insecure: "VSPHERE_ALLOW_UNVERIFIED_SSL",
+		Username: "VSPHERE_USER",
+		Password: "VSPHERE_PASSWORD",
+		hostOnly: true,
+	},
+}
+
+// mountPath returns the configured mount path of the secret.
+func (vsb *VSphereBinding) mountPath() string {
+	if vsb.Spec.MountPath != "" {
+		return vsb.Spec.MountPath
+	}
+	return vsphere.DefaultMountPath
+}
+
+// envNames resolves the environment variable names to inject.
+func (vsb *VSphereBinding) envNames() vsbEnvNames {
+	preset := VSphereBindingEnvPresetDefault
+	if vsb.Spec.Env != nil && vsb.Spec.Env.Preset != "" {
+		preset = vsb.Spec.Env.Preset
+	}
+
+	if preset == VSphereBindingEnvPresetCustom {
+		return vsbEnvNames{
+			URL:        vsb.Spec.Env.URL,
+			Insecure:   vsb.Spec.Env.Insecure,
+			Username:   vsb.Spec.Env.Username,
+			Password:   vsb.Spec.Env.Password,
+			SecretPath: vsb.Spec.Env.SecretPath,
+		}
+	}
+
+	names := vsbEnvPresets[preset]
+	// The vsphere package reads the secret from VC_SECRET_PATH when it is not
+	// mounted at its default location.
+	if preset == VSphereBindingEnvPresetDefault && vsb.mountPath() != vsphere.DefaultMountPath {
+		names.SecretPath = "VC_SECRET_PATH"
+	}
+	return names
+}
+
+// selects returns whether the container with the given name is bound.
+func (vsb *VSphereBinding) selects(name string) bool {
+	if len(vsb.Spec.ContainerNames) == 0 {
+		return true
+	}
+	for _, n := range vsb.Spec.ContainerNames {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// env returns the environment variables to inject into bound containers.
+func (vsb *VSphereBinding) env() []corev1.EnvVar {
+	names := vsb.envNames()
+	secretKeyRef := func(key string) *corev1.EnvVarSource {
+		return &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: vsb.Spec.SecretRef.Name,
+				},
+				Key: key,
+			},
+		}
+	}
+
+	address := vsb.Spec.Address.String()
+	if names.hostOnly {
+		address = vsb.Spec.Address.Host
+	}
+
+	var env []corev1.EnvVar
+	if names.URL != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  names.URL,
+			Value: address,
+		})
+	}
+	if names.Insecure != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  names.Insecure,
+			Value: fmt.Sprintf("%v", vsb.Spec.SkipTLSVerify),
+		})
+	}
+	if names.Username != "" {
+		env = append(env, corev1.EnvVar{
+			Name:      names.Username,
+			ValueFrom: secretKeyRef(corev1.BasicAuthUsernameKey),
+		})
+	}
+	if names.Password != "" {
+		env = append(env, corev1.EnvVar{
+			Name:      names.Password,
+			ValueFrom: secretKeyRef(corev1.BasicAuthPasswordKey),
+		})
+	}
+	if names.SecretPath != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  names.SecretPath,
+			Value: vsb.mountPath(),
+		})
+	}
+	return env
+}
+
 // Do implements psbinding.Bindable
 func (vsb *VSphereBinding) Do(ctx context.Context, ps *duckv1.WithPod) {
 	// First undo so that we can just unconditionally append below.
@@ -79,77 +227,71 @@ func (vsb *VSphereBinding) Do(ctx context.Context, ps *duckv1.WithPod) {
 	}
 	ps.Spec.Template.Spec.Volumes = append(ps.Spec.Template.Spec.Volumes, volume)
 
-	// Make sure that each [init]container in the PodSpec has a VolumeMount like this:
+	// Make sure that each selected [init]container in the PodSpec has a
+	// VolumeMount like this:
 	volumeMount := corev1.VolumeMount{
 		Name:      vsphere.VolumeName,
 		ReadOnly:  true,
-		MountPath: vsphere.DefaultMountPath,
+		MountPath: vsb.mountPath(),
 	}
 
+	env := vsb.env()
 	spec := ps.Spec.Template.Spec
 	for i := range spec.InitContainers {
+		if !vsb.selects(spec.InitContainers[i].Name) {
+			continue
+		}
 		spec.InitContainers[i].VolumeMounts = append(spec.InitContainers[i].VolumeMounts, volumeMount)
-		spec.InitContainers[i].Env = append(spec.InitContainers[i].Env, corev1.EnvVar{
-			Name:  "VC_URL",
-			Value: vsb.Spec.Address.String(),
-		}, corev1.EnvVar{
-			Name:  "VC_INSECURE",
-			Value: fmt.Sprintf("%v", vsb.Spec.SkipTLSVerify),
-		}, corev1.EnvVar{
-			Name: "VC_USERNAME",
-			ValueFrom: &corev1.EnvVarSource{
-				SecretKeyRef: &corev1.SecretKeySelector{
-					LocalObjectReference: corev1.LocalObjectReference{
-						Name: vsb.Spec.SecretRef.Name,
-					},
-					Key: corev1.BasicAuthUsernameKey,
-				},
-			},
-		}, corev1.EnvVar{
-			Name: "VC_PASSWORD",
-			ValueFrom: &corev1.EnvVarSource{
-				SecretKeyRef: &corev1.SecretKeySelector{
-					LocalObjectReference: corev1.LocalObjectReference{
-						Name: vsb.Spec.SecretRef.Name,
-					},
-					Key: corev1.BasicAuthPasswordKey,
-				},
-			},
-		})
+		spec.InitContainers[i].Env = append(spec.InitContainers[i].Env, env...)
 	}
 	for i := range spec.Containers {
+		if !vsb.selects(spec.Containers[i].Name) {
+			continue
+		}
 		spec.Containers[i].VolumeMounts = append(spec.Containers[i].VolumeMounts, volumeMount)
-		spec.Containers[i].Env = append(spec.Containers[i].Env, corev1.EnvVar{
-			Name:  "VC_URL",
-			Value: vsb.Spec.Address.String(),
-		}, corev1.EnvVar{
-			Name:  "VC_INSECURE",
-			Value: fmt.Sprintf("%v", vsb.Spec.SkipTLSVerify),
-		}, corev1.EnvVar{
-			Name: "VC_USERNAME",
-			ValueFrom: &corev1.EnvVarSource{
-				SecretKeyRef: &corev1.SecretKeySelector{
-					LocalObjectReference: corev1.LocalObjectReference{
-						Name: vsb.Spec.SecretRef.Name,
-					},
-					Key: corev1.BasicAuthUsernameKey,
-				},
-			},
-		}, corev1.EnvVar{
-			Name: "VC_PASSWORD",
-			ValueFrom: &corev1.EnvVarSource{
-				SecretKeyRef: &corev1.SecretKeySelector{
-					LocalObjectReference: corev1.LocalObjectReference{
-						Name: vsb.Spec.SecretRef.Name,
-					},
-					Key: corev1.BasicAuthPasswordKey,
-				},
-			},
-		})
+		spec.Containers[i].Env = append(spec.Containers[i].Env, env...)
+	}
+
+	// Remember what was injected for Undo.
+	names := make([]string, 0, len(env))
+	for _, ev := range env {
+		names = append(names, ev.Name)
 	}
+	if ps.Spec.Template.Annotations == nil {
+		ps.Spec.Template.Annotations = make(map[string]string, 1)
+	}
+	ps.Spec.Template.Annotations[VSphereBindingEnvAnnotation] = strings.Join(names, ",")
 }
 
+// injectedEnv returns the names of the environment variables injected into the
+// given PodSpecable and whether they were recorded by Do. PodSpecables bound
+// before VSphereBindingEnvAnnotation was introduced carry the default or the
+// current names.
+func (vsb *VSphereBinding) injectedEnv(ps *duckv1.WithPod) (sets.String, bool) {
+	if names, ok := ps.Spec.Template.Annotations[VSphereBindingEnvAnnotation]; ok {
+		injected := sets.NewString(strings.Split(names, ",")...)
+		injected.Delete("")
+		return injected, true
+	}
+
+	injected := sets.NewString()
+	for _, names := range []vsbEnvNames{vsbEnvPresets[VSphereBindingEnvPresetDefault], vsb.envNames()} {
+		injected.Insert(names.URL, names.Insecure, names.Username, names.Password, names.SecretPath)
+	}
+	injected.Delete("")
+	return injected, false
+}
+
+// Undo implements psbinding.Bindable
 func (vsb *VSphereBinding) Undo(ctx context.Context, ps *duckv1.WithPod) {
+	injected, recorded := vsb.injectedEnv(ps)
+	if ps.Spec.Template.Annotations != nil {
+		delete(ps.Spec.Template.Annotations, VSphereBindingEnvAnnotation)
+		if len(ps.Spec.Template.Annotations) == 0 {
+			ps.Spec.Template.Annotations = nil
+		}
+	}
+
 	spec := ps.Spec.Template.Spec
 
 	for i, v := range spec.Volumes {
@@ -159,48 +301,35 @@ func (vsb *VSphereBinding) Undo(ctx context.Context, ps *duckv1.WithPod) {
 		}
 	}
 
-	for i, c := range spec.InitContainers {
+	// Bound containers are the ones with the secret mounted, regardless of
+	// the containers currently selected by the binding. Without a record of
+	// the injected variables, they are removed from the selected containers.
+	undo := func(c *corev1.Container) {
+		bound := !recorded && vsb.selects(c.Name)
 		for j, vm := range c.VolumeMounts {
 			if vm.Name == vsphere.VolumeName {
-				spec.InitContainers[i].VolumeMounts = append(c.VolumeMounts[:j], c.VolumeMounts[j+1:]...)
+				c.VolumeMounts = append(c.VolumeMounts[:j], c.VolumeMounts[j+1:]...)
+				bound = true
 				break
 			}
 		}
 
-		if len(c.Env) == 0 {
-			continue
+		if !bound || len(c.Env) == 0 {
+			return
 		}
-		env := make([]corev1.EnvVar, 0, len(spec.InitContainers[i].Env))
-		for j, ev := range c.Env {
-			switch ev.Name {
-			case "VC_URL", "VC_INSECURE", "VC_USERNAME", "VC_PASSWORD":
-				continue
-			default:
-				env = append(env, spec.InitContainers[i].Env[j])
+		env := make([]corev1.EnvVar, 0, len(c.Env))
+		for _, ev := range c.Env {
+			if !injected.Has(ev.Name) {
+				env = append(env, ev)
 			}
 		}
-		spec.InitContainers[i].Env = env
+		c.Env = env
 	}
-	for i, c := range spec.Containers {
-		for j, vm := range c.VolumeMounts {
-			if vm.Name == vsphere.VolumeName {
-				spec.Containers[i].VolumeMounts = append(c.VolumeMounts[:j], c.VolumeMounts[j+1:]...)
-				break
-			}
-		}
 
-		if len(c.Env) == 0 {
-			continue
-		}
-		env := make([]corev1.EnvVar, 0, len(spec.Containers[i].Env))
-		for j, ev := range c.Env {
-			switch ev.Name {
-			case "VC_URL", "VC_INSECURE", "VC_USERNAME", "VC_PASSWORD":
-				continue
-			default:
-				env = append(env, spec.Containers[i].Env[j])
-			}
-		}
-		spec.Containers[i].Env = env
+	for i := range spec.InitContainers {
+		undo(&spec.InitContainers[i])
+	}
+	for i := range spec.Containers {
+		undo(&spec.Containers[i])
 	}
 }
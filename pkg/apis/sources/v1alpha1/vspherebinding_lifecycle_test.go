@@ -7,6 +7,7 @@ package v1alpha1
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -620,6 +621,9 @@ func TestVSphereBindingDo(t *testing.T) {
 			vsb := vsb.DeepCopy()
 			vsb.Do(ctx, got)
 
+			test.want.Spec.Template.Annotations = map[string]string{
+				VSphereBindingEnvAnnotation: "VC_URL,VC_INSECURE,VC_USERNAME,VC_PASSWORD",
+			}
 			if !cmp.Equal(got, test.want) {
 				t.Errorf("Do (-want, +got): %s", cmp.Diff(test.want, got))
 			}
@@ -627,6 +631,260 @@ func TestVSphereBindingDo(t *testing.T) {
 	}
 }
 
+func TestVSphereBindingDoEnvAndSelection(t *testing.T) {
+	url := apis.URL{
+		Scheme: "https",
+		Host:   "vcenter.local",
+		Path:   "/sdk",
+	}
+	secretName := "ssssshhhh-dont-tell"
+	secretKeyRef := func(key string) *corev1.EnvVarSource {
+		return &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: secretName,
+				},
+				Key: key,
+			},
+		}
+	}
+	volume := corev1.Volume{
+		Name: vsphere.VolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: secretName,
+			},
+		},
+	}
+	podWith := func(containers ...corev1.Container) *duckv1.WithPod {
+		return &duckv1.WithPod{
+			Spec: duckv1.WithPodSpec{
+				Template: duckv1.PodSpecable{
+					Spec: corev1.PodSpec{
+						Containers: containers,
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name string
+		spec VSphereBindingSpec
+		in   *duckv1.WithPod
+		want []corev1.Container
+	}{{
+		name: "govc preset",
+		spec: VSphereBindingSpec{
+			Env: &VSphereBindingEnv{Preset: VSphereBindingEnvPresetGovc},
+		},
+		in: podWith(corev1.Container{Name: "govc"}),
+		want: []corev1.Container{{
+			Name: "govc",
+			Env: []corev1.EnvVar{
+				{Name: "GOVC_URL", Value: url.String()},
+				{Name: "GOVC_INSECURE", Value: "true"},
+				{Name: "GOVC_USERNAME", ValueFrom: secretKeyRef(corev1.BasicAuthUsernameKey)},
+				{Name: "GOVC_PASSWORD", ValueFrom: secretKeyRef(corev1.BasicAuthPasswordKey)},
+			},
+			VolumeMounts: []corev1.VolumeMount{{
+				Name:      vsphere.VolumeName,
+				ReadOnly:  true,
+				MountPath: vsphere.DefaultMountPath,
+			}},
+		}},
+	}, {
+		name: "terraform preset only uses the host",
+		spec: VSphereBindingSpec{
+			Env: &VSphereBindingEnv{Preset: VSphereBindingEnvPresetTerraform},
+		},
+		in: podWith(corev1.Container{Name: "terraform"}),
+		want: []corev1.Container{{
+			Name: "terraform",
+			Env: []corev1.EnvVar{
+				{Name: "VSPHERE_SERVER", Value: url.Host},
+				{Name: "VSPHERE_ALLOW_UNVERIFIED_SSL", Value: "true"},
+				{Name: "VSPHERE_USER", ValueFrom: secretKeyRef(corev1.BasicAuthUsernameKey)},
+				{Name: "VSPHERE_PASSWORD", ValueFrom: secretKeyRef(corev1.BasicAuthPasswordKey)},
+			},
+			VolumeMounts: []corev1.VolumeMount{{
+				Name:      vsphere.VolumeName,
+				ReadOnly:  true,
+				MountPath: vsphere.DefaultMountPath,
+			}},
+		}},
+	}, {
+		name: "custom mount path sets secret path",
+		spec: VSphereBindingSpec{
+			MountPath: "/etc/vsphere",
+		},
+		in: podWith(corev1.Container{Name: "adapter"}),
+		want: []corev1.Container{{
+			Name: "adapter",
+			Env: []corev1.EnvVar{
+				{Name: "VC_URL", Value: url.String()},
+				{Name: "VC_INSECURE", Value: "true"},
+				{Name: "VC_USERNAME", ValueFrom: secretKeyRef(corev1.BasicAuthUsernameKey)},
+				{Name: "VC_PASSWORD", ValueFrom: secretKeyRef(corev1.BasicAuthPasswordKey)},
+				{Name: "VC_SECRET_PATH", Value: "/etc/vsphere"},
+			},
+			VolumeMounts: []corev1.VolumeMount{{
+				Name:      vsphere.VolumeName,
+				ReadOnly:  true,
+				MountPath: "/etc/vsphere",
+			}},
+		}},
+	}, {
+		name: "custom names without credentials",
+		spec: VSphereBindingSpec{
+			Env: &VSphereBindingEnv{
+				Preset: VSphereBindingEnvPresetCustom,
+				URL:    "VCENTER",
+			},
+		},
+		in: podWith(corev1.Container{Name: "app"}),
+		want: []corev1.Container{{
+			Name: "app",
+			Env: []corev1.EnvVar{
+				{Name: "VCENTER", Value: url.String()},
+			},
+			VolumeMounts: []corev1.VolumeMount{{
+				Name:      vsphere.VolumeName,
+				ReadOnly:  true,
+				MountPath: vsphere.DefaultMountPath,
+			}},
+		}},
+	}, {
+		name: "only selected containers",
+		spec: VSphereBindingSpec{
+			ContainerNames: []string{"app"},
+		},
+		in: podWith(corev1.Container{
+			Name: "app",
+		}, corev1.Container{
+			Name: "sidecar",
+			Env: []corev1.EnvVar{
+				{Name: "VC_URL", Value: "not-ours"},
+			},
+		}),
+		want: []corev1.Container{{
+			Name: "app",
+			Env: []corev1.EnvVar{
+				{Name: "VC_URL", Value: url.String()},
+				{Name: "VC_INSECURE", Value: "true"},
+				{Name: "VC_USERNAME", ValueFrom: secretKeyRef(corev1.BasicAuthUsernameKey)},
+				{Name: "VC_PASSWORD", ValueFrom: secretKeyRef(corev1.BasicAuthPasswordKey)},
+			},
+			VolumeMounts: []corev1.VolumeMount{{
+				Name:      vsphere.VolumeName,
+				ReadOnly:  true,
+				MountPath: vsphere.DefaultMountPath,
+			}},
+		}, {
+			Name: "sidecar",
+			Env: []corev1.EnvVar{
+				{Name: "VC_URL", Value: "not-ours"},
+			},
+		}},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			vsb := &VSphereBinding{Spec: test.spec}
+			vsb.Spec.VAuthSpec = VAuthSpec{
+				Address:       url,
+				SkipTLSVerify: true,
+				SecretRef: corev1.LocalObjectReference{
+					Name: secretName,
+				},
+			}
+
+			got := test.in
+			vsb.Do(ctx, got)
+
+			want := podWith(test.want...)
+			want.Spec.Template.Spec.Volumes = []corev1.Volume{volume}
+			names := make([]string, 0, len(test.want[0].Env))
+			for _, ev := range test.want[0].Env {
+				names = append(names, ev.Name)
+			}
+			want.Spec.Template.Annotations = map[string]string{
+				VSphereBindingEnvAnnotation: strings.Join(names, ","),
+			}
+			if !cmp.Equal(got, want) {
+				t.Errorf("Do (-want, +got): %s", cmp.Diff(want, got))
+			}
+
+			// Undo must remove exactly what Do added.
+			vsb.Undo(ctx, got)
+			for _, c := range got.Spec.Template.Spec.Containers {
+				for _, ev := range c.Env {
+					if ev.Name != "VC_URL" || ev.Value != "not-ours" {
+						t.Errorf("Undo left env var %q in container %q", ev.Name, c.Name)
+					}
+				}
+				if len(c.VolumeMounts) != 0 {
+					t.Errorf("Undo left volume mounts in container %q: %v", c.Name, c.VolumeMounts)
+				}
+			}
+			if len(got.Spec.Template.Spec.Volumes) != 0 {
+				t.Errorf("Undo left volumes: %v", got.Spec.Template.Spec.Volumes)
+			}
+		})
+	}
+}
+
+func TestVSphereBindingUndoAfterSpecChange(t *testing.T) {
+	ctx := context.Background()
+	in := &duckv1.WithPod{
+		Spec: duckv1.WithPodSpec{
+			Template: duckv1.PodSpecable{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name: "govc",
+					}, {
+						Name: "sidecar",
+						Env: []corev1.EnvVar{
+							{Name: "VC_URL", Value: "not-ours"},
+						},
+					}},
+				},
+			},
+		},
+	}
+	want := in.DeepCopy()
+
+	vsb := &VSphereBinding{
+		Spec: VSphereBindingSpec{
+			VAuthSpec: VAuthSpec{
+				Address: apis.URL{Scheme: "https", Host: "vcenter.local"},
+				SecretRef: corev1.LocalObjectReference{
+					Name: "creds",
+				},
+			},
+			MountPath:      "/etc/vsphere",
+			ContainerNames: []string{"govc"},
+			Env:            &VSphereBindingEnv{Preset: VSphereBindingEnvPresetGovc},
+		},
+	}
+	got := in.DeepCopy()
+	vsb.Do(ctx, got)
+
+	// The binding now selects other containers and injects other names.
+	vsb.Spec.ContainerNames = []string{"sidecar"}
+	vsb.Spec.Env = &VSphereBindingEnv{Preset: VSphereBindingEnvPresetPowerCLI}
+	vsb.Undo(ctx, got)
+
+	want.Spec.Template.Spec.Containers[0].Env = []corev1.EnvVar{}
+	want.Spec.Template.Spec.Containers[0].VolumeMounts = []corev1.VolumeMount{}
+	want.Spec.Template.Spec.Volumes = []corev1.Volume{}
+	if !cmp.Equal(got, want) {
+		t.Errorf("Undo (-want, +got): %s", cmp.Diff(want, got))
+	}
+}
+
 func TestTypicalBindingFlow(t *testing.T) {
 	r := &VSphereBindingStatus{}
 	r.InitializeConditions()
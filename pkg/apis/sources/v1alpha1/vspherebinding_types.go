@@ -43,6 +43,83 @@ type VSphereBindingSpec struct {
 	duckv1alpha1.BindingSpec `json:",inline"`
 
 	VAuthSpec `json:",inline"`
+
+	// MountPath is the path at which the secret referenced by SecretRef is
+	// mounted into the bound containers. If unspecified this defaults to
+	// "/var/bindings/vsphere".
+	// +optional
+	MountPath string `json:"mountPath,omitempty"`
+
+	// Env configures the names of the environment variables injected into the
+	// bound containers. If unspecified the "default" preset (VC_URL,
+	// VC_INSECURE, VC_USERNAME and VC_PASSWORD) is used.
+	// +optional
+	Env *VSphereBindingEnv `json:"env,omitempty"`
+
+	// ContainerNames restricts the binding to the named containers and init
+	// containers of the subject. If empty, all containers are bound.
+	// +optional
+	ContainerNames []string `json:"containerNames,omitempty"`
+}
+
+// VSphereBindingEnvPreset is a well-known set of environment variable names
+// understood by a vSphere client tool.
+type VSphereBindingEnvPreset string
+
+const (
+	// VSphereBindingEnvPresetDefault injects VC_URL, VC_INSECURE, VC_USERNAME
+	// and VC_PASSWORD as consumed by the vsphere package of this repository.
+	VSphereBindingEnvPresetDefault VSphereBindingEnvPreset = "default"
+
+	// VSphereBindingEnvPresetGovc injects GOVC_URL, GOVC_INSECURE,
+	// GOVC_USERNAME and GOVC_PASSWORD as consumed by govc.
+	VSphereBindingEnvPresetGovc VSphereBindingEnvPreset = "govc"
+
+	// VSphereBindingEnvPresetPowerCLI injects VI_SERVER, VI_INSECURE,
+	// VI_USERNAME and VI_PASSWORD for use in PowerCLI scripts.
+	VSphereBindingEnvPresetPowerCLI VSphereBindingEnvPreset = "powercli"
+
+	// VSphereBindingEnvPresetTerraform injects VSPHERE_SERVER,
+	// VSPHERE_ALLOW_UNVERIFIED_SSL, VSPHERE_USER and VSPHERE_PASSWORD as
+	// consumed by the Terraform vSphere provider. VSPHERE_SERVER only carries
+	// the host of the address.
+	VSphereBindingEnvPresetTerraform VSphereBindingEnvPreset = "terraform"
+
+	// VSphereBindingEnvPresetCustom injects the variable names given in
+	// VSphereBindingEnv.
+	VSphereBindingEnvPresetCustom VSphereBindingEnvPreset = "custom"
+)
+
+// VSphereBindingEnv configures the environment variables injected by a
+// VSphereBinding.
+type VSphereBindingEnv struct {
+	// Preset selects a well-known set of variable names. One of "default",
+	// "govc", "powercli", "terraform" or "custom".
+	// +optional
+	Preset VSphereBindingEnvPreset `json:"preset,omitempty"`
+
+	// The fields below are only allowed with the "custom" preset. Variables
+	// with an empty name are not injected.
+
+	// URL is the name of the variable holding the address.
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// Insecure is the name of the variable holding SkipTLSVerify.
+	// +optional
+	Insecure string `json:"insecure,omitempty"`
+
+	// Username is the name of the variable holding the username from the secret.
+	// +optional
+	Username string `json:"username,omitempty"`
+
+	// Password is the name of the variable holding the password from the secret.
+	// +optional
+	Password string `json:"password,omitempty"`
+
+	// SecretPath is the name of the variable holding the mount path of the secret.
+	// +optional
+	SecretPath string `json:"secretPath,omitempty"`
 }
 
 // VAuthSpec is the information used to authenticate with a vSphere API
@@ -7,7 +7,10 @@ package v1alpha1
 
 import (
 	"context"
+	"path"
+	"strings"
 
+	"k8s.io/apimachinery/pkg/util/validation"
 	"knative.dev/pkg/apis"
 )
 
@@ -22,7 +25,62 @@ func (vsb *VSphereBinding) Validate(ctx context.Context) *apis.FieldError {
 
 // Validate implements apis.Validatable
 func (fbs *VSphereBindingSpec) Validate(ctx context.Context) *apis.FieldError {
-	return fbs.Subject.Validate(ctx).ViaField("subject").Also(fbs.VAuthSpec.Validate(ctx))
+	err := fbs.Subject.Validate(ctx).ViaField("subject").Also(fbs.VAuthSpec.Validate(ctx))
+
+	if fbs.MountPath != "" && !path.IsAbs(fbs.MountPath) {
+		err = err.Also(apis.ErrInvalidValue(fbs.MountPath, "mountPath"))
+	}
+
+	if fbs.Env != nil {
+		err = err.Also(fbs.Env.Validate(ctx).ViaField("env"))
+	}
+
+	for i, name := range fbs.ContainerNames {
+		if name == "" {
+			err = err.Also(apis.ErrInvalidArrayValue(name, "containerNames", i))
+		}
+	}
+	return err
+}
+
+// Validate implements apis.Validatable
+func (vbe *VSphereBindingEnv) Validate(ctx context.Context) (err *apis.FieldError) {
+	names := []struct{ field, name string }{
+		{"url", vbe.URL},
+		{"insecure", vbe.Insecure},
+		{"username", vbe.Username},
+		{"password", vbe.Password},
+		{"secretPath", vbe.SecretPath},
+	}
+
+	switch vbe.Preset {
+	case "", VSphereBindingEnvPresetDefault, VSphereBindingEnvPresetGovc,
+		VSphereBindingEnvPresetPowerCLI, VSphereBindingEnvPresetTerraform:
+		for _, n := range names {
+			if n.name != "" {
+				err = err.Also(apis.ErrDisallowedFields(n.field))
+			}
+		}
+
+	case VSphereBindingEnvPresetCustom:
+		var set bool
+		for _, n := range names {
+			if n.name == "" {
+				continue
+			}
+			set = true
+			if msgs := validation.IsEnvVarName(n.name); len(msgs) > 0 {
+				err = err.Also(apis.ErrInvalidValue(n.name, n.field, strings.Join(msgs, ", ")))
+			}
+		}
+		if !set {
+			err = err.Also(apis.ErrMissingOneOf("url", "insecure", "username", "password", "secretPath"))
+		}
+
+	default:
+		err = err.Also(apis.ErrInvalidValue(vbe.Preset, "preset"))
+	}
+	return err
 }
 
 // Validate implements apis.Validatable
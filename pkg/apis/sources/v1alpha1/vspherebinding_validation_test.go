@@ -115,6 +115,100 @@ func TestVSphereBindingValidation(t *testing.T) {
 			},
 		},
 		want: apis.ErrMissingField("spec.address.host"),
+	}, {
+		name: "valid env preset, mount path and containers",
+		c: &VSphereBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "valid",
+				Namespace: validBindingSpec.Subject.Namespace,
+			},
+			Spec: VSphereBindingSpec{
+				BindingSpec:    validBindingSpec,
+				VAuthSpec:      validVAuthSpec,
+				MountPath:      "/etc/vsphere",
+				Env:            &VSphereBindingEnv{Preset: VSphereBindingEnvPresetGovc},
+				ContainerNames: []string{"govc"},
+			},
+		},
+		want: nil,
+	}, {
+		name: "relative mount path",
+		c: &VSphereBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "valid",
+				Namespace: validBindingSpec.Subject.Namespace,
+			},
+			Spec: VSphereBindingSpec{
+				BindingSpec: validBindingSpec,
+				VAuthSpec:   validVAuthSpec,
+				MountPath:   "etc/vsphere",
+			},
+		},
+		want: apis.ErrInvalidValue("etc/vsphere", "spec.mountPath"),
+	}, {
+		name: "unknown env preset",
+		c: &VSphereBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "valid",
+				Namespace: validBindingSpec.Subject.Namespace,
+			},
+			Spec: VSphereBindingSpec{
+				BindingSpec: validBindingSpec,
+				VAuthSpec:   validVAuthSpec,
+				Env:         &VSphereBindingEnv{Preset: "ansible"},
+			},
+		},
+		want: apis.ErrInvalidValue("ansible", "spec.env.preset"),
+	}, {
+		name: "names with non-custom preset",
+		c: &VSphereBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "valid",
+				Namespace: validBindingSpec.Subject.Namespace,
+			},
+			Spec: VSphereBindingSpec{
+				BindingSpec: validBindingSpec,
+				VAuthSpec:   validVAuthSpec,
+				Env: &VSphereBindingEnv{
+					Preset: VSphereBindingEnvPresetGovc,
+					URL:    "MY_URL",
+				},
+			},
+		},
+		want: apis.ErrDisallowedFields("spec.env.url"),
+	}, {
+		name: "custom preset without names",
+		c: &VSphereBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "valid",
+				Namespace: validBindingSpec.Subject.Namespace,
+			},
+			Spec: VSphereBindingSpec{
+				BindingSpec: validBindingSpec,
+				VAuthSpec:   validVAuthSpec,
+				Env:         &VSphereBindingEnv{Preset: VSphereBindingEnvPresetCustom},
+			},
+		},
+		want: apis.ErrMissingOneOf("spec.env.url", "spec.env.insecure", "spec.env.username",
+			"spec.env.password", "spec.env.secretPath"),
+	}, {
+		name: "custom preset with invalid name",
+		c: &VSphereBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "valid",
+				Namespace: validBindingSpec.Subject.Namespace,
+			},
+			Spec: VSphereBindingSpec{
+				BindingSpec: validBindingSpec,
+				VAuthSpec:   validVAuthSpec,
+				Env: &VSphereBindingEnv{
+					Preset: VSphereBindingEnvPresetCustom,
+					URL:    "1NVALID",
+				},
+			},
+		},
+		want: apis.ErrInvalidValue("1NVALID", "spec.env.url",
+			"a valid environment variable name must consist of alphabetic characters, digits, '_', '-', or '.', and must not start with a digit (e.g. 'my.env-name',  or 'MY_ENV.NAME',  or 'MyEnvName1', regex used for validation is '[-._a-zA-Z][-._a-zA-Z0-9]*')"),
 	}}
 
 	for _, test := range tests {
@@ -26,6 +26,20 @@ func (vs *VSphereSource) SetDefaults(ctx context.Context) {
 		vs.Spec.CheckpointConfig.PeriodSeconds = int64(vsphere.CheckpointDefaultPeriod.Seconds())
 	}
 
+	if vs.Spec.CheckpointConfig.RetainPolicy == "" {
+		vs.Spec.CheckpointConfig.RetainPolicy = VCheckpointRetainPolicyDelete
+	}
+
+	if vs.Spec.CheckpointConfig.MismatchPolicy == "" {
+		vs.Spec.CheckpointConfig.MismatchPolicy = VCheckpointMismatchReplayMaxAge
+	}
+
+	if vs.Spec.ShutdownGracePeriodSeconds == 0 {
+		vs.Spec.ShutdownGracePeriodSeconds = int64(vsphere.ShutdownDefaultGracePeriod.Seconds())
+	}
+
+	vs.Spec.Polling.SetDefaults(ctx)
+
 	// preserve backward-compatibility
 	if vs.Spec.PayloadEncoding == "" {
 		vs.Spec.PayloadEncoding = cloudevents.ApplicationXML
@@ -33,3 +47,16 @@ func (vs *VSphereSource) SetDefaults(ctx context.Context) {
 		vs.Spec.PayloadEncoding = strings.ToLower(vs.Spec.PayloadEncoding)
 	}
 }
+
+// SetDefaults implements apis.Defaultable
+func (vps *VPollingSpec) SetDefaults(_ context.Context) {
+	if vps.MinBackoffSeconds == 0 {
+		vps.MinBackoffSeconds = int64(vsphere.PollDefaultMinBackoff.Seconds())
+	}
+	if vps.MaxBackoffSeconds == 0 {
+		vps.MaxBackoffSeconds = int64(vsphere.PollDefaultMaxBackoff.Seconds())
+	}
+	if vps.BatchSize == 0 {
+		vps.BatchSize = vsphere.PollDefaultBatchSize
+	}
+}
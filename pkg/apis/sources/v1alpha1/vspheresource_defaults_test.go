@@ -17,6 +17,12 @@ import (
 	"github.com/vmware-tanzu/sources-for-knative/pkg/vsphere"
 )
 
+var defaultVPolling = VPollingSpec{
+	MinBackoffSeconds: int64(vsphere.PollDefaultMinBackoff.Seconds()),
+	MaxBackoffSeconds: int64(vsphere.PollDefaultMaxBackoff.Seconds()),
+	BatchSize:         vsphere.PollDefaultBatchSize,
+}
+
 func TestVSphereSourceDefaulting(t *testing.T) {
 	tests := []struct {
 		name string
@@ -41,10 +47,14 @@ func TestVSphereSourceDefaulting(t *testing.T) {
 				SourceSpec: validSourceSpec,
 				VAuthSpec:  validVAuthSpec,
 				CheckpointConfig: VCheckpointSpec{
-					MaxAgeSeconds: 0,
-					PeriodSeconds: int64(vsphere.CheckpointDefaultPeriod.Seconds()),
+					MaxAgeSeconds:  0,
+					PeriodSeconds:  int64(vsphere.CheckpointDefaultPeriod.Seconds()),
+					RetainPolicy:   VCheckpointRetainPolicyDelete,
+					MismatchPolicy: VCheckpointMismatchReplayMaxAge,
 				},
-				PayloadEncoding: cloudevents.ApplicationXML,
+				PayloadEncoding:            cloudevents.ApplicationXML,
+				ShutdownGracePeriodSeconds: int64(vsphere.ShutdownDefaultGracePeriod.Seconds()),
+				Polling:                    defaultVPolling,
 			},
 		},
 	}, {
@@ -67,10 +77,14 @@ func TestVSphereSourceDefaulting(t *testing.T) {
 				SourceSpec: validSourceSpec,
 				VAuthSpec:  validVAuthSpec,
 				CheckpointConfig: VCheckpointSpec{
-					MaxAgeSeconds: 0,
-					PeriodSeconds: int64(vsphere.CheckpointDefaultPeriod.Seconds()),
+					MaxAgeSeconds:  0,
+					PeriodSeconds:  int64(vsphere.CheckpointDefaultPeriod.Seconds()),
+					RetainPolicy:   VCheckpointRetainPolicyDelete,
+					MismatchPolicy: VCheckpointMismatchReplayMaxAge,
 				},
-				PayloadEncoding: cloudevents.ApplicationJSON,
+				PayloadEncoding:            cloudevents.ApplicationJSON,
+				ShutdownGracePeriodSeconds: int64(vsphere.ShutdownDefaultGracePeriod.Seconds()),
+				Polling:                    defaultVPolling,
 			},
 		},
 	}, {
@@ -111,10 +125,14 @@ func TestVSphereSourceDefaulting(t *testing.T) {
 				},
 				VAuthSpec: validVAuthSpec,
 				CheckpointConfig: VCheckpointSpec{
-					MaxAgeSeconds: 0,
-					PeriodSeconds: int64(vsphere.CheckpointDefaultPeriod.Seconds()),
+					MaxAgeSeconds:  0,
+					PeriodSeconds:  int64(vsphere.CheckpointDefaultPeriod.Seconds()),
+					RetainPolicy:   VCheckpointRetainPolicyDelete,
+					MismatchPolicy: VCheckpointMismatchReplayMaxAge,
 				},
-				PayloadEncoding: cloudevents.ApplicationXML,
+				PayloadEncoding:            cloudevents.ApplicationXML,
+				ShutdownGracePeriodSeconds: int64(vsphere.ShutdownDefaultGracePeriod.Seconds()),
+				Polling:                    defaultVPolling,
 			},
 		},
 	}, {
@@ -140,11 +158,15 @@ func TestVSphereSourceDefaulting(t *testing.T) {
 				SourceSpec: validSourceSpec,
 				VAuthSpec:  validVAuthSpec,
 				CheckpointConfig: VCheckpointSpec{
-					MaxAgeSeconds: 3600,
-					PeriodSeconds: 60,
+					MaxAgeSeconds:  3600,
+					PeriodSeconds:  60,
+					RetainPolicy:   VCheckpointRetainPolicyDelete,
+					MismatchPolicy: VCheckpointMismatchReplayMaxAge,
 				},
-				PayloadEncoding:    cloudevents.ApplicationXML,
-				ServiceAccountName: "",
+				PayloadEncoding:            cloudevents.ApplicationXML,
+				ShutdownGracePeriodSeconds: int64(vsphere.ShutdownDefaultGracePeriod.Seconds()),
+				Polling:                    defaultVPolling,
+				ServiceAccountName:         "",
 			},
 		},
 	}, {
@@ -167,11 +189,79 @@ func TestVSphereSourceDefaulting(t *testing.T) {
 				SourceSpec: validSourceSpec,
 				VAuthSpec:  validVAuthSpec,
 				CheckpointConfig: VCheckpointSpec{
-					MaxAgeSeconds: 0,
-					PeriodSeconds: int64(vsphere.CheckpointDefaultPeriod.Seconds()),
+					MaxAgeSeconds:  0,
+					PeriodSeconds:  int64(vsphere.CheckpointDefaultPeriod.Seconds()),
+					RetainPolicy:   VCheckpointRetainPolicyDelete,
+					MismatchPolicy: VCheckpointMismatchReplayMaxAge,
+				},
+				PayloadEncoding:            cloudevents.ApplicationXML,
+				ShutdownGracePeriodSeconds: int64(vsphere.ShutdownDefaultGracePeriod.Seconds()),
+				Polling:                    defaultVPolling,
+				ServiceAccountName:         "test-svcacc",
+			},
+		},
+	}, {
+		name: "custom shutdownGracePeriodSeconds",
+		c: &VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: VSphereSourceSpec{
+				SourceSpec:                 validSourceSpec,
+				VAuthSpec:                  validVAuthSpec,
+				ShutdownGracePeriodSeconds: 60,
+			},
+		},
+		want: &VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: VSphereSourceSpec{
+				SourceSpec: validSourceSpec,
+				VAuthSpec:  validVAuthSpec,
+				CheckpointConfig: VCheckpointSpec{
+					PeriodSeconds:  int64(vsphere.CheckpointDefaultPeriod.Seconds()),
+					RetainPolicy:   VCheckpointRetainPolicyDelete,
+					MismatchPolicy: VCheckpointMismatchReplayMaxAge,
+				},
+				PayloadEncoding:            cloudevents.ApplicationXML,
+				ShutdownGracePeriodSeconds: 60,
+				Polling:                    defaultVPolling,
+			},
+		},
+	}, {
+		name: "custom polling",
+		c: &VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: VSphereSourceSpec{
+				SourceSpec: validSourceSpec,
+				VAuthSpec:  validVAuthSpec,
+				Polling: VPollingSpec{
+					MaxBackoffSeconds: 30,
+				},
+			},
+		},
+		want: &VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: VSphereSourceSpec{
+				SourceSpec: validSourceSpec,
+				VAuthSpec:  validVAuthSpec,
+				CheckpointConfig: VCheckpointSpec{
+					PeriodSeconds:  int64(vsphere.CheckpointDefaultPeriod.Seconds()),
+					RetainPolicy:   VCheckpointRetainPolicyDelete,
+					MismatchPolicy: VCheckpointMismatchReplayMaxAge,
+				},
+				PayloadEncoding:            cloudevents.ApplicationXML,
+				ShutdownGracePeriodSeconds: int64(vsphere.ShutdownDefaultGracePeriod.Seconds()),
+				Polling: VPollingSpec{
+					MinBackoffSeconds: int64(vsphere.PollDefaultMinBackoff.Seconds()),
+					MaxBackoffSeconds: 30,
+					BatchSize:         vsphere.PollDefaultBatchSize,
 				},
-				PayloadEncoding:    cloudevents.ApplicationXML,
-				ServiceAccountName: "test-svcacc",
 			},
 		},
 	}}
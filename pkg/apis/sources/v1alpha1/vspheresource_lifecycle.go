@@ -6,7 +6,10 @@ SPDX-License-Identifier: Apache-2.0
 package v1alpha1
 
 import (
+	"time"
+
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"knative.dev/pkg/apis"
@@ -65,3 +68,110 @@ func (vss *VSphereSourceStatus) PropagateAdapterStatus(d appsv1.DeploymentStatus
 
 	condSet.Manage(vss).MarkUnknown(VSphereSourceConditionAdapterReady, "", "")
 }
+
+// MarkSuspended sets the Suspended condition while the adapter is scaled to
+// zero.
+func (vss *VSphereSourceStatus) MarkSuspended() {
+	condSet.Manage(vss).MarkTrue(VSphereSourceConditionSuspended)
+}
+
+// ClearSuspended removes the Suspended condition once the source is resumed.
+func (vss *VSphereSourceStatus) ClearSuspended() {
+	_ = condSet.Manage(vss).ClearCondition(VSphereSourceConditionSuspended)
+}
+
+// MarkEventTypesRegistered sets the EventTypesRegistered condition once the
+// event types of the vCenter are registered.
+func (vss *VSphereSourceStatus) MarkEventTypesRegistered() {
+	condSet.Manage(vss).MarkTrue(VSphereSourceConditionEventTypesRegistered)
+}
+
+// MarkEventTypesPending sets the EventTypesRegistered condition to Unknown
+// while the event catalog of the vCenter has not been read yet.
+func (vss *VSphereSourceStatus) MarkEventTypesPending(reason, messageFormat string, messageA ...interface{}) {
+	condSet.Manage(vss).MarkUnknown(VSphereSourceConditionEventTypesRegistered, reason, messageFormat, messageA...)
+}
+
+// MarkEventTypesNotRegistered sets the EventTypesRegistered condition to
+// False if the event types of the vCenter could not be registered.
+func (vss *VSphereSourceStatus) MarkEventTypesNotRegistered(reason, messageFormat string, messageA ...interface{}) {
+	condSet.Manage(vss).MarkFalse(VSphereSourceConditionEventTypesRegistered, reason, messageFormat, messageA...)
+}
+
+// PropagateBackfillStatus sets the BackfillSucceeded condition from the
+// conditions of the backfill Job.
+func (vss *VSphereSourceStatus) PropagateBackfillStatus(js batchv1.JobStatus) {
+	for _, cond := range js.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch cond.Type {
+		case batchv1.JobComplete:
+			condSet.Manage(vss).MarkTrue(VSphereSourceConditionBackfillSucceeded)
+			return
+		case batchv1.JobFailed:
+			condSet.Manage(vss).MarkFalse(VSphereSourceConditionBackfillSucceeded, cond.Reason, cond.Message)
+			return
+		case batchv1.JobSuspended:
+			condSet.Manage(vss).MarkUnknown(VSphereSourceConditionBackfillSucceeded, "BackfillSuspended", "the backfill job is suspended")
+			return
+		}
+	}
+
+	condSet.Manage(vss).MarkUnknown(VSphereSourceConditionBackfillSucceeded, "BackfillRunning", "the backfill job is running")
+}
+
+// ClearBackfill removes the backfill status and the BackfillSucceeded
+// condition once spec.backfill is removed.
+func (vss *VSphereSourceStatus) ClearBackfill() {
+	vss.Backfill = nil
+	_ = condSet.Manage(vss).ClearCondition(VSphereSourceConditionBackfillSucceeded)
+}
+
+// PropagateCheckpointStatus sets the CheckpointValid condition from the
+// checkpoint mismatch reported by the adapter. With the Fail policy the
+// adapter does not start, otherwise the checkpoint was reset according to
+// the policy.
+func (vss *VSphereSourceStatus) PropagateCheckpointStatus(policy VCheckpointMismatchPolicy) {
+	switch {
+	case vss.CheckpointMismatch == "":
+		condSet.Manage(vss).MarkTrue(VSphereSourceConditionCheckpointValid)
+	case policy == VCheckpointMismatchFail:
+		condSet.Manage(vss).MarkFalse(VSphereSourceConditionCheckpointValid, "CheckpointMismatch", vss.CheckpointMismatch)
+	default:
+		condSet.Manage(vss).MarkTrueWithReason(VSphereSourceConditionCheckpointValid, "CheckpointReset",
+			"checkpoint reset with policy %s: %s", policy, vss.CheckpointMismatch)
+	}
+}
+
+// PropagateEventsFlowing sets the EventsFlowing condition from the delivery
+// status reported by the adapter. A maxLagSeconds of 0 uses
+// DefaultMaxEventLag.
+func (vss *VSphereSourceStatus) PropagateEventsFlowing(maxLagSeconds int64) {
+	status, reason, message := vss.eventsFlowing(time.Now(), MaxEventLag(maxLagSeconds))
+	switch status {
+	case corev1.ConditionUnknown:
+		condSet.Manage(vss).MarkUnknown(VSphereSourceConditionEventsFlowing, reason, message)
+	case corev1.ConditionFalse:
+		condSet.Manage(vss).MarkFalse(VSphereSourceConditionEventsFlowing, reason, message)
+	case corev1.ConditionTrue:
+		condSet.Manage(vss).MarkTrue(VSphereSourceConditionEventsFlowing)
+	}
+}
+
+// CopyAdapterStatus copies the fields the adapter reports in the status of its
+// source from the given status. The reconciler takes them from a fresh read of
+// the source when updating the status, so that it never rolls back a report
+// of the adapter with the copy it reconciled.
+func (vss *VSphereSourceStatus) CopyAdapterStatus(from *VSphereSourceStatus) {
+	vss.Checkpoint = from.Checkpoint.DeepCopy()
+	from.EventDeliveryStatus.DeepCopyInto(&vss.EventDeliveryStatus)
+	vss.LastEventKey = from.LastEventKey
+	vss.CheckpointMismatch = from.CheckpointMismatch
+
+	// the progress of a backfill is kept unless its time range changed
+	if b := from.Backfill; b != nil && vss.Backfill != nil &&
+		b.StartTime.Equal(&vss.Backfill.StartTime) && b.EndTime.Equal(&vss.Backfill.EndTime) {
+		vss.Backfill = b.DeepCopy()
+	}
+}
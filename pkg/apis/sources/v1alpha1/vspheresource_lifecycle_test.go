@@ -7,9 +7,12 @@ package v1alpha1
 
 import (
 	"testing"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/apis/duck"
@@ -112,3 +115,199 @@ func TestTypicalSourceFlow(t *testing.T) {
 	// After all of that, we're finally ready!
 	apistest.CheckConditionSucceeded(r, VSphereSourceConditionReady, t)
 }
+
+func TestVSphereSourceEventsFlowing(t *testing.T) {
+	r := &VSphereSourceStatus{}
+	r.InitializeConditions()
+
+	r.PropagateEventsFlowing(0)
+	apistest.CheckConditionOngoing(r, VSphereSourceConditionEventsFlowing, t)
+
+	now := time.Now()
+	r.LastPollTime = &metav1.Time{Time: now}
+	r.EventLag = &metav1.Duration{Duration: time.Minute}
+	r.PropagateEventsFlowing(0)
+	apistest.CheckConditionSucceeded(r, VSphereSourceConditionEventsFlowing, t)
+
+	r.PropagateEventsFlowing(30)
+	apistest.CheckConditionFailed(r, VSphereSourceConditionEventsFlowing, t)
+
+	// a quiet vCenter delivers no events but is still read
+	r.EventLag = nil
+	r.LastEventTime = &metav1.Time{Time: now.Add(-time.Hour)}
+	r.LastSentTime = &metav1.Time{Time: now.Add(-time.Hour)}
+	r.PropagateEventsFlowing(0)
+	apistest.CheckConditionSucceeded(r, VSphereSourceConditionEventsFlowing, t)
+
+	// a stalled adapter does not read events
+	r.LastPollTime = &metav1.Time{Time: now.Add(-time.Hour)}
+	r.PropagateEventsFlowing(0)
+	apistest.CheckConditionFailed(r, VSphereSourceConditionEventsFlowing, t)
+
+	r.LastPollTime = &metav1.Time{Time: now}
+	r.LastError = "sink unavailable"
+	r.PropagateEventsFlowing(30)
+	apistest.CheckConditionFailed(r, VSphereSourceConditionEventsFlowing, t)
+
+	// EventsFlowing does not affect the Ready condition.
+	apistest.CheckConditionOngoing(r, VSphereSourceConditionReady, t)
+}
+
+func TestVSphereSourceSuspended(t *testing.T) {
+	r := &VSphereSourceStatus{}
+	r.InitializeConditions()
+	r.PropagateAuthStatus(duckv1.Status{
+		Conditions: []apis.Condition{{
+			Type:   apis.ConditionReady,
+			Status: corev1.ConditionTrue,
+		}},
+	})
+	// a deployment scaled to zero is available
+	r.PropagateAdapterStatus(appsv1.DeploymentStatus{
+		Conditions: []appsv1.DeploymentCondition{{
+			Type:   appsv1.DeploymentAvailable,
+			Status: corev1.ConditionTrue,
+		}},
+	})
+
+	r.MarkSuspended()
+	apistest.CheckConditionSucceeded(r, VSphereSourceConditionSuspended, t)
+
+	// Suspended does not affect the Ready condition.
+	apistest.CheckConditionSucceeded(r, VSphereSourceConditionReady, t)
+
+	r.ClearSuspended()
+	if cond := r.GetCondition(VSphereSourceConditionSuspended); cond != nil {
+		t.Errorf("Suspended condition = %v, want none after resume", cond)
+	}
+}
+
+func TestVSphereSourceEventTypesRegistered(t *testing.T) {
+	r := &VSphereSourceStatus{}
+	r.InitializeConditions()
+	r.PropagateAuthStatus(duckv1.Status{
+		Conditions: []apis.Condition{{
+			Type:   apis.ConditionReady,
+			Status: corev1.ConditionTrue,
+		}},
+	})
+	r.PropagateAdapterStatus(appsv1.DeploymentStatus{
+		Conditions: []appsv1.DeploymentCondition{{
+			Type:   appsv1.DeploymentAvailable,
+			Status: corev1.ConditionTrue,
+		}},
+	})
+
+	r.MarkEventTypesPending("CatalogPending", "not read yet")
+	apistest.CheckConditionOngoing(r, VSphereSourceConditionEventTypesRegistered, t)
+
+	r.MarkEventTypesNotRegistered("RegistrationFailed", "login failed")
+	apistest.CheckConditionFailed(r, VSphereSourceConditionEventTypesRegistered, t)
+
+	// EventTypesRegistered does not affect the Ready condition.
+	apistest.CheckConditionSucceeded(r, VSphereSourceConditionReady, t)
+
+	r.MarkEventTypesRegistered()
+	apistest.CheckConditionSucceeded(r, VSphereSourceConditionEventTypesRegistered, t)
+}
+
+func TestVSphereSourceBackfill(t *testing.T) {
+	r := &VSphereSourceStatus{}
+	r.InitializeConditions()
+
+	r.PropagateBackfillStatus(batchv1.JobStatus{Active: 1})
+	apistest.CheckConditionOngoing(r, VSphereSourceConditionBackfillSucceeded, t)
+
+	r.PropagateBackfillStatus(batchv1.JobStatus{
+		Conditions: []batchv1.JobCondition{{
+			Type:   batchv1.JobSuspended,
+			Status: corev1.ConditionTrue,
+		}},
+	})
+	if cond := r.GetCondition(VSphereSourceConditionBackfillSucceeded); cond.Reason != "BackfillSuspended" {
+		t.Errorf("BackfillSucceeded reason = %q, want %q", cond.Reason, "BackfillSuspended")
+	}
+
+	r.PropagateBackfillStatus(batchv1.JobStatus{
+		Conditions: []batchv1.JobCondition{{
+			Type:    batchv1.JobFailed,
+			Status:  corev1.ConditionTrue,
+			Reason:  "BackoffLimitExceeded",
+			Message: "Job has reached the specified backoff limit",
+		}},
+	})
+	apistest.CheckConditionFailed(r, VSphereSourceConditionBackfillSucceeded, t)
+
+	r.PropagateBackfillStatus(batchv1.JobStatus{
+		Conditions: []batchv1.JobCondition{{
+			Type:   batchv1.JobComplete,
+			Status: corev1.ConditionTrue,
+		}},
+	})
+	apistest.CheckConditionSucceeded(r, VSphereSourceConditionBackfillSucceeded, t)
+
+	// BackfillSucceeded does not affect the Ready condition.
+	apistest.CheckConditionOngoing(r, VSphereSourceConditionReady, t)
+
+	r.Backfill = &VBackfillStatus{EventsSent: 1}
+	r.ClearBackfill()
+	if cond := r.GetCondition(VSphereSourceConditionBackfillSucceeded); cond != nil {
+		t.Errorf("BackfillSucceeded condition = %v, want none after clearing the backfill", cond)
+	}
+	if r.Backfill != nil {
+		t.Errorf("Backfill = %v, want nil after clearing the backfill", r.Backfill)
+	}
+}
+
+func TestVSphereSourceCheckpointValid(t *testing.T) {
+	r := &VSphereSourceStatus{}
+	r.InitializeConditions()
+
+	r.PropagateCheckpointStatus(VCheckpointMismatchReplayMaxAge)
+	apistest.CheckConditionSucceeded(r, VSphereSourceConditionCheckpointValid, t)
+
+	r.CheckpointMismatch = "vCenter event keys were reset"
+	r.PropagateCheckpointStatus(VCheckpointMismatchResetToNow)
+	apistest.CheckConditionSucceeded(r, VSphereSourceConditionCheckpointValid, t)
+	if cond := r.GetCondition(VSphereSourceConditionCheckpointValid); cond.Reason != "CheckpointReset" {
+		t.Errorf("CheckpointValid reason = %q, want %q", cond.Reason, "CheckpointReset")
+	}
+
+	r.PropagateCheckpointStatus(VCheckpointMismatchFail)
+	apistest.CheckConditionFailed(r, VSphereSourceConditionCheckpointValid, t)
+
+	// CheckpointValid does not affect the Ready condition.
+	apistest.CheckConditionOngoing(r, VSphereSourceConditionReady, t)
+}
+
+func TestVSphereSourceCopyAdapterStatus(t *testing.T) {
+	start := metav1.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := metav1.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	reported := &VSphereSourceStatus{
+		EventDeliveryStatus: EventDeliveryStatus{EventsSentTotal: 42},
+		LastEventKey:        7,
+		Backfill:            &VBackfillStatus{StartTime: start, EndTime: end, EventsSent: 3},
+	}
+
+	r := &VSphereSourceStatus{Backfill: &VBackfillStatus{StartTime: start, EndTime: end}}
+	r.CopyAdapterStatus(reported)
+	if r.EventsSentTotal != 42 || r.LastEventKey != 7 || r.Backfill.EventsSent != 3 {
+		t.Errorf("CopyAdapterStatus() = %+v, want reported status", r)
+	}
+
+	// the progress of another backfill range is not copied
+	later := metav1.Date(2022, 1, 3, 0, 0, 0, 0, time.UTC)
+	r = &VSphereSourceStatus{Backfill: &VBackfillStatus{StartTime: start, EndTime: later}}
+	r.CopyAdapterStatus(reported)
+	if r.Backfill.EventsSent != 0 || !r.Backfill.EndTime.Equal(&later) {
+		t.Errorf("CopyAdapterStatus() backfill = %+v, want reset for new range", r.Backfill)
+	}
+
+	// nor the progress of a removed backfill
+	r = &VSphereSourceStatus{}
+	r.CopyAdapterStatus(reported)
+	if r.Backfill != nil {
+		t.Errorf("CopyAdapterStatus() backfill = %+v, want nil", r.Backfill)
+	}
+}
@@ -7,9 +7,12 @@ package v1alpha1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/kmeta"
+
+	"github.com/vmware-tanzu/sources-for-knative/pkg/vsphere"
 )
 
 // +genclient
@@ -46,15 +49,141 @@ type VSphereSourceSpec struct {
 	PayloadEncoding  string          `json:"payloadEncoding"`
 	// ServiceAccountName holds the name of the Kubernetes service account
 	// as which the underlying K8s resources should be run. If unspecified
-	// this will default to the "default" service account for the namespace
-	// in which the HorizonSource exists.
+	// a dedicated service account owned by the VSphereSource is created.
 	// +optional
 	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+	// MaxEventLagSeconds is the maximum time between the creation of an event
+	// in vCenter and its delivery to the sink before the EventsFlowing
+	// condition becomes False. The condition also becomes False if no event
+	// was delivered for this time. Defaults to 300 seconds.
+	// +optional
+	MaxEventLagSeconds int64 `json:"maxEventLagSeconds,omitempty"`
+	// ShutdownGracePeriodSeconds is the time the adapter has to finish
+	// in-flight sends, save the checkpoint, report the delivery status and
+	// log out when it is stopped. The termination grace period of the
+	// adapter pod is set a few seconds above it.
+	// Defaults to 30 seconds.
+	// +optional
+	ShutdownGracePeriodSeconds int64 `json:"shutdownGracePeriodSeconds,omitempty"`
+	// Polling configures how vCenter events are read.
+	// +optional
+	Polling VPollingSpec `json:"polling"`
+	// Suspend scales the adapter to zero to pause event delivery. The
+	// checkpoint is kept and events are replayed according to
+	// CheckpointConfig when the source is resumed.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+	// Backfill delivers the vCenter events created in a past time range to
+	// the sink using a one-shot Job. The Job does not use or modify the
+	// checkpoint of the source.
+	// +optional
+	Backfill *VBackfillSpec `json:"backfill,omitempty"`
+	// CloudEventMapping configures the CloudEvent type, source and subject
+	// of the vCenter events. The CloudEvent helpers of the events package
+	// only support the default type.
+	// +optional
+	CloudEventMapping *CloudEventMapping `json:"cloudEventMapping,omitempty"`
+}
+
+// VBackfillSpec is the time range of vCenter events to backfill. Changing the
+// range replaces a running or finished backfill.
+type VBackfillSpec struct {
+	// StartTime is the creation time of the first event to deliver.
+	StartTime metav1.Time `json:"startTime"`
+	// EndTime is the creation time after which events are no longer
+	// delivered.
+	EndTime metav1.Time `json:"endTime"`
+}
+
+// VPollingSpec configures how vCenter events are read. Events are read
+// continuously, when no new events are received the delay between reads is
+// doubled from MinBackoffSeconds up to MaxBackoffSeconds.
+type VPollingSpec struct {
+	// MinBackoffSeconds defaults to 1 second.
+	// +optional
+	MinBackoffSeconds int64 `json:"minBackoffSeconds,omitempty"`
+	// MaxBackoffSeconds defaults to 5 seconds.
+	// +optional
+	MaxBackoffSeconds int64 `json:"maxBackoffSeconds,omitempty"`
+	// BatchSize is the maximum number of events read at once, up to 1000.
+	// Defaults to 100.
+	// +optional
+	BatchSize int32 `json:"batchSize,omitempty"`
 }
 
 type VCheckpointSpec struct {
 	MaxAgeSeconds int64 `json:"maxAgeSeconds"`
 	PeriodSeconds int64 `json:"periodSeconds"`
+	// Store configures where checkpoints are stored. If unspecified,
+	// checkpoints are stored in a ConfigMap owned by the VSphereSource.
+	// +optional
+	Store *VCheckpointStoreSpec `json:"store,omitempty"`
+	// RetainPolicy controls whether the checkpoint ConfigMap is deleted with
+	// the VSphereSource. Defaults to Delete.
+	// +optional
+	RetainPolicy VCheckpointRetainPolicy `json:"retainPolicy,omitempty"`
+	// MismatchPolicy controls where the adapter starts when the checkpoint
+	// does not match the vCenter, e.g. because the vCenter was replaced or
+	// restored from a backup. Defaults to ReplayMaxAge.
+	// +optional
+	MismatchPolicy VCheckpointMismatchPolicy `json:"mismatchPolicy,omitempty"`
+}
+
+// VCheckpointMismatchPolicy controls where the adapter starts when the
+// checkpoint was saved for a different vCenter instance or API version, or
+// its event key is ahead of the vCenter events.
+type VCheckpointMismatchPolicy string
+
+const (
+	// VCheckpointMismatchReplayMaxAge ignores the checkpoint and replays the
+	// events of the last maxAgeSeconds.
+	VCheckpointMismatchReplayMaxAge VCheckpointMismatchPolicy = vsphere.CheckpointMismatchReplayMaxAge
+	// VCheckpointMismatchResetToNow ignores the checkpoint and starts at the
+	// current vCenter time.
+	VCheckpointMismatchResetToNow VCheckpointMismatchPolicy = vsphere.CheckpointMismatchResetToNow
+	// VCheckpointMismatchFail stops the adapter until the checkpoint is
+	// removed.
+	VCheckpointMismatchFail VCheckpointMismatchPolicy = vsphere.CheckpointMismatchFail
+)
+
+// VCheckpointRetainPolicy controls what happens to the checkpoint ConfigMap
+// when the VSphereSource is deleted.
+type VCheckpointRetainPolicy string
+
+const (
+	// VCheckpointRetainPolicyDelete deletes the checkpoint ConfigMap with the
+	// VSphereSource.
+	VCheckpointRetainPolicyDelete VCheckpointRetainPolicy = "Delete"
+	// VCheckpointRetainPolicyRetain keeps the checkpoint ConfigMap when the
+	// VSphereSource is deleted. A VSphereSource created later in the same
+	// namespace for the same vCenter adopts the retained checkpoint.
+	VCheckpointRetainPolicyRetain VCheckpointRetainPolicy = "Retain"
+)
+
+// VCheckpointStoreType is the type of a checkpoint store.
+type VCheckpointStoreType string
+
+const (
+	// VCheckpointStoreConfigMap stores checkpoints in a ConfigMap owned by the
+	// VSphereSource.
+	VCheckpointStoreConfigMap VCheckpointStoreType = vsphere.CheckpointStoreConfigMap
+	// VCheckpointStoreStatus stores checkpoints in status.checkpoint of the
+	// VSphereSource.
+	VCheckpointStoreStatus VCheckpointStoreType = vsphere.CheckpointStoreStatus
+	// VCheckpointStoreFile stores checkpoints in a file on a
+	// PersistentVolumeClaim.
+	VCheckpointStoreFile VCheckpointStoreType = vsphere.CheckpointStoreFile
+)
+
+// VCheckpointStoreSpec configures where checkpoints are stored. When
+// switching from the ConfigMap store, the checkpoint in the ConfigMap is used
+// until the new store holds one.
+type VCheckpointStoreSpec struct {
+	Type VCheckpointStoreType `json:"type"`
+	// ClaimName is the name of the PersistentVolumeClaim holding the
+	// checkpoint file of the file store. It is required for the file store.
+	// +optional
+	ClaimName string `json:"claimName,omitempty"`
 }
 
 const (
@@ -69,11 +198,83 @@ const (
 
 	// VSphereSourceConditionAdapterReady is set to reflect the state of the adapter part of the VSphereSource.
 	VSphereSourceConditionAdapterReady = "AdapterReady"
+
+	// VSphereSourceConditionEventsFlowing is set to reflect whether events are
+	// delivered to the sink. It does not affect the Ready condition.
+	VSphereSourceConditionEventsFlowing = "EventsFlowing"
+
+	// VSphereSourceConditionSuspended is set while the adapter of the
+	// VSphereSource is scaled to zero. It does not affect the Ready condition.
+	VSphereSourceConditionSuspended = "Suspended"
+
+	// VSphereSourceConditionBackfillSucceeded is set to reflect the state of
+	// the backfill Job of the VSphereSource. It does not affect the Ready
+	// condition.
+	VSphereSourceConditionBackfillSucceeded = "BackfillSucceeded"
+
+	// VSphereSourceConditionCheckpointValid is set to reflect whether the
+	// checkpoint matches the vCenter. It does not affect the Ready condition.
+	VSphereSourceConditionCheckpointValid = "CheckpointValid"
+
+	// VSphereSourceConditionEventTypesRegistered is set to reflect whether
+	// the event types of the vCenter are registered as EventTypes of the sink
+	// broker. It does not affect the Ready condition.
+	VSphereSourceConditionEventTypesRegistered = "EventTypesRegistered"
 )
 
 // VSphereSourceStatus communicates the observed state of the VSphereSource (from the controller).
 type VSphereSourceStatus struct {
 	duckv1.SourceStatus `json:",inline"`
+
+	// Checkpoint is the last checkpoint saved by the adapter when using the
+	// status checkpoint store.
+	// +optional
+	Checkpoint *runtime.RawExtension `json:"checkpoint,omitempty"`
+
+	// EventDeliveryStatus is reported by the adapter.
+	EventDeliveryStatus `json:",inline"`
+
+	// LastEventKey is the key of the last vCenter event delivered to the sink.
+	// +optional
+	LastEventKey int32 `json:"lastEventKey,omitempty"`
+
+	// CheckpointMismatch is reported by the adapter when the checkpoint does
+	// not match the vCenter.
+	// +optional
+	CheckpointMismatch string `json:"checkpointMismatch,omitempty"`
+
+	// Backfill reports the progress of the backfill Job.
+	// +optional
+	Backfill *VBackfillStatus `json:"backfill,omitempty"`
+}
+
+// VBackfillStatus reports the progress of a backfill, it is reset when the
+// backfilled time range changes.
+type VBackfillStatus struct {
+	// StartTime and EndTime are the time range being backfilled.
+	StartTime metav1.Time `json:"startTime"`
+	EndTime   metav1.Time `json:"endTime"`
+
+	// EventsSent is the number of events of the time range delivered to the
+	// sink. A retried Job continues counting from the reported value.
+	// +optional
+	EventsSent int64 `json:"eventsSent,omitempty"`
+
+	// LastEventTime is the creation time of the last event delivered to the
+	// sink by the backfill. A retried Job resumes at this time.
+	// +optional
+	LastEventTime *metav1.Time `json:"lastEventTime,omitempty"`
+
+	// LastEventKey is the key of the last event delivered to the sink by the
+	// backfill. A retried Job skips the events created in the second of
+	// LastEventTime with a key not larger than LastEventKey.
+	// +optional
+	LastEventKey int32 `json:"lastEventKey,omitempty"`
+
+	// CompletionTime is the time the backfill delivered all events of the
+	// time range.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
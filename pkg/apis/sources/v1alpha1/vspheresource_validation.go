@@ -23,15 +23,78 @@ func (vsss *VSphereSourceSpec) Validate(ctx context.Context) *apis.FieldError {
 	errs := vsss.Sink.Validate(ctx).ViaField("sink").
 		Also(vsss.VAuthSpec.Validate(ctx)).
 		Also(vsss.CheckpointConfig.
-			Validate(ctx))
+			Validate(ctx)).
+		Also(vsss.Polling.Validate(ctx).ViaField("polling"))
+
+	if vsss.Backfill != nil {
+		errs = errs.Also(vsss.Backfill.Validate(ctx).ViaField("backfill"))
+	}
+
+	if vsss.CloudEventMapping != nil {
+		errs = errs.Also(vsss.CloudEventMapping.Validate(ctx).ViaField("cloudEventMapping"))
+	}
 
 	encoding := strings.ToLower(vsss.PayloadEncoding)
 	if (encoding != cloudevents.ApplicationJSON) && (encoding != cloudevents.ApplicationXML) {
 		errs = errs.Also(apis.ErrInvalidValue(encoding, "payloadEncoding"))
 	}
+
+	if vsss.MaxEventLagSeconds < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(vsss.MaxEventLagSeconds, "maxEventLagSeconds"))
+	}
+
+	if vsss.ShutdownGracePeriodSeconds < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(vsss.ShutdownGracePeriodSeconds, "shutdownGracePeriodSeconds"))
+	}
 	return errs
 }
 
+func (vbs VBackfillSpec) Validate(_ context.Context) (err *apis.FieldError) {
+	if vbs.StartTime.IsZero() {
+		err = err.Also(apis.ErrMissingField("startTime"))
+	}
+	if vbs.EndTime.IsZero() {
+		err = err.Also(apis.ErrMissingField("endTime"))
+	}
+	if err == nil && !vbs.StartTime.Before(&vbs.EndTime) {
+		err = &apis.FieldError{
+			Message: "startTime must be before endTime",
+			Paths:   []string{"startTime", "endTime"},
+		}
+	}
+	return err
+}
+
+// maxPollBatchSize is the maximum number of events vCenter returns at once
+const maxPollBatchSize = 1000
+
+func (vps VPollingSpec) Validate(_ context.Context) (err *apis.FieldError) {
+	err = validateBackoff(vps.MinBackoffSeconds, vps.MaxBackoffSeconds)
+
+	if vps.BatchSize < 0 || vps.BatchSize > maxPollBatchSize {
+		err = err.Also(apis.ErrOutOfBoundsValue(vps.BatchSize, 1, maxPollBatchSize, "batchSize"))
+	}
+	return err
+}
+
+// validateBackoff validates the bounds of a polling backoff, unset values
+// are defaulted
+func validateBackoff(minSeconds, maxSeconds int64) (err *apis.FieldError) {
+	if minSeconds < 0 {
+		err = err.Also(apis.ErrInvalidValue(minSeconds, "minBackoffSeconds"))
+	}
+	if maxSeconds < 0 {
+		err = err.Also(apis.ErrInvalidValue(maxSeconds, "maxBackoffSeconds"))
+	}
+	if minSeconds > 0 && maxSeconds > 0 && minSeconds > maxSeconds {
+		err = err.Also(&apis.FieldError{
+			Message: "minBackoffSeconds must not be greater than maxBackoffSeconds",
+			Paths:   []string{"minBackoffSeconds", "maxBackoffSeconds"},
+		})
+	}
+	return err
+}
+
 func (vcs VCheckpointSpec) Validate(ctx context.Context) (err *apis.FieldError) {
 	if vcs.PeriodSeconds < 0 {
 		err = err.Also(apis.ErrInvalidValue(vcs.PeriodSeconds, "checkpointConfig.periodSeconds"))
@@ -41,5 +104,38 @@ func (vcs VCheckpointSpec) Validate(ctx context.Context) (err *apis.FieldError)
 		err = err.Also(apis.ErrInvalidValue(vcs.MaxAgeSeconds, "checkpointConfig.maxAgeSeconds"))
 	}
 
+	switch vcs.RetainPolicy {
+	case "", VCheckpointRetainPolicyDelete:
+	case VCheckpointRetainPolicyRetain:
+		// only the ConfigMap store outlives the source
+		if vcs.Store != nil && vcs.Store.Type != VCheckpointStoreConfigMap {
+			err = err.Also(apis.ErrGeneric("retainPolicy Retain requires the configmap checkpoint store",
+				"checkpointConfig.retainPolicy", "checkpointConfig.store.type"))
+		}
+	default:
+		err = err.Also(apis.ErrInvalidValue(vcs.RetainPolicy, "checkpointConfig.retainPolicy"))
+	}
+
+	switch vcs.MismatchPolicy {
+	case "", VCheckpointMismatchReplayMaxAge, VCheckpointMismatchResetToNow, VCheckpointMismatchFail:
+	default:
+		err = err.Also(apis.ErrInvalidValue(vcs.MismatchPolicy, "checkpointConfig.mismatchPolicy"))
+	}
+
+	if store := vcs.Store; store != nil {
+		switch store.Type {
+		case VCheckpointStoreConfigMap, VCheckpointStoreStatus, VCheckpointStoreFile:
+		default:
+			err = err.Also(apis.ErrInvalidValue(store.Type, "checkpointConfig.store.type"))
+		}
+		if store.ClaimName != "" && store.Type != VCheckpointStoreFile {
+			err = err.Also(apis.ErrDisallowedFields("checkpointConfig.store.claimName"))
+		}
+		// the checkpoint file has to outlive the adapter pod
+		if store.ClaimName == "" && store.Type == VCheckpointStoreFile {
+			err = err.Also(apis.ErrMissingField("checkpointConfig.store.claimName"))
+		}
+	}
+
 	return err
 }
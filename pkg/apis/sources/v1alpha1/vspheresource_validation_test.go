@@ -8,6 +8,7 @@ package v1alpha1
 import (
 	"context"
 	"testing"
+	"time"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 	"knative.dev/pkg/apis"
@@ -15,6 +16,8 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/sources-for-knative/pkg/cemapping"
 )
 
 var (
@@ -130,6 +133,272 @@ func TestVSphereSourceValidation(t *testing.T) {
 		},
 		want: apis.ErrInvalidValue("-10", "spec.checkpointConfig.maxAgeSeconds").Also(apis.ErrInvalidValue("-5",
 			"spec.checkpointConfig.periodSeconds")),
+	}, {
+		name: "valid file checkpoint store",
+		c: &VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: VSphereSourceSpec{
+				SourceSpec: validSourceSpec,
+				VAuthSpec:  validVAuthSpec,
+				CheckpointConfig: VCheckpointSpec{
+					Store: &VCheckpointStoreSpec{
+						Type:      VCheckpointStoreFile,
+						ClaimName: "checkpoints",
+					},
+				},
+				PayloadEncoding: cloudevents.ApplicationXML,
+			},
+		},
+		want: nil,
+	}, {
+		name: "file checkpoint store without claim",
+		c: &VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: VSphereSourceSpec{
+				SourceSpec: validSourceSpec,
+				VAuthSpec:  validVAuthSpec,
+				CheckpointConfig: VCheckpointSpec{
+					Store: &VCheckpointStoreSpec{
+						Type: VCheckpointStoreFile,
+					},
+				},
+				PayloadEncoding: cloudevents.ApplicationXML,
+			},
+		},
+		want: apis.ErrMissingField("spec.checkpointConfig.store.claimName"),
+	}, {
+		name: "invalid checkpoint store",
+		c: &VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: VSphereSourceSpec{
+				SourceSpec: validSourceSpec,
+				VAuthSpec:  validVAuthSpec,
+				CheckpointConfig: VCheckpointSpec{
+					Store: &VCheckpointStoreSpec{
+						Type:      "etcd",
+						ClaimName: "checkpoints",
+					},
+				},
+				PayloadEncoding: cloudevents.ApplicationXML,
+			},
+		},
+		want: apis.ErrInvalidValue("etcd", "spec.checkpointConfig.store.type").Also(
+			apis.ErrDisallowedFields("spec.checkpointConfig.store.claimName")),
+	}, {
+		name: "valid retained checkpoint",
+		c: &VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: VSphereSourceSpec{
+				SourceSpec: validSourceSpec,
+				VAuthSpec:  validVAuthSpec,
+				CheckpointConfig: VCheckpointSpec{
+					RetainPolicy: VCheckpointRetainPolicyRetain,
+				},
+				PayloadEncoding: cloudevents.ApplicationXML,
+			},
+		},
+		want: nil,
+	}, {
+		name: "invalid retainPolicy",
+		c: &VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: VSphereSourceSpec{
+				SourceSpec: validSourceSpec,
+				VAuthSpec:  validVAuthSpec,
+				CheckpointConfig: VCheckpointSpec{
+					RetainPolicy: "Keep",
+				},
+				PayloadEncoding: cloudevents.ApplicationXML,
+			},
+		},
+		want: apis.ErrInvalidValue("Keep", "spec.checkpointConfig.retainPolicy"),
+	}, {
+		name: "invalid mismatchPolicy",
+		c: &VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: VSphereSourceSpec{
+				SourceSpec: validSourceSpec,
+				VAuthSpec:  validVAuthSpec,
+				CheckpointConfig: VCheckpointSpec{
+					MismatchPolicy: "Ignore",
+				},
+				PayloadEncoding: cloudevents.ApplicationXML,
+			},
+		},
+		want: apis.ErrInvalidValue("Ignore", "spec.checkpointConfig.mismatchPolicy"),
+	}, {
+		name: "retained checkpoint with status store",
+		c: &VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: VSphereSourceSpec{
+				SourceSpec: validSourceSpec,
+				VAuthSpec:  validVAuthSpec,
+				CheckpointConfig: VCheckpointSpec{
+					RetainPolicy: VCheckpointRetainPolicyRetain,
+					Store:        &VCheckpointStoreSpec{Type: VCheckpointStoreStatus},
+				},
+				PayloadEncoding: cloudevents.ApplicationXML,
+			},
+		},
+		want: apis.ErrGeneric("retainPolicy Retain requires the configmap checkpoint store",
+			"spec.checkpointConfig.retainPolicy", "spec.checkpointConfig.store.type"),
+	}, {
+		name: "invalid maxEventLagSeconds",
+		c: &VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: VSphereSourceSpec{
+				SourceSpec:         validSourceSpec,
+				VAuthSpec:          validVAuthSpec,
+				PayloadEncoding:    cloudevents.ApplicationXML,
+				MaxEventLagSeconds: -1,
+			},
+		},
+		want: apis.ErrInvalidValue("-1", "spec.maxEventLagSeconds"),
+	}, {
+		name: "invalid polling",
+		c: &VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: VSphereSourceSpec{
+				SourceSpec:      validSourceSpec,
+				VAuthSpec:       validVAuthSpec,
+				PayloadEncoding: cloudevents.ApplicationXML,
+				Polling: VPollingSpec{
+					MinBackoffSeconds: 10,
+					MaxBackoffSeconds: 5,
+					BatchSize:         5000,
+				},
+			},
+		},
+		want: (&apis.FieldError{
+			Message: "minBackoffSeconds must not be greater than maxBackoffSeconds",
+			Paths:   []string{"spec.polling.minBackoffSeconds", "spec.polling.maxBackoffSeconds"},
+		}).Also(apis.ErrOutOfBoundsValue(5000, 1, 1000, "spec.polling.batchSize")),
+	}, {
+		name: "valid backfill",
+		c: &VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: VSphereSourceSpec{
+				SourceSpec:      validSourceSpec,
+				VAuthSpec:       validVAuthSpec,
+				PayloadEncoding: cloudevents.ApplicationXML,
+				Backfill: &VBackfillSpec{
+					StartTime: metav1.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+					EndTime:   metav1.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC),
+				},
+			},
+		},
+		want: nil,
+	}, {
+		name: "backfill missing endTime",
+		c: &VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: VSphereSourceSpec{
+				SourceSpec:      validSourceSpec,
+				VAuthSpec:       validVAuthSpec,
+				PayloadEncoding: cloudevents.ApplicationXML,
+				Backfill: &VBackfillSpec{
+					StartTime: metav1.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+				},
+			},
+		},
+		want: apis.ErrMissingField("spec.backfill.endTime"),
+	}, {
+		name: "backfill startTime after endTime",
+		c: &VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: VSphereSourceSpec{
+				SourceSpec:      validSourceSpec,
+				VAuthSpec:       validVAuthSpec,
+				PayloadEncoding: cloudevents.ApplicationXML,
+				Backfill: &VBackfillSpec{
+					StartTime: metav1.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC),
+					EndTime:   metav1.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+				},
+			},
+		},
+		want: &apis.FieldError{
+			Message: "startTime must be before endTime",
+			Paths:   []string{"spec.backfill.startTime", "spec.backfill.endTime"},
+		},
+	}, {
+		name: "valid cloudEventMapping",
+		c: &VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: VSphereSourceSpec{
+				SourceSpec:      validSourceSpec,
+				VAuthSpec:       validVAuthSpec,
+				PayloadEncoding: cloudevents.ApplicationXML,
+				CloudEventMapping: &CloudEventMapping{
+					Type:    "com.example.{{ lower .Class }}.{{ lower .Type }}",
+					Source:  "urn:vcenter:{{ .InstanceUUID }}",
+					Subject: "{{ .EntityType }}/{{ .Entity }}",
+				},
+			},
+		},
+		want: nil,
+	}, {
+		name: "invalid cloudEventMapping",
+		c: &VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: VSphereSourceSpec{
+				SourceSpec:      validSourceSpec,
+				VAuthSpec:       validVAuthSpec,
+				PayloadEncoding: cloudevents.ApplicationXML,
+				CloudEventMapping: &CloudEventMapping{
+					Source: "{{ .Entity }}",
+				},
+			},
+		},
+		want: apis.ErrInvalidValue("{{ .Entity }}", "spec.cloudEventMapping.source",
+			cemapping.ValidateSourceTemplate("{{ .Entity }}").Error()),
+	}, {
+		name: "cloudEventMapping renders empty type and invalid source",
+		c: &VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: VSphereSourceSpec{
+				SourceSpec:      validSourceSpec,
+				VAuthSpec:       validVAuthSpec,
+				PayloadEncoding: cloudevents.ApplicationXML,
+				CloudEventMapping: &CloudEventMapping{
+					Type:   "{{ if false }}com.example{{ end }}",
+					Source: "vcenter {{ .InstanceUUID }}",
+				},
+			},
+		},
+		want: apis.ErrInvalidValue("{{ if false }}com.example{{ end }}", "spec.cloudEventMapping.type",
+			cemapping.ValidateTypeTemplate("{{ if false }}com.example{{ end }}").Error()).Also(
+			apis.ErrInvalidValue("vcenter {{ .InstanceUUID }}", "spec.cloudEventMapping.source",
+				cemapping.ValidateSourceTemplate("vcenter {{ .InstanceUUID }}").Error())),
 	}}
 
 	for _, test := range tests {
@@ -11,9 +11,61 @@ SPDX-License-Identifier: Apache-2.0
 package v1alpha1
 
 import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	duckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	apis "knative.dev/pkg/apis"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudEventMapping) DeepCopyInto(out *CloudEventMapping) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudEventMapping.
+func (in *CloudEventMapping) DeepCopy() *CloudEventMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudEventMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventDeliveryStatus) DeepCopyInto(out *EventDeliveryStatus) {
+	*out = *in
+	if in.LastEventTime != nil {
+		in, out := &in.LastEventTime, &out.LastEventTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSentTime != nil {
+		in, out := &in.LastSentTime, &out.LastSentTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastPollTime != nil {
+		in, out := &in.LastPollTime, &out.LastPollTime
+		*out = (*in).DeepCopy()
+	}
+	if in.EventLag != nil {
+		in, out := &in.EventLag, &out.EventLag
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventDeliveryStatus.
+func (in *EventDeliveryStatus) DeepCopy() *EventDeliveryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EventDeliveryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HorizonAuthSpec) DeepCopyInto(out *HorizonAuthSpec) {
 	*out = *in
@@ -32,6 +84,185 @@ func (in *HorizonAuthSpec) DeepCopy() *HorizonAuthSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HorizonBinding) DeepCopyInto(out *HorizonBinding) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HorizonBinding.
+func (in *HorizonBinding) DeepCopy() *HorizonBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(HorizonBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HorizonBinding) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HorizonBindingList) DeepCopyInto(out *HorizonBindingList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]HorizonBinding, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HorizonBindingList.
+func (in *HorizonBindingList) DeepCopy() *HorizonBindingList {
+	if in == nil {
+		return nil
+	}
+	out := new(HorizonBindingList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HorizonBindingList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HorizonBindingSpec) DeepCopyInto(out *HorizonBindingSpec) {
+	*out = *in
+	in.BindingSpec.DeepCopyInto(&out.BindingSpec)
+	in.HorizonAuthSpec.DeepCopyInto(&out.HorizonAuthSpec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HorizonBindingSpec.
+func (in *HorizonBindingSpec) DeepCopy() *HorizonBindingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HorizonBindingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HorizonBindingStatus) DeepCopyInto(out *HorizonBindingStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HorizonBindingStatus.
+func (in *HorizonBindingStatus) DeepCopy() *HorizonBindingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HorizonBindingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HorizonEventFilter) DeepCopyInto(out *HorizonEventFilter) {
+	*out = *in
+	if in.Types != nil {
+		in, out := &in.Types, &out.Types
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Severities != nil {
+		in, out := &in.Severities, &out.Severities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Modules != nil {
+		in, out := &in.Modules, &out.Modules
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DesktopPoolNames != nil {
+		in, out := &in.DesktopPoolNames, &out.DesktopPoolNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ApplicationPoolNames != nil {
+		in, out := &in.ApplicationPoolNames, &out.ApplicationPoolNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HorizonEventFilter.
+func (in *HorizonEventFilter) DeepCopy() *HorizonEventFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(HorizonEventFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HorizonEventPosition) DeepCopyInto(out *HorizonEventPosition) {
+	*out = *in
+	if in.EventIDs != nil {
+		in, out := &in.EventIDs, &out.EventIDs
+		*out = make([]int64, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HorizonEventPosition.
+func (in *HorizonEventPosition) DeepCopy() *HorizonEventPosition {
+	if in == nil {
+		return nil
+	}
+	out := new(HorizonEventPosition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HorizonPollingSpec) DeepCopyInto(out *HorizonPollingSpec) {
+	*out = *in
+	if in.SendRetries != nil {
+		in, out := &in.SendRetries, &out.SendRetries
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HorizonPollingSpec.
+func (in *HorizonPollingSpec) DeepCopy() *HorizonPollingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HorizonPollingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HorizonSource) DeepCopyInto(out *HorizonSource) {
 	*out = *in
@@ -98,6 +329,22 @@ func (in *HorizonSourceSpec) DeepCopyInto(out *HorizonSourceSpec) {
 	*out = *in
 	in.SourceSpec.DeepCopyInto(&out.SourceSpec)
 	in.HorizonAuthSpec.DeepCopyInto(&out.HorizonAuthSpec)
+	if in.Filter != nil {
+		in, out := &in.Filter, &out.Filter
+		*out = new(HorizonEventFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Delivery != nil {
+		in, out := &in.Delivery, &out.Delivery
+		*out = new(duckv1.DeliverySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Polling.DeepCopyInto(&out.Polling)
+	if in.CloudEventMapping != nil {
+		in, out := &in.CloudEventMapping, &out.CloudEventMapping
+		*out = new(CloudEventMapping)
+		**out = **in
+	}
 	return
 }
 
@@ -115,6 +362,17 @@ func (in *HorizonSourceSpec) DeepCopy() *HorizonSourceSpec {
 func (in *HorizonSourceStatus) DeepCopyInto(out *HorizonSourceStatus) {
 	*out = *in
 	in.SourceStatus.DeepCopyInto(&out.SourceStatus)
+	in.EventDeliveryStatus.DeepCopyInto(&out.EventDeliveryStatus)
+	if in.LastEventPosition != nil {
+		in, out := &in.LastEventPosition, &out.LastEventPosition
+		*out = new(HorizonEventPosition)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeadLetterSinkURI != nil {
+		in, out := &in.DeadLetterSinkURI, &out.DeadLetterSinkURI
+		*out = new(apis.URL)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -146,9 +404,58 @@ func (in *VAuthSpec) DeepCopy() *VAuthSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VBackfillSpec) DeepCopyInto(out *VBackfillSpec) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	in.EndTime.DeepCopyInto(&out.EndTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VBackfillSpec.
+func (in *VBackfillSpec) DeepCopy() *VBackfillSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VBackfillSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VBackfillStatus) DeepCopyInto(out *VBackfillStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	in.EndTime.DeepCopyInto(&out.EndTime)
+	if in.LastEventTime != nil {
+		in, out := &in.LastEventTime, &out.LastEventTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VBackfillStatus.
+func (in *VBackfillStatus) DeepCopy() *VBackfillStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VBackfillStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VCheckpointSpec) DeepCopyInto(out *VCheckpointSpec) {
 	*out = *in
+	if in.Store != nil {
+		in, out := &in.Store, &out.Store
+		*out = new(VCheckpointStoreSpec)
+		**out = **in
+	}
 	return
 }
 
@@ -162,6 +469,167 @@ func (in *VCheckpointSpec) DeepCopy() *VCheckpointSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VCheckpointStoreSpec) DeepCopyInto(out *VCheckpointStoreSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VCheckpointStoreSpec.
+func (in *VCheckpointStoreSpec) DeepCopy() *VCheckpointStoreSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VCheckpointStoreSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VPollingSpec) DeepCopyInto(out *VPollingSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPollingSpec.
+func (in *VPollingSpec) DeepCopy() *VPollingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VPollingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereAction) DeepCopyInto(out *VSphereAction) {
+	*out = *in
+	if in.Snapshot != nil {
+		in, out := &in.Snapshot, &out.Snapshot
+		*out = new(VSphereSnapshotAction)
+		**out = **in
+	}
+	if in.CustomAttribute != nil {
+		in, out := &in.CustomAttribute, &out.CustomAttribute
+		*out = new(VSphereCustomAttributeAction)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereAction.
+func (in *VSphereAction) DeepCopy() *VSphereAction {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereActionSink) DeepCopyInto(out *VSphereActionSink) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereActionSink.
+func (in *VSphereActionSink) DeepCopy() *VSphereActionSink {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereActionSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VSphereActionSink) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereActionSinkList) DeepCopyInto(out *VSphereActionSinkList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VSphereActionSink, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereActionSinkList.
+func (in *VSphereActionSinkList) DeepCopy() *VSphereActionSinkList {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereActionSinkList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VSphereActionSinkList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereActionSinkSpec) DeepCopyInto(out *VSphereActionSinkSpec) {
+	*out = *in
+	in.VAuthSpec.DeepCopyInto(&out.VAuthSpec)
+	if in.Actions != nil {
+		in, out := &in.Actions, &out.Actions
+		*out = make([]VSphereAction, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereActionSinkSpec.
+func (in *VSphereActionSinkSpec) DeepCopy() *VSphereActionSinkSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereActionSinkSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereActionSinkStatus) DeepCopyInto(out *VSphereActionSinkStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	in.AddressStatus.DeepCopyInto(&out.AddressStatus)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereActionSinkStatus.
+func (in *VSphereActionSinkStatus) DeepCopy() *VSphereActionSinkStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereActionSinkStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VSphereBinding) DeepCopyInto(out *VSphereBinding) {
 	*out = *in
@@ -190,6 +658,22 @@ func (in *VSphereBinding) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereBindingEnv) DeepCopyInto(out *VSphereBindingEnv) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereBindingEnv.
+func (in *VSphereBindingEnv) DeepCopy() *VSphereBindingEnv {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereBindingEnv)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VSphereBindingList) DeepCopyInto(out *VSphereBindingList) {
 	*out = *in
@@ -228,6 +712,16 @@ func (in *VSphereBindingSpec) DeepCopyInto(out *VSphereBindingSpec) {
 	*out = *in
 	in.BindingSpec.DeepCopyInto(&out.BindingSpec)
 	in.VAuthSpec.DeepCopyInto(&out.VAuthSpec)
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = new(VSphereBindingEnv)
+		**out = **in
+	}
+	if in.ContainerNames != nil {
+		in, out := &in.ContainerNames, &out.ContainerNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -258,6 +752,38 @@ func (in *VSphereBindingStatus) DeepCopy() *VSphereBindingStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereCustomAttributeAction) DeepCopyInto(out *VSphereCustomAttributeAction) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereCustomAttributeAction.
+func (in *VSphereCustomAttributeAction) DeepCopy() *VSphereCustomAttributeAction {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereCustomAttributeAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereSnapshotAction) DeepCopyInto(out *VSphereSnapshotAction) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereSnapshotAction.
+func (in *VSphereSnapshotAction) DeepCopy() *VSphereSnapshotAction {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereSnapshotAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VSphereSource) DeepCopyInto(out *VSphereSource) {
 	*out = *in
@@ -324,7 +850,18 @@ func (in *VSphereSourceSpec) DeepCopyInto(out *VSphereSourceSpec) {
 	*out = *in
 	in.SourceSpec.DeepCopyInto(&out.SourceSpec)
 	in.VAuthSpec.DeepCopyInto(&out.VAuthSpec)
-	out.CheckpointConfig = in.CheckpointConfig
+	in.CheckpointConfig.DeepCopyInto(&out.CheckpointConfig)
+	out.Polling = in.Polling
+	if in.Backfill != nil {
+		in, out := &in.Backfill, &out.Backfill
+		*out = new(VBackfillSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CloudEventMapping != nil {
+		in, out := &in.CloudEventMapping, &out.CloudEventMapping
+		*out = new(CloudEventMapping)
+		**out = **in
+	}
 	return
 }
 
@@ -342,6 +879,17 @@ func (in *VSphereSourceSpec) DeepCopy() *VSphereSourceSpec {
 func (in *VSphereSourceStatus) DeepCopyInto(out *VSphereSourceStatus) {
 	*out = *in
 	in.SourceStatus.DeepCopyInto(&out.SourceStatus)
+	if in.Checkpoint != nil {
+		in, out := &in.Checkpoint, &out.Checkpoint
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	in.EventDeliveryStatus.DeepCopyInto(&out.EventDeliveryStatus)
+	if in.Backfill != nil {
+		in, out := &in.Backfill, &out.Backfill
+		*out = new(VBackfillStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
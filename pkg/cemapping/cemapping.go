@@ -0,0 +1,219 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package cemapping renders the CloudEvent type, source and subject of the
+// events produced by the source adapters from Go templates, e.g.
+//
+//	type: com.example.{{ lower .Type }}
+//	source: urn:vcenter:{{ .InstanceUUID }}
+//	subject: {{ .EntityType }}/{{ .Entity }}
+package cemapping
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"strings"
+	"text/template"
+)
+
+// Config holds the templates of the CloudEvent attributes. An empty template
+// uses the default of the adapter.
+type Config struct {
+	// Type is rendered with EventData and must not be empty.
+	Type string `json:"type,omitempty"`
+	// Source is rendered once with SourceData and must not be empty.
+	Source string `json:"source,omitempty"`
+	// Subject is rendered with EventData, an empty subject is not set.
+	Subject string `json:"subject,omitempty"`
+}
+
+// SourceData is the data of the source template.
+type SourceData struct {
+	// Address is the address of the vCenter or Horizon server.
+	Address string
+	// InstanceUUID is the instance UUID of the vCenter, empty for Horizon.
+	InstanceUUID string
+}
+
+// EventData is the data of the type and subject templates.
+type EventData struct {
+	// Type is the vSphere or Horizon event type, e.g. VmPoweredOnEvent or
+	// VLSI_USERLOGGEDIN.
+	Type string
+	// Class is the vSphere event class, e.g. event, empty for Horizon.
+	Class string
+	// Entity is the ID of the primary entity of the event, e.g. the managed
+	// object ID vm-42 or the Horizon machine ID. It is empty if the event has
+	// no entity.
+	Entity string
+	// EntityType is the type of Entity, e.g. VirtualMachine or HostSystem,
+	// or Machine for Horizon.
+	EntityType string
+}
+
+var funcs = template.FuncMap{
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+}
+
+// Mapper renders the CloudEvent attributes of events.
+type Mapper struct {
+	typ     *template.Template
+	source  *template.Template
+	subject *template.Template
+}
+
+// New returns a Mapper for c, empty templates of c are taken from defaults.
+func New(c, defaults Config) (*Mapper, error) {
+	if c.Type == "" {
+		c.Type = defaults.Type
+	}
+	if c.Source == "" {
+		c.Source = defaults.Source
+	}
+	if c.Subject == "" {
+		c.Subject = defaults.Subject
+	}
+
+	var (
+		m   Mapper
+		err error
+	)
+	if m.typ, err = parse("type", c.Type); err != nil {
+		return nil, err
+	}
+	if m.source, err = parse("source", c.Source); err != nil {
+		return nil, err
+	}
+	if m.subject, err = parse("subject", c.Subject); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// MustNew is like New but panics on an invalid template.
+func MustNew(c, defaults Config) *Mapper {
+	m, err := New(c, defaults)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// Source returns the CloudEvent source.
+func (m *Mapper) Source(d SourceData) (string, error) {
+	s, err := render("source", m.source, d, true)
+	if err != nil {
+		return "", err
+	}
+	if err := uriReference(s); err != nil {
+		return "", fmt.Errorf("render source template: %w", err)
+	}
+	return s, nil
+}
+
+// Type returns the CloudEvent type of an event.
+func (m *Mapper) Type(d EventData) (string, error) {
+	return render("type", m.typ, d, true)
+}
+
+// Subject returns the CloudEvent subject of an event, which may be empty.
+func (m *Mapper) Subject(d EventData) (string, error) {
+	return render("subject", m.subject, d, false)
+}
+
+// sampleSourceData and sampleEventData are the data templates are rendered
+// with during validation.
+var (
+	sampleSourceData = SourceData{
+		Address:      "https://vcenter.local/sdk",
+		InstanceUUID: "dbed6e0c-bd88-4ef6-b594-21283e1c677f",
+	}
+	sampleEventData = EventData{
+		Type:       "VmPoweredOnEvent",
+		Class:      "event",
+		Entity:     "vm-42",
+		EntityType: "VirtualMachine",
+	}
+)
+
+// ValidateSourceTemplate returns an error if text is not a valid source
+// template or does not render a URI reference.
+func ValidateSourceTemplate(text string) error {
+	s, err := validate("source", text, sampleSourceData)
+	if err != nil || text == "" {
+		return err
+	}
+	if s == "" {
+		return fmt.Errorf("render source template: empty source for %s", sampleSourceData.Address)
+	}
+	return uriReference(s)
+}
+
+// ValidateTypeTemplate returns an error if text is not a valid type template
+// or renders empty.
+func ValidateTypeTemplate(text string) error {
+	s, err := validate("type", text, sampleEventData)
+	if err == nil && text != "" && s == "" {
+		return fmt.Errorf("render type template: empty type for %s", sampleEventData.Type)
+	}
+	return err
+}
+
+// ValidateEventTemplate returns an error if text is not a valid subject
+// template.
+func ValidateEventTemplate(text string) error {
+	_, err := validate("subject", text, sampleEventData)
+	return err
+}
+
+// validate returns the rendered template, empty if text is empty
+func validate(name, text string, data interface{}) (string, error) {
+	t, err := parse(name, text)
+	if err != nil {
+		return "", err
+	}
+	// fields which do not exist are only detected when executing
+	return render(name, t, data, false)
+}
+
+// uriReference returns an error if s is not a URI reference
+func uriReference(s string) error {
+	if strings.ContainsAny(s, " \t\r\n") {
+		return fmt.Errorf("%q is not a URI reference", s)
+	}
+	if _, err := url.Parse(s); err != nil {
+		return fmt.Errorf("%q is not a URI reference: %w", s, err)
+	}
+	return nil
+}
+
+// parse returns the parsed template, nil if text is empty
+func parse(name, text string) (*template.Template, error) {
+	if text == "" {
+		return nil, nil
+	}
+	t, err := template.New(name).Funcs(funcs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s template: %w", name, err)
+	}
+	return t, nil
+}
+
+func render(name string, t *template.Template, data interface{}, required bool) (string, error) {
+	var b bytes.Buffer
+	if t != nil {
+		if err := t.Execute(&b, data); err != nil {
+			return "", fmt.Errorf("render %s template: %w", name, err)
+		}
+	}
+
+	s := strings.TrimSpace(b.String())
+	if s == "" && required {
+		return "", fmt.Errorf("render %s template: empty %s", name, name)
+	}
+	return s, nil
+}
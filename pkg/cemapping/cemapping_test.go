@@ -0,0 +1,147 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cemapping
+
+import (
+	"testing"
+)
+
+var testDefaults = Config{
+	Type:    "com.example.{{ .Type }}.v0",
+	Source:  "{{ .Address }}",
+	Subject: "{{ .Entity }}",
+}
+
+func TestMapper(t *testing.T) {
+	event := EventData{
+		Type:       "VmPoweredOnEvent",
+		Class:      "event",
+		Entity:     "vm-42",
+		EntityType: "VirtualMachine",
+	}
+	source := SourceData{
+		Address:      "https://vcenter.local/sdk",
+		InstanceUUID: "dbed6e0c-bd88-4ef6-b594-21283e1c677f",
+	}
+
+	tests := []struct {
+		name        string
+		config      Config
+		event       EventData
+		wantType    string
+		wantSource  string
+		wantSubject string
+	}{{
+		name:        "defaults",
+		event:       event,
+		wantType:    "com.example.VmPoweredOnEvent.v0",
+		wantSource:  "https://vcenter.local/sdk",
+		wantSubject: "vm-42",
+	}, {
+		name: "custom mapping",
+		config: Config{
+			Type:    "com.corp.{{ lower .Class }}.{{ lower .Type }}",
+			Source:  "urn:vcenter:{{ .InstanceUUID }}",
+			Subject: "{{ .EntityType }}/{{ .Entity }}",
+		},
+		event:       event,
+		wantType:    "com.corp.event.vmpoweredonevent",
+		wantSource:  "urn:vcenter:dbed6e0c-bd88-4ef6-b594-21283e1c677f",
+		wantSubject: "VirtualMachine/vm-42",
+	}, {
+		name:        "event without entity",
+		event:       EventData{Type: "UserLoginSessionEvent"},
+		wantType:    "com.example.UserLoginSessionEvent.v0",
+		wantSource:  "https://vcenter.local/sdk",
+		wantSubject: "",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := New(tt.config, testDefaults)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			if got, err := m.Type(tt.event); err != nil || got != tt.wantType {
+				t.Errorf("Type() = %q, %v, want %q", got, err, tt.wantType)
+			}
+			if got, err := m.Source(source); err != nil || got != tt.wantSource {
+				t.Errorf("Source() = %q, %v, want %q", got, err, tt.wantSource)
+			}
+			if got, err := m.Subject(tt.event); err != nil || got != tt.wantSubject {
+				t.Errorf("Subject() = %q, %v, want %q", got, err, tt.wantSubject)
+			}
+		})
+	}
+}
+
+func TestMapper_emptyAttribute(t *testing.T) {
+	m, err := New(Config{Source: "{{ .InstanceUUID }}", Type: "{{ .Entity }}"}, testDefaults)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if _, err := m.Source(SourceData{Address: "horizon.local"}); err == nil {
+		t.Error("Source() error = nil, want error for empty source")
+	}
+	if _, err := m.Type(EventData{Type: "VmPoweredOnEvent"}); err == nil {
+		t.Error("Type() error = nil, want error for empty type")
+	}
+}
+
+func TestValidateTemplate(t *testing.T) {
+	if err := ValidateEventTemplate("{{ upper .Type }}"); err != nil {
+		t.Errorf("ValidateEventTemplate() error = %v", err)
+	}
+	if err := ValidateEventTemplate(""); err != nil {
+		t.Errorf("ValidateEventTemplate() of empty template error = %v", err)
+	}
+	if err := ValidateEventTemplate("{{ .Type"); err == nil {
+		t.Error("ValidateEventTemplate() error = nil, want parse error")
+	}
+	if err := ValidateEventTemplate("{{ .Address }}"); err == nil {
+		t.Error("ValidateEventTemplate() error = nil, want error for source field")
+	}
+	if err := ValidateSourceTemplate("urn:vcenter:{{ .InstanceUUID }}"); err != nil {
+		t.Errorf("ValidateSourceTemplate() error = %v", err)
+	}
+	if err := ValidateSourceTemplate("{{ .Entity }}"); err == nil {
+		t.Error("ValidateSourceTemplate() error = nil, want error for event field")
+	}
+	if err := ValidateSourceTemplate(`{{ if eq .Address "" }}urn:horizon{{ end }}`); err == nil {
+		t.Error("ValidateSourceTemplate() error = nil, want error for empty source")
+	}
+	if err := ValidateSourceTemplate("vcenter {{ .InstanceUUID }}"); err == nil {
+		t.Error("ValidateSourceTemplate() error = nil, want error for invalid URI reference")
+	}
+	if err := ValidateSourceTemplate("https://{{ .InstanceUUID }}:port/"); err == nil {
+		t.Error("ValidateSourceTemplate() error = nil, want error for invalid URI reference")
+	}
+	if err := ValidateTypeTemplate("com.example.{{ .Type }}"); err != nil {
+		t.Errorf("ValidateTypeTemplate() error = %v", err)
+	}
+	if err := ValidateTypeTemplate(""); err != nil {
+		t.Errorf("ValidateTypeTemplate() of empty template error = %v", err)
+	}
+	if err := ValidateTypeTemplate(`{{ if eq .Class "eventex" }}{{ .Type }}{{ end }}`); err == nil {
+		t.Error("ValidateTypeTemplate() error = nil, want error for empty type")
+	}
+	if err := ValidateTypeTemplate("{{ .Address }}"); err == nil {
+		t.Error("ValidateTypeTemplate() error = nil, want error for source field")
+	}
+}
+
+func TestMapper_invalidSource(t *testing.T) {
+	m, err := New(Config{Source: "vcenter {{ .Address }}"}, testDefaults)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if _, err := m.Source(SourceData{Address: "vcenter.local"}); err == nil {
+		t.Error("Source() error = nil, want error for invalid URI reference")
+	}
+}
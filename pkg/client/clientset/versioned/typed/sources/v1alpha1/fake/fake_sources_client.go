@@ -17,10 +17,18 @@ type FakeSourcesV1alpha1 struct {
 	*testing.Fake
 }
 
+func (c *FakeSourcesV1alpha1) HorizonBindings(namespace string) v1alpha1.HorizonBindingInterface {
+	return &FakeHorizonBindings{c, namespace}
+}
+
 func (c *FakeSourcesV1alpha1) HorizonSources(namespace string) v1alpha1.HorizonSourceInterface {
 	return &FakeHorizonSources{c, namespace}
 }
 
+func (c *FakeSourcesV1alpha1) VSphereActionSinks(namespace string) v1alpha1.VSphereActionSinkInterface {
+	return &FakeVSphereActionSinks{c, namespace}
+}
+
 func (c *FakeSourcesV1alpha1) VSphereBindings(namespace string) v1alpha1.VSphereBindingInterface {
 	return &FakeVSphereBindings{c, namespace}
 }
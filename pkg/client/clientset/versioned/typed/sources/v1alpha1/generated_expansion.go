@@ -7,8 +7,12 @@ SPDX-License-Identifier: Apache-2.0
 
 package v1alpha1
 
+type HorizonBindingExpansion interface{}
+
 type HorizonSourceExpansion interface{}
 
+type VSphereActionSinkExpansion interface{}
+
 type VSphereBindingExpansion interface{}
 
 type VSphereSourceExpansion interface{}
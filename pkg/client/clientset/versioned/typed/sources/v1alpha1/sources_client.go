@@ -17,7 +17,9 @@ import (
 
 type SourcesV1alpha1Interface interface {
 	RESTClient() rest.Interface
+	HorizonBindingsGetter
 	HorizonSourcesGetter
+	VSphereActionSinksGetter
 	VSphereBindingsGetter
 	VSphereSourcesGetter
 }
@@ -27,10 +29,18 @@ type SourcesV1alpha1Client struct {
 	restClient rest.Interface
 }
 
+func (c *SourcesV1alpha1Client) HorizonBindings(namespace string) HorizonBindingInterface {
+	return newHorizonBindings(c, namespace)
+}
+
 func (c *SourcesV1alpha1Client) HorizonSources(namespace string) HorizonSourceInterface {
 	return newHorizonSources(c, namespace)
 }
 
+func (c *SourcesV1alpha1Client) VSphereActionSinks(namespace string) VSphereActionSinkInterface {
+	return newVSphereActionSinks(c, namespace)
+}
+
 func (c *SourcesV1alpha1Client) VSphereBindings(namespace string) VSphereBindingInterface {
 	return newVSphereBindings(c, namespace)
 }
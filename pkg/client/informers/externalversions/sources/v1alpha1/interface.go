@@ -13,8 +13,12 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// HorizonBindings returns a HorizonBindingInformer.
+	HorizonBindings() HorizonBindingInformer
 	// HorizonSources returns a HorizonSourceInformer.
 	HorizonSources() HorizonSourceInformer
+	// VSphereActionSinks returns a VSphereActionSinkInformer.
+	VSphereActionSinks() VSphereActionSinkInformer
 	// VSphereBindings returns a VSphereBindingInformer.
 	VSphereBindings() VSphereBindingInformer
 	// VSphereSources returns a VSphereSourceInformer.
@@ -32,11 +36,21 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// HorizonBindings returns a HorizonBindingInformer.
+func (v *version) HorizonBindings() HorizonBindingInformer {
+	return &horizonBindingInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // HorizonSources returns a HorizonSourceInformer.
 func (v *version) HorizonSources() HorizonSourceInformer {
 	return &horizonSourceInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// VSphereActionSinks returns a VSphereActionSinkInformer.
+func (v *version) VSphereActionSinks() VSphereActionSinkInformer {
+	return &vSphereActionSinkInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // VSphereBindings returns a VSphereBindingInformer.
 func (v *version) VSphereBindings() VSphereBindingInformer {
 	return &vSphereBindingInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
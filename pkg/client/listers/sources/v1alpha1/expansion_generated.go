@@ -7,6 +7,14 @@ SPDX-License-Identifier: Apache-2.0
 
 package v1alpha1
 
+// HorizonBindingListerExpansion allows custom methods to be added to
+// HorizonBindingLister.
+type HorizonBindingListerExpansion interface{}
+
+// HorizonBindingNamespaceListerExpansion allows custom methods to be added to
+// HorizonBindingNamespaceLister.
+type HorizonBindingNamespaceListerExpansion interface{}
+
 // HorizonSourceListerExpansion allows custom methods to be added to
 // HorizonSourceLister.
 type HorizonSourceListerExpansion interface{}
@@ -15,6 +23,14 @@ type HorizonSourceListerExpansion interface{}
 // HorizonSourceNamespaceLister.
 type HorizonSourceNamespaceListerExpansion interface{}
 
+// VSphereActionSinkListerExpansion allows custom methods to be added to
+// VSphereActionSinkLister.
+type VSphereActionSinkListerExpansion interface{}
+
+// VSphereActionSinkNamespaceListerExpansion allows custom methods to be added to
+// VSphereActionSinkNamespaceLister.
+type VSphereActionSinkNamespaceListerExpansion interface{}
+
 // VSphereBindingListerExpansion allows custom methods to be added to
 // VSphereBindingLister.
 type VSphereBindingListerExpansion interface{}
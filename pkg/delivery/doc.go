@@ -0,0 +1,14 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package delivery reports the delivery of events by a source adapter in the
+// status of its source. A Reporter records polls, sent events and failures
+// and periodically patches the status subresource of the source:
+//
+//	r := delivery.NewReporter(client.Resource(gvr).Namespace(ns), name, "lastEventID")
+//	go r.Run(ctx, 10*time.Second)
+//	r.Polled()
+//	r.Sent(1, id, created)
+package delivery
@@ -0,0 +1,294 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package delivery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"knative.dev/pkg/logging"
+)
+
+const (
+	// flushTimeout bounds the final status update when the reporter is stopped.
+	flushTimeout = 5 * time.Second
+
+	// HeartbeatPeriod is the maximum age of the poll time reported by an
+	// adapter which keeps reading events from its source.
+	HeartbeatPeriod = 30 * time.Second
+)
+
+// Reporter records the delivery of events to the sink and reports it in the
+// status of a source. A nil Reporter discards all reports.
+type Reporter struct {
+	client  dynamic.ResourceInterface
+	name    string
+	idField string
+
+	mu        sync.Mutex
+	dirty     bool
+	id        interface{}
+	eventTime time.Time
+	sentTime  time.Time
+	total     int64
+	lastError string
+	posField  string
+	position  interface{}
+	// delay between creation and delivery of the last live event
+	lag *time.Duration
+
+	pollTime         time.Time
+	reportedPollTime time.Time
+}
+
+// NewReporter returns a Reporter for the source with the given name. The ID of
+// the last delivered event is reported in status.<idField>.
+func NewReporter(client dynamic.ResourceInterface, name, idField string) *Reporter {
+	return &Reporter{
+		client:  client,
+		name:    name,
+		idField: idField,
+	}
+}
+
+// Init adds the number of events sent reported in the status of the source to
+// the total, so the total survives adapter restarts.
+func (r *Reporter) Init(ctx context.Context) error {
+	if r == nil {
+		return nil
+	}
+
+	src, err := r.client.Get(ctx, r.name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("get source %q: %w", r.name, err)
+	}
+
+	total, _, err := unstructured.NestedInt64(src.Object, "status", "eventsSentTotal")
+	if err != nil {
+		return fmt.Errorf("read status.eventsSentTotal of source %q: %w", r.name, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.total += total
+	return nil
+}
+
+// LastPosition decodes the position reported in status.<field> of the source
+// into position. It returns false if no position was reported.
+func (r *Reporter) LastPosition(ctx context.Context, field string, position interface{}) (bool, error) {
+	if r == nil {
+		return false, nil
+	}
+
+	src, err := r.client.Get(ctx, r.name, metav1.GetOptions{})
+	if err != nil {
+		return false, fmt.Errorf("get source %q: %w", r.name, err)
+	}
+
+	value, found, err := unstructured.NestedFieldNoCopy(src.Object, "status", field)
+	if err != nil || !found {
+		return false, err
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return false, err
+	}
+	if err := json.Unmarshal(b, position); err != nil {
+		return false, fmt.Errorf("read status.%s of source %q: %w", field, r.name, err)
+	}
+	return true, nil
+}
+
+// Advance records the position of the adapter in the event stream, which is
+// reported in status.<field>. Unlike the ID of the last delivered event, the
+// position covers dead-lettered and skipped events as well, so an adapter can
+// resume after every event processed before it was restarted.
+func (r *Reporter) Advance(field string, position interface{}) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.posField = field
+	r.position = position
+	r.dirty = true
+}
+
+// Sent records the delivery of n live events, i.e. events read as they were
+// created, the last one with the given ID and creation time. The delay between
+// creation and delivery of the last event is reported as the event lag. It
+// clears the last error.
+func (r *Reporter) Sent(n int, id interface{}, created time.Time) {
+	if r == nil || n == 0 {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sent(n, id, created)
+	lag := r.sentTime.Sub(created)
+	r.lag = &lag
+}
+
+// Replayed records the delivery of n events which were created before they
+// were read, e.g. replayed from a checkpoint after a restart. Unlike Sent, it
+// does not report their delay as event lag.
+func (r *Reporter) Replayed(n int, id interface{}, created time.Time) {
+	if r == nil || n == 0 {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sent(n, id, created)
+}
+
+func (r *Reporter) sent(n int, id interface{}, created time.Time) {
+	r.id = id
+	r.eventTime = created
+	r.sentTime = time.Now()
+	r.total += int64(n)
+	r.lastError = ""
+	r.dirty = true
+}
+
+// Polled records that the adapter read events from its source, whether or not
+// there were new events. The poll time is reported at most every
+// HeartbeatPeriod, so a quiet source is not mistaken for a stalled adapter.
+func (r *Reporter) Polled() {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.pollTime = time.Now()
+	if r.pollTime.Sub(r.reportedPollTime) >= HeartbeatPeriod {
+		r.dirty = true
+	}
+}
+
+// Failed records a failed delivery.
+func (r *Reporter) Failed(err error) {
+	if r == nil || err == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lastError = err.Error()
+	r.dirty = true
+}
+
+// Flush patches the status of the source if anything was recorded since the
+// last flush.
+func (r *Reporter) Flush(ctx context.Context) error {
+	if r == nil {
+		return nil
+	}
+
+	r.mu.Lock()
+	if !r.dirty {
+		r.mu.Unlock()
+		return nil
+	}
+
+	status := map[string]interface{}{
+		"eventsSentTotal": r.total,
+		// null removes a previously reported error
+		"lastError": nil,
+	}
+	if r.lastError != "" {
+		status["lastError"] = r.lastError
+	}
+	if !r.sentTime.IsZero() {
+		status["lastEventTime"] = metav1.NewTime(r.eventTime)
+		status["lastSentTime"] = metav1.NewTime(r.sentTime)
+		status[r.idField] = r.id
+	}
+	if r.lag != nil {
+		status["eventLag"] = metav1.Duration{Duration: *r.lag}
+	}
+	if !r.pollTime.IsZero() {
+		status["lastPollTime"] = metav1.NewTime(r.pollTime)
+		r.reportedPollTime = r.pollTime
+	}
+	if r.posField != "" {
+		status[r.posField] = r.position
+	}
+	r.dirty = false
+	r.mu.Unlock()
+
+	patch, err := json.Marshal(map[string]interface{}{"status": status})
+	if err != nil {
+		return fmt.Errorf("marshal status patch: %w", err)
+	}
+
+	_, err = r.client.Patch(ctx, r.name, types.MergePatchType, patch, metav1.PatchOptions{}, "status")
+	if err != nil {
+		r.mu.Lock()
+		r.dirty = true
+		r.mu.Unlock()
+		return fmt.Errorf("patch status of source %q: %w", r.name, err)
+	}
+	return nil
+}
+
+// Run flushes the recorded deliveries every period until the context is
+// canceled, followed by a final flush.
+func (r *Reporter) Run(ctx context.Context, period time.Duration) {
+	if r == nil {
+		return
+	}
+
+	r.Report(ctx, period)
+
+	// using fresh ctx to avoid canceled error during the final flush
+	flushCtx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+	defer cancel()
+	if err := r.Flush(flushCtx); err != nil {
+		logging.FromContext(ctx).Warnw("could not report delivery status", zap.Error(err))
+	}
+}
+
+// Report flushes the recorded deliveries every period until the context is
+// canceled. Unlike Run, it does not flush when the context is canceled, so
+// the caller can flush the last deliveries within its own shutdown deadline.
+func (r *Reporter) Report(ctx context.Context, period time.Duration) {
+	if r == nil {
+		return
+	}
+
+	logger := logging.FromContext(ctx)
+	if err := r.Init(ctx); err != nil {
+		logger.Warnw("could not read delivery status", zap.Error(err))
+	}
+
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-ticker.C:
+			if err := r.Flush(ctx); err != nil {
+				logger.Warnw("could not report delivery status", zap.Error(err))
+			}
+		}
+	}
+}
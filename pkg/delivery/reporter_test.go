@@ -0,0 +1,236 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package delivery
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+var testResource = schema.GroupVersionResource{
+	Group:    "sources.tanzu.vmware.com",
+	Version:  "v1alpha1",
+	Resource: "horizonsources",
+}
+
+func newTestClient(t *testing.T, status map[string]interface{}) *dynamicfake.FakeDynamicClient {
+	t.Helper()
+
+	src := &unstructured.Unstructured{}
+	src.SetAPIVersion("sources.tanzu.vmware.com/v1alpha1")
+	src.SetKind("HorizonSource")
+	src.SetNamespace("ns")
+	src.SetName("source")
+	if status != nil {
+		if err := unstructured.SetNestedMap(src.Object, status, "status"); err != nil {
+			t.Fatalf("set status: %v", err)
+		}
+	}
+
+	return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{testResource: "HorizonSourceList"}, src)
+}
+
+func getStatus(t *testing.T, dc *dynamicfake.FakeDynamicClient) map[string]interface{} {
+	t.Helper()
+
+	src, err := dc.Resource(testResource).Namespace("ns").Get(context.TODO(), "source", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("get source: %v", err)
+	}
+	status, _, err := unstructured.NestedMap(src.Object, "status")
+	if err != nil {
+		t.Fatalf("get status: %v", err)
+	}
+	return status
+}
+
+func countPatches(dc *dynamicfake.FakeDynamicClient) int {
+	var n int
+	for _, a := range dc.Actions() {
+		if a.GetVerb() == "patch" {
+			n++
+		}
+	}
+	return n
+}
+
+func TestReporter(t *testing.T) {
+	ctx := context.TODO()
+	dc := newTestClient(t, map[string]interface{}{"eventsSentTotal": int64(10)})
+	r := NewReporter(dc.Resource(testResource).Namespace("ns"), "source", "lastEventID")
+
+	if err := r.Init(ctx); err != nil {
+		t.Fatalf("Init() = %v", err)
+	}
+
+	// nothing recorded yet
+	if err := r.Flush(ctx); err != nil {
+		t.Fatalf("Flush() = %v", err)
+	}
+	if got := countPatches(dc); got != 0 {
+		t.Errorf("patches after empty Flush() = %d, want 0", got)
+	}
+
+	created := time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC)
+	r.Failed(errors.New("sink unavailable"))
+	if err := r.Flush(ctx); err != nil {
+		t.Fatalf("Flush() = %v", err)
+	}
+	status := getStatus(t, dc)
+	if got := status["lastError"]; got != "sink unavailable" {
+		t.Errorf("lastError = %v, want %q", got, "sink unavailable")
+	}
+
+	r.Sent(3, "42", created)
+	if err := r.Flush(ctx); err != nil {
+		t.Fatalf("Flush() = %v", err)
+	}
+	status = getStatus(t, dc)
+	if got := status["eventsSentTotal"]; got != int64(13) {
+		t.Errorf("eventsSentTotal = %v, want 13", got)
+	}
+	if got := status["lastEventID"]; got != "42" {
+		t.Errorf("lastEventID = %v, want 42", got)
+	}
+	if got := status["lastEventTime"]; got != "2022-01-01T12:00:00Z" {
+		t.Errorf("lastEventTime = %v, want 2022-01-01T12:00:00Z", got)
+	}
+	if _, ok := status["lastSentTime"]; !ok {
+		t.Error("lastSentTime not set")
+	}
+	if _, ok := status["lastError"]; ok {
+		t.Errorf("lastError = %v, want unset", status["lastError"])
+	}
+
+	// unchanged status is not patched again
+	if err := r.Flush(ctx); err != nil {
+		t.Fatalf("Flush() = %v", err)
+	}
+	if got := countPatches(dc); got != 2 {
+		t.Errorf("patches = %d, want 2", got)
+	}
+}
+
+func TestReporterPolled(t *testing.T) {
+	ctx := context.TODO()
+	dc := newTestClient(t, nil)
+	r := NewReporter(dc.Resource(testResource).Namespace("ns"), "source", "lastEventID")
+
+	r.Polled()
+	if err := r.Flush(ctx); err != nil {
+		t.Fatalf("Flush() = %v", err)
+	}
+	if _, ok := getStatus(t, dc)["lastPollTime"]; !ok {
+		t.Error("lastPollTime not set")
+	}
+
+	// polls are reported at most every HeartbeatPeriod
+	r.Polled()
+	if err := r.Flush(ctx); err != nil {
+		t.Fatalf("Flush() = %v", err)
+	}
+	if got := countPatches(dc); got != 1 {
+		t.Errorf("patches = %d, want 1", got)
+	}
+}
+
+func TestReporterReplayed(t *testing.T) {
+	ctx := context.TODO()
+	dc := newTestClient(t, nil)
+	r := NewReporter(dc.Resource(testResource).Namespace("ns"), "source", "lastEventID")
+
+	// replayed events do not report their lag
+	r.Replayed(2, "2", time.Now().Add(-time.Hour))
+	if err := r.Flush(ctx); err != nil {
+		t.Fatalf("Flush() = %v", err)
+	}
+	status := getStatus(t, dc)
+	if got := status["eventsSentTotal"]; got != int64(2) {
+		t.Errorf("eventsSentTotal = %v, want 2", got)
+	}
+	if _, ok := status["eventLag"]; ok {
+		t.Errorf("eventLag = %v, want unset", status["eventLag"])
+	}
+
+	r.Sent(1, "3", time.Now().Add(-time.Minute))
+	if err := r.Flush(ctx); err != nil {
+		t.Fatalf("Flush() = %v", err)
+	}
+	lag, err := time.ParseDuration(getStatus(t, dc)["eventLag"].(string))
+	if err != nil || lag < time.Minute || lag > 2*time.Minute {
+		t.Errorf("eventLag = %v, %v, want about 1m", lag, err)
+	}
+}
+
+func TestReporterPosition(t *testing.T) {
+	ctx := context.TODO()
+	dc := newTestClient(t, nil)
+	r := NewReporter(dc.Resource(testResource).Namespace("ns"), "source", "lastEventID")
+
+	type position struct {
+		Time int64   `json:"time"`
+		IDs  []int64 `json:"ids"`
+	}
+
+	var got position
+	if found, err := r.LastPosition(ctx, "position", &got); err != nil || found {
+		t.Errorf("LastPosition() without status = %v, %v, want false, nil", found, err)
+	}
+
+	want := position{Time: 1627368903620, IDs: []int64{98558, 98559}}
+	r.Advance("position", want)
+	if err := r.Flush(ctx); err != nil {
+		t.Fatalf("Flush() = %v", err)
+	}
+
+	found, err := r.LastPosition(ctx, "position", &got)
+	if err != nil || !found {
+		t.Fatalf("LastPosition() = %v, %v, want true, nil", found, err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("LastPosition() (-want, +got) = %s", diff)
+	}
+}
+
+func TestReporterFlushRetries(t *testing.T) {
+	ctx := context.TODO()
+	dc := newTestClient(t, nil)
+	dc.PrependReactor("patch", "horizonsources", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("conflict")
+	})
+	r := NewReporter(dc.Resource(testResource).Namespace("ns"), "source", "lastEventID")
+
+	r.Sent(1, "1", time.Now())
+	if err := r.Flush(ctx); err == nil {
+		t.Fatal("Flush() succeeded, want error")
+	}
+	if err := r.Flush(ctx); err == nil {
+		t.Fatal("Flush() succeeded, want error")
+	}
+	if got := countPatches(dc); got != 2 {
+		t.Errorf("patches = %d, want 2", got)
+	}
+}
+
+func TestNilReporter(t *testing.T) {
+	var r *Reporter
+	r.Sent(1, "1", time.Now())
+	r.Failed(errors.New("failed"))
+	if err := r.Flush(context.TODO()); err != nil {
+		t.Errorf("Flush() = %v", err)
+	}
+}
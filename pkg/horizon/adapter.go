@@ -8,27 +8,59 @@ package horizon
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
-	"strings"
+	"sync"
 	"time"
 
 	"github.com/benbjohnson/clock"
 	cloudevents "github.com/cloudevents/sdk-go/v2"
+	cecontext "github.com/cloudevents/sdk-go/v2/context"
 	"github.com/jpillora/backoff"
 	"github.com/kelseyhightower/envconfig"
 	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"knative.dev/eventing/pkg/adapter/v2"
+	"knative.dev/pkg/injection/clients/dynamicclient"
 	"knative.dev/pkg/logging"
+
+	"github.com/vmware-tanzu/sources-for-knative/pkg/cemapping"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/delivery"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/sinkauth"
 )
 
 const (
-	defaultPollInterval = time.Second
-	eventTypeFormat     = "com.vmware.horizon.%s.v0"
-
-	retryBackoff  = time.Second
-	retryMaxTries = 5
+	// DefaultPollInterval is the default interval between polls
+	DefaultPollInterval = time.Second
+	// DefaultMinBackoff is the default initial delay between polls when no
+	// new events are received, also used as the initial delay between send
+	// retries
+	DefaultMinBackoff = time.Second
+	// DefaultMaxBackoff is the default maximum delay between polls when no
+	// new events are received
+	DefaultMaxBackoff = 5 * time.Second
+	// DefaultSendRetries is the default number of retries of a failed send
+	// within a poll
+	DefaultSendRetries = 5
+
+	// interval to report the delivery status in the HorizonSource status
+	statusReportPeriod = 10 * time.Second
+
+	// DefaultDeliveryRetries is the number of polls an event which could not be
+	// delivered is retried in before it is dead-lettered
+	DefaultDeliveryRetries = 3
+
+	// extension set on dead-lettered events with the sink they could not be
+	// delivered to
+	ceErrorDestExtension = "knativeerrordest"
 )
 
+var horizonSourcesResource = schema.GroupVersionResource{
+	Group:    "sources.tanzu.vmware.com",
+	Version:  "v1alpha1",
+	Resource: "horizonsources",
+}
+
 type envConfig struct {
 	// Include the standard adapter.EnvConfig used by all adapters.
 	adapter.EnvConfig
@@ -38,6 +70,33 @@ type envConfig struct {
 	Insecure bool   `envconfig:"HORIZON_INSECURE" default:"false"`
 	// overwrite useful for local development
 	SecretPath string `envconfig:"HORIZON_SECRET_PATH" default:""`
+	// JSON-encoded EventFilter selecting the events to retrieve
+	EventFilter string `envconfig:"HORIZON_EVENT_FILTER" default:""`
+
+	// DeadLetterSink receives events which could not be delivered, if empty
+	// such events are discarded
+	DeadLetterSink string `envconfig:"HORIZON_DEAD_LETTER_SINK" default:""`
+	// DeadLetterSinkAudience is the OIDC audience of the dead letter sink, if
+	// it requires tokens
+	DeadLetterSinkAudience string `envconfig:"HORIZON_DEAD_LETTER_SINK_AUDIENCE" default:""`
+	// DeliveryRetries is the number of polls an event is retried in,
+	// DefaultDeliveryRetries if not positive
+	DeliveryRetries int `envconfig:"HORIZON_DELIVERY_RETRIES"`
+
+	// PollInterval is the interval between polls
+	PollInterval time.Duration `envconfig:"HORIZON_POLL_INTERVAL" default:"1s"`
+	// MinBackoff and MaxBackoff bound the delay between polls when no new
+	// events are received
+	MinBackoff time.Duration `envconfig:"HORIZON_POLL_MIN_BACKOFF" default:"1s"`
+	MaxBackoff time.Duration `envconfig:"HORIZON_POLL_MAX_BACKOFF" default:"5s"`
+	// SendRetries is the number of retries of a failed send within a poll
+	SendRetries int `envconfig:"HORIZON_SEND_RETRIES" default:"5"`
+	// JSON-encoded cemapping.Config of the CloudEvent type, source and
+	// subject
+	CloudEventMapping string `envconfig:"HORIZON_CE_MAPPING" default:""`
+
+	// Audience is the OIDC audience of the sink, if it requires tokens
+	Audience string `envconfig:"K_AUDIENCE" default:""`
 }
 
 func NewEnv() adapter.EnvConfigAccessor { return &envConfig{} }
@@ -47,10 +106,25 @@ type Adapter struct {
 	client cloudevents.Client
 
 	source       string
+	mapper       *cemapping.Mapper
 	sink         string
 	hclient      Client
 	clock        clock.Clock
 	pollInterval time.Duration
+	minBackoff   time.Duration
+	maxBackoff   time.Duration
+	sendRetries  int
+	reporter     *delivery.Reporter
+
+	deadLetterSink string
+	// deadLetterClient sends to the dead letter sink, client if nil
+	deadLetterClient cloudevents.Client
+	retries          int
+	// failed delivery attempts by event ID
+	attempts map[int64]int
+	// events created before are replayed, e.g. after a resume, and do not
+	// count towards the event lag
+	started time.Time
 }
 
 func NewAdapter(ctx context.Context, _ adapter.EnvConfigAccessor, ceClient cloudevents.Client) adapter.Adapter {
@@ -66,37 +140,70 @@ func NewAdapter(ctx context.Context, _ adapter.EnvConfigAccessor, ceClient cloud
 		logger.Fatalw("create horizon client", zap.Error(err))
 	}
 
+	mapper, source, err := newMapper(env.CloudEventMapping, env.Address)
+	if err != nil {
+		logger.Fatalw("configure CloudEvent mapping", zap.Error(err))
+	}
+
+	client := dynamicclient.Get(ctx).Resource(horizonSourcesResource).Namespace(env.Namespace)
+
 	return &Adapter{
-		client:       ceClient,
-		source:       env.Address,
+		client:       sinkauth.NewClient(ceClient, sinkauth.ForAudience(env.Audience, sinkauth.SinkTokenName)),
+		source:       source,
+		mapper:       mapper,
 		sink:         env.GetSink(),
 		hclient:      hc,
 		clock:        clock.New(),
-		pollInterval: defaultPollInterval,
+		pollInterval: env.PollInterval,
+		minBackoff:   env.MinBackoff,
+		maxBackoff:   env.MaxBackoff,
+		sendRetries:  env.SendRetries,
+		reporter:     delivery.NewReporter(client, env.Name, "lastEventID"),
+
+		deadLetterSink:   env.DeadLetterSink,
+		deadLetterClient: sinkauth.NewClient(ceClient, sinkauth.ForAudience(env.DeadLetterSinkAudience, DeadLetterSinkTokenName)),
+		retries:          env.DeliveryRetries,
+		attempts:         make(map[int64]int),
 	}
 }
 
 // Start runs the adapter. Returns if ctx is cancelled or on unrecoverable
 // error, e.g. reading or sending events.
 func (a *Adapter) Start(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		a.reporter.Run(ctx, statusReportPeriod)
+	}()
+	// stop the reporter and wait for its final status update
+	defer wg.Wait()
+	defer cancel()
+
 	return a.run(ctx)
 }
 
 // run starts polling the Horizon event API until the specified context is
 // cancelled or when an error is returned while retrieving Horizon events
 func (a *Adapter) run(ctx context.Context) error {
-	var (
-		lastEvent *AuditEventSummary
-		since     Timestamp
-	)
+	// position in the event stream, i.e. the time and IDs of the last
+	// processed events
+	cur := a.lastCursor(ctx)
+	a.started = time.Now()
+
+	pollInterval := a.pollInterval
+	if pollInterval <= 0 {
+		pollInterval = DefaultPollInterval
+	}
 
 	logger := logging.FromContext(ctx).With(
 		zap.String("source", a.source),
-		zap.Duration("pollIntervalSeconds", a.pollInterval),
+		zap.Duration("pollIntervalSeconds", pollInterval),
 	)
 	logger.Infow("starting horizon source adapter")
 
-	ticker := a.clock.Ticker(a.pollInterval)
+	ticker := a.clock.Ticker(pollInterval)
 	defer func() {
 		ticker.Stop()
 
@@ -108,8 +215,11 @@ func (a *Adapter) run(ctx context.Context) error {
 	backoffCfg := backoff.Backoff{
 		Factor: 2,
 		Jitter: false,
-		Min:    retryBackoff,
-		Max:    retryMaxTries * time.Second,
+		Min:    a.backoffMin(),
+		Max:    a.maxBackoff,
+	}
+	if backoffCfg.Max <= 0 {
+		backoffCfg.Max = DefaultMaxBackoff
 	}
 
 	for {
@@ -119,16 +229,13 @@ func (a *Adapter) run(ctx context.Context) error {
 			return ctx.Err()
 
 		case <-ticker.C:
-			if lastEvent != nil {
-				since = Timestamp(lastEvent.Time)
-			}
-
+			since := cur.time
 			if since == 0 {
 				logger.Debug("retrieving initial set of events")
 			} else {
 				logger.Debugw("retrieving events with time range filter",
 					zap.Any("sinceUnixMilli", since),
-					zap.String("sinceConverted", time.Unix(int64(since/1000), 0).String()),
+					zap.String("sinceConverted", time.UnixMilli(int64(since)).String()),
 				)
 			}
 
@@ -136,78 +243,163 @@ func (a *Adapter) run(ctx context.Context) error {
 			if err != nil {
 				return fmt.Errorf("get events: %w", err)
 			}
+			a.reporter.Polled()
 
-			skip := false
-			switch len(events) {
-			case 0:
-				skip = true
-			case 1: // check if this is lastEvent we have already seen
-				if lastEvent != nil && events[0].ID == lastEvent.ID {
-					skip = true
-				}
-			}
-
-			if skip {
+			logger.Debugw("retrieved events", zap.Int("count", len(events)))
+			// the time range filter includes the events at the cursor time
+			// which have already been processed
+			events = cur.unseen(events)
+			if len(events) == 0 {
 				sleep := backoffCfg.Duration()
 				logger.Debugw("backing off retrieving events: no new events received", zap.Duration("backoffSeconds", sleep))
-				time.Sleep(sleep)
+				select {
+				case <-ctx.Done():
+				case <-a.clock.After(sleep):
+				}
 				continue
 			}
 
-			logger.Debugw("retrieved new events", zap.Int("count", len(events)))
-			events = removeEvent(events, lastEvent)
 			logger.Debugw("remaining new events after filtering out duplicate events", zap.Int("count", len(events)))
-			lastEvent = a.sendEvents(ctx, events)
+			a.sendEvents(ctx, events, &cur)
 			backoffCfg.Reset()
 		}
 	}
 }
 
-// sendEvents sends the given events to the configured SINK returning the last
-// successfully sent event
-//
-// TODO (@mgasch): There is a risk of poison pill issue here, leading to a constant loop
-// in the invoking function.
-func (a *Adapter) sendEvents(ctx context.Context, events []AuditEventSummary) *AuditEventSummary {
+// sendEvents sends the given events to the configured SINK in ascending time
+// order and advances cur past every processed event, i.e. delivered or
+// dead-lettered. An event which could not be delivered is retried in subsequent polls and
+// dead-lettered once its retries are exhausted. Sending stops at the first
+// event which is neither delivered nor dead-lettered so the time offset in the
+// stream does not advance past it.
+func (a *Adapter) sendEvents(ctx context.Context, events []AuditEventSummary, cur *cursor) {
 	logger := logging.FromContext(ctx).With(
 		zap.String("source", a.source),
 		zap.String("sink", a.sink),
 	)
 
+	if a.attempts == nil {
+		a.attempts = make(map[int64]int)
+	}
+
 	// Horizon events are returned in descending time order thus the "id" in a
 	// Horizon event can not be used for ordering (see testdata for example with
 	// concurrent timestamps)
 	reverse(events)
 
-	// last successful processed event to track time offset in stream
-	var lastEvent *AuditEventSummary
-
-	ctx = cloudevents.ContextWithRetriesExponentialBackoff(ctx, retryBackoff, retryMaxTries)
+	ctx = cloudevents.ContextWithRetriesExponentialBackoff(ctx, a.backoffMin(), a.sendRetries)
 	for i := range events {
 		event := events[i]
 		// don't waste cycles when ctx canceled
 		if ctx.Err() != nil {
-			return lastEvent
+			return
 		}
 
 		log := logger.With(zap.Any("event", event))
-		ce, err := toCloudEvent(event, a.source)
+		ce, err := toCloudEvent(event, a.source, a.ceMapper())
 		if err != nil {
+			// retrying would not fix the conversion
 			log.Errorw("skipping event because it could not be converted to cloudevent", zap.Error(err))
+			a.advance(cur, event)
 			continue
 		}
 
-		// TODO: better partial batch failure handling here?
 		result := a.client.Send(ctx, ce)
-		if !cloudevents.IsACK(result) {
-			log.Errorw("could not send cloudevent", zap.Error(result))
+		if cloudevents.IsACK(result) {
+			log.Debugw("successfully sent event")
+			if ce.Time().Before(a.started) {
+				a.reporter.Replayed(1, ce.ID(), ce.Time())
+			} else {
+				a.reporter.Sent(1, ce.ID(), ce.Time())
+			}
+			delete(a.attempts, event.ID)
+			a.advance(cur, event)
 			continue
 		}
-		log.Debugw("successfully sent event")
-		lastEvent = &event
+
+		a.reporter.Failed(result)
+		a.attempts[event.ID]++
+		if attempts := a.attempts[event.ID]; attempts <= a.deliveryRetries() {
+			log.Errorw("could not send cloudevent, retrying in next poll", zap.Error(result),
+				zap.Int("attempts", attempts))
+			return
+		}
+
+		if err = a.deadLetter(ctx, ce); err != nil {
+			log.Errorw("could not send cloudevent to dead letter sink, retrying in next poll", zap.Error(err))
+			return
+		}
+		log.Warnw("dead-lettered cloudevent after exhausting retries", zap.Error(result))
+		delete(a.attempts, event.ID)
+		a.advance(cur, event)
 	}
+}
 
-	return lastEvent
+// lastCursor returns the position in the event stream after the events
+// processed before the adapter started, e.g. before the source was suspended,
+// or the start of the stream if no position was reported
+func (a *Adapter) lastCursor(ctx context.Context) cursor {
+	logger := logging.FromContext(ctx)
+
+	var pos Position
+	found, err := a.reporter.LastPosition(ctx, PositionField, &pos)
+	if err != nil {
+		logger.Warnw("could not read last position, retrieving initial set of events", zap.Error(err))
+		return cursor{}
+	}
+	if !found || pos.Time == 0 {
+		return cursor{}
+	}
+
+	logger.Infow("resuming after last processed events", zap.Any("position", pos))
+	return resumeCursor(pos)
+}
+
+// advance moves cur to the given processed event and records the new position
+// in the status of the source
+func (a *Adapter) advance(cur *cursor, event AuditEventSummary) {
+	cur.advance(event)
+	a.reporter.Advance(PositionField, cur.position())
+}
+
+// backoffMin returns the initial delay between polls when no new events are
+// received and between send retries
+func (a *Adapter) backoffMin() time.Duration {
+	if a.minBackoff <= 0 {
+		return DefaultMinBackoff
+	}
+	return a.minBackoff
+}
+
+// deliveryRetries returns the number of polls an event which could not be
+// delivered is retried in
+func (a *Adapter) deliveryRetries() int {
+	if a.retries <= 0 {
+		return DefaultDeliveryRetries
+	}
+	return a.retries
+}
+
+// deadLetter sends the given event to the dead letter sink. Without a dead
+// letter sink the event is discarded.
+func (a *Adapter) deadLetter(ctx context.Context, ce cloudevents.Event) error {
+	if a.deadLetterSink == "" {
+		logging.FromContext(ctx).Warnw("discarding cloudevent: no dead letter sink configured",
+			zap.String("id", ce.ID()))
+		return nil
+	}
+
+	client := a.deadLetterClient
+	if client == nil {
+		client = a.client
+	}
+
+	ce.SetExtension(ceErrorDestExtension, a.sink)
+	result := client.Send(cecontext.WithTarget(ctx, a.deadLetterSink), ce)
+	if !cloudevents.IsACK(result) {
+		return result
+	}
+	return nil
 }
 
 // reverse mutates the given slice and reverses its order
@@ -218,36 +410,87 @@ func reverse(ev []AuditEventSummary) {
 	}
 }
 
-// removeEvent returns a copy of list with the given event removed
-func removeEvent(list []AuditEventSummary, event *AuditEventSummary) []AuditEventSummary {
-	deduped := make([]AuditEventSummary, len(list))
-	copy(deduped, list)
+// cursor is the position in the Horizon event stream. Horizon timestamps have
+// millisecond precision and several events can share the same timestamp, thus
+// the IDs of all processed events at the cursor time are tracked.
+type cursor struct {
+	time Timestamp
+	seen map[int64]struct{}
+}
 
-	if event == nil {
-		return deduped
+// resumeCursor returns a cursor at the given position
+func resumeCursor(pos Position) cursor {
+	c := cursor{time: pos.Time, seen: make(map[int64]struct{}, len(pos.EventIDs))}
+	for _, id := range pos.EventIDs {
+		c.seen[id] = struct{}{}
 	}
+	return c
+}
 
-	for i := range list {
-		if list[i].ID == event.ID {
-			// Remove the element at index i from a.
-			copy(deduped[i:], deduped[i+1:])              // shift deduped[i+1:] left one index.
-			deduped[len(deduped)-1] = AuditEventSummary{} // erase last element (write zero value).
-			deduped = deduped[:len(deduped)-1]            // truncate slice.
+// position returns the position of the cursor
+func (c *cursor) position() Position {
+	pos := Position{Time: c.time, EventIDs: make([]int64, 0, len(c.seen))}
+	for id := range c.seen {
+		pos.EventIDs = append(pos.EventIDs, id)
+	}
+	sort.Slice(pos.EventIDs, func(i, j int) bool { return pos.EventIDs[i] < pos.EventIDs[j] })
+	return pos
+}
+
+// advance moves the cursor to the given processed event, which must not be
+// older than the cursor
+func (c *cursor) advance(event AuditEventSummary) {
+	if t := Timestamp(event.Time); t != c.time || c.seen == nil {
+		c.time = t
+		c.seen = make(map[int64]struct{})
+	}
+	c.seen[event.ID] = struct{}{}
+}
+
+// unseen returns a copy of list without the events older than the cursor or
+// already processed at the cursor time
+func (c *cursor) unseen(list []AuditEventSummary) []AuditEventSummary {
+	events := make([]AuditEventSummary, 0, len(list))
+	for _, e := range list {
+		if t := Timestamp(e.Time); t < c.time {
+			continue
+		} else if _, ok := c.seen[e.ID]; ok && t == c.time {
+			continue
 		}
+		events = append(events, e)
+	}
+	return events
+}
+
+// ceMapper returns the configured CloudEvent mapper or the default one
+func (a *Adapter) ceMapper() *cemapping.Mapper {
+	if a.mapper == nil {
+		return defaultMapper
 	}
-	return deduped
+	return a.mapper
 }
 
-func toCloudEvent(horizonEvent AuditEventSummary, source string) (cloudevents.Event, error) {
+func toCloudEvent(horizonEvent AuditEventSummary, source string, mapper *cemapping.Mapper) (cloudevents.Event, error) {
 	ce := cloudevents.NewEvent()
 
-	// TODO: revisit CE properties used here
+	data := eventData(horizonEvent)
+	ceType, err := mapper.Type(data)
+	if err != nil {
+		return cloudevents.Event{}, fmt.Errorf("map cloudevent type: %w", err)
+	}
+	subject, err := mapper.Subject(data)
+	if err != nil {
+		return cloudevents.Event{}, fmt.Errorf("map cloudevent subject: %w", err)
+	}
+
 	id := strconv.Itoa(int(horizonEvent.ID))
 	ce.SetID(id)
 	ce.SetSource(source)
-	ce.SetType(convertEventType(horizonEvent.Type))
-	t := time.Unix(horizonEvent.Time/1000, 0) // time is converted from ms
-	ce.SetTime(t)
+	ce.SetType(ceType)
+	if subject != "" {
+		ce.SetSubject(subject)
+	}
+	ce.SetTime(time.UnixMilli(horizonEvent.Time))
 
 	if err := ce.SetData(cloudevents.ApplicationJSON, horizonEvent); err != nil {
 		return cloudevents.Event{}, fmt.Errorf("set cloudevent data: %w", err)
@@ -259,11 +502,3 @@ func toCloudEvent(horizonEvent AuditEventSummary, source string) (cloudevents.Ev
 
 	return ce, nil
 }
-
-// convertEventType converts a Horizon event type to a normalized cloud event
-// type. For example, VLSI_USERLOGGEDIN is converted to
-// com.vmware.horizon.vlsi_userloggedin.v0
-func convertEventType(t string) string {
-	t = strings.ToLower(t)
-	return fmt.Sprintf(eventTypeFormat, t)
-}
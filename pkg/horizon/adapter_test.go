@@ -9,8 +9,11 @@ import (
 	"context"
 	"encoding/json"
 	"net"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
@@ -19,8 +22,15 @@ import (
 	ce "github.com/cloudevents/sdk-go/v2"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zaptest"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
 	"knative.dev/eventing/pkg/adapter/v2"
 	"knative.dev/pkg/logging"
+
+	"github.com/vmware-tanzu/sources-for-knative/pkg/delivery"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/sinkauth"
 )
 
 const (
@@ -40,13 +50,7 @@ func TestAdapter(t *testing.T) {
 	ceClient, err := ce.NewClient(tr)
 	require.NoError(t, err)
 
-	f, err := os.Open(testEvents)
-	require.NoErrorf(t, err, "open golden file: %s", testEvents)
-
-	var events []AuditEventSummary
-	dec := json.NewDecoder(f)
-	err = dec.Decode(&events)
-	require.NoError(t, err, "JSON decode test events")
+	events := readTestEvents(t)
 
 	a := &Adapter{
 		client:       ceClient,
@@ -127,33 +131,156 @@ func TestAdapterMain(t *testing.T) {
 	}()
 }
 
-func Test_removeItem(t *testing.T) {
-	t.Run("event is nil", func(t *testing.T) {
-		ev := createFakeEvents(10)
-		got := removeEvent(ev, nil)
-		require.Equal(t, ev, got)
+func Test_cursor(t *testing.T) {
+	events := readTestEvents(t)
+
+	t.Run("initial cursor", func(t *testing.T) {
+		var cur cursor
+		require.Equal(t, events, cur.unseen(events))
 	})
 
 	t.Run("empty events", func(t *testing.T) {
-		got := removeEvent([]AuditEventSummary{}, &AuditEventSummary{})
-		require.Equal(t, []AuditEventSummary{}, got)
+		var cur cursor
+		cur.advance(events[0])
+		require.Empty(t, cur.unseen([]AuditEventSummary{}))
+	})
+
+	t.Run("older events are removed", func(t *testing.T) {
+		var cur cursor
+		cur.advance(events[2])
+		require.Equal(t, events[:2], cur.unseen(events))
 	})
 
-	t.Run("one duplicate event", func(t *testing.T) {
-		ev := createFakeEvents(3)
-		got := removeEvent(ev, &AuditEventSummary{ID: 10})
-		require.Equal(t, ev[1:], got)
+	t.Run("concurrent events", func(t *testing.T) {
+		// events[3:6] share the same timestamp
+		var cur cursor
+		cur.advance(events[6])
+		cur.advance(events[5])
+		cur.advance(events[3])
+		require.Equal(t, Timestamp(events[4].Time), cur.time)
+		require.Equal(t, []AuditEventSummary{events[0], events[1], events[2], events[4]}, cur.unseen(events))
 	})
+
+	t.Run("resumed cursor", func(t *testing.T) {
+		// events[3:6] share the same timestamp
+		var cur cursor
+		cur.advance(events[6])
+		cur.advance(events[5])
+		cur.advance(events[3])
+
+		pos := cur.position()
+		require.Equal(t, Position{Time: Timestamp(events[3].Time), EventIDs: []int64{events[3].ID, events[5].ID}}, pos)
+
+		cur = resumeCursor(pos)
+		require.Equal(t, []AuditEventSummary{events[0], events[1], events[2], events[4]}, cur.unseen(events))
+	})
+}
+
+func TestAdapter_lastCursor(t *testing.T) {
+	ctx := logging.WithLogger(context.Background(), zaptest.NewLogger(t).Sugar())
+
+	src := &unstructured.Unstructured{}
+	src.SetAPIVersion("sources.tanzu.vmware.com/v1alpha1")
+	src.SetKind("HorizonSource")
+	src.SetNamespace("ns")
+	src.SetName("source")
+	dc := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{horizonSourcesResource: "HorizonSourceList"}, src)
+	reporter := delivery.NewReporter(dc.Resource(horizonSourcesResource).Namespace("ns"), "source", "lastEventID")
+
+	// without a reported position the initial set of events is retrieved
+	a := &Adapter{reporter: reporter}
+	require.Equal(t, cursor{}, a.lastCursor(ctx))
+
+	// a restarted adapter resumes at the reported position
+	var cur cursor
+	a.advance(&cur, AuditEventSummary{ID: 98558, Time: 1627368903620})
+	a.advance(&cur, AuditEventSummary{ID: 98559, Time: 1627368903620})
+	require.NoError(t, reporter.Flush(ctx))
+
+	a = &Adapter{reporter: delivery.NewReporter(dc.Resource(horizonSourcesResource).Namespace("ns"), "source", "lastEventID")}
+	require.Equal(t, cur, a.lastCursor(ctx))
 }
 
-// createFakeEvents creates returns a []AuditEventSummary where the ID of each
-// element is set to 10 + current counter
-func createFakeEvents(count int) []AuditEventSummary {
-	events := make([]AuditEventSummary, count)
-	for i := 0; i < count; i++ {
-		events[i] = AuditEventSummary{ID: int64(10 + i)}
+func TestAdapter_concurrentTimestamps(t *testing.T) {
+	ctx := logging.WithLogger(context.Background(), zaptest.NewLogger(t).Sugar())
+
+	sink := newRecordingSink(t)
+	tr, err := ce.NewHTTP(ce.WithTarget(sink.URL))
+	require.NoError(t, err)
+	ceClient, err := ce.NewClient(tr)
+	require.NoError(t, err)
+
+	a := &Adapter{
+		client: ceClient,
+		source: "http://api.horizon.corp.local",
+		sink:   sink.URL,
+	}
+
+	events := readTestEvents(t)
+	var cur cursor
+
+	// poll returns the newest to oldest events of the stream, starting at
+	// offset, with a timestamp not older than the cursor
+	poll := func(offset int) {
+		var res []AuditEventSummary
+		for _, e := range events[offset:] {
+			if Timestamp(e.Time) >= cur.time {
+				res = append(res, e)
+			}
+		}
+		a.sendEvents(ctx, cur.unseen(res), &cur)
 	}
 
+	// only 98560 of the three events at 1627368903620 has been created yet
+	poll(5)
+	require.Equal(t, []string{"98554", "98555", "98556", "98557", "98560"}, sink.received)
+
+	// 98558 and 98559 were created at the same millisecond
+	poll(2)
+	poll(2)
+	poll(0)
+	require.Equal(t, []string{
+		"98554", "98555", "98556", "98557", "98560",
+		"98559", "98558", "98561",
+		"98562", "98563",
+	}, sink.received)
+}
+
+func Test_toCloudEvent(t *testing.T) {
+	events := readTestEvents(t)
+
+	got, err := toCloudEvent(events[0], "http://api.horizon.corp.local", defaultMapper)
+	require.NoError(t, err)
+	require.Equal(t, "98563", got.ID())
+	require.Equal(t, "com.vmware.horizon.rest_auth_login_success.v0", got.Type())
+	require.Equal(t, int64(1627369939733), got.Time().UnixMilli())
+	require.Empty(t, got.Subject())
+
+	mapper, source, err := newMapper(`{"type": "com.example.horizon.{{ .Type }}", "source": "urn:horizon:{{ .Address }}"}`,
+		"api.horizon.corp.local")
+	require.NoError(t, err)
+
+	event := events[0]
+	event.MachineID = "machine-1"
+	got, err = toCloudEvent(event, source, mapper)
+	require.NoError(t, err)
+	require.Equal(t, "com.example.horizon.REST_AUTH_LOGIN_SUCCESS", got.Type())
+	require.Equal(t, "urn:horizon:api.horizon.corp.local", got.Source())
+	require.Equal(t, "machine-1", got.Subject())
+}
+
+func readTestEvents(t *testing.T) []AuditEventSummary {
+	t.Helper()
+
+	f, err := os.Open(testEvents)
+	require.NoErrorf(t, err, "open golden file: %s", testEvents)
+	defer f.Close()
+
+	var events []AuditEventSummary
+	err = json.NewDecoder(f).Decode(&events)
+	require.NoError(t, err, "JSON decode test events")
+
 	return events
 }
 
@@ -223,3 +350,144 @@ func (h *horizonMockClient) GetEvents(ctx context.Context, since Timestamp) ([]A
 func (h *horizonMockClient) Logout(ctx context.Context) error {
 	return nil
 }
+
+// recordingSink records the IDs of received events and rejects the events
+// with the IDs in reject
+type recordingSink struct {
+	sync.Mutex
+	*httptest.Server
+	reject     map[string]bool
+	received   []string
+	errorDests []string
+}
+
+func newRecordingSink(t *testing.T, reject ...string) *recordingSink {
+	s := &recordingSink{reject: map[string]bool{}}
+	for _, id := range reject {
+		s.reject[id] = true
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Lock()
+		defer s.Unlock()
+
+		id := r.Header.Get("ce-id")
+		if s.reject[id] {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		s.received = append(s.received, id)
+		s.errorDests = append(s.errorDests, r.Header.Get("ce-"+ceErrorDestExtension))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func TestAdapter_sendEventsDeadLetter(t *testing.T) {
+	ctx := logging.WithLogger(context.Background(), zaptest.NewLogger(t).Sugar())
+
+	sink := newRecordingSink(t, "11")
+	dls := newRecordingSink(t)
+
+	tr, err := ce.NewHTTP(ce.WithTarget(sink.URL))
+	require.NoError(t, err)
+	ceClient, err := ce.NewClient(tr)
+	require.NoError(t, err)
+
+	a := &Adapter{
+		client:         ceClient,
+		source:         "http://api.horizon.corp.local",
+		sink:           sink.URL,
+		deadLetterSink: dls.URL,
+		retries:        1,
+	}
+
+	// Horizon API returns events ordered from newest to oldest
+	newEvents := func(ids ...int64) []AuditEventSummary {
+		var events []AuditEventSummary
+		for _, id := range ids {
+			events = append(events, AuditEventSummary{
+				ID:   id,
+				Type: "VLSI_USERLOGGEDIN",
+				Time: 1000 * id,
+			})
+		}
+		return events
+	}
+
+	var cur cursor
+
+	// first attempt of 11 fails, time offset must not advance past 10
+	a.sendEvents(ctx, newEvents(12, 11, 10), &cur)
+	require.Equal(t, Timestamp(10000), cur.time)
+	require.Equal(t, []string{"10"}, sink.received)
+	require.Empty(t, dls.received)
+
+	// retry of 11 fails, 11 is dead-lettered and 12 delivered
+	a.sendEvents(ctx, newEvents(12, 11), &cur)
+	require.Equal(t, Timestamp(12000), cur.time)
+	require.Equal(t, []string{"10", "12"}, sink.received)
+	require.Equal(t, []string{"11"}, dls.received)
+	require.Equal(t, []string{sink.URL}, dls.errorDests)
+	require.Empty(t, a.attempts)
+}
+
+func TestAdapter_sendEventsDeadLetterFails(t *testing.T) {
+	ctx := logging.WithLogger(context.Background(), zaptest.NewLogger(t).Sugar())
+
+	sink := newRecordingSink(t, "10")
+	dls := newRecordingSink(t, "10")
+
+	tr, err := ce.NewHTTP(ce.WithTarget(sink.URL))
+	require.NoError(t, err)
+	ceClient, err := ce.NewClient(tr)
+	require.NoError(t, err)
+
+	a := &Adapter{
+		client:         ceClient,
+		source:         "http://api.horizon.corp.local",
+		sink:           sink.URL,
+		deadLetterSink: dls.URL,
+	}
+
+	// neither delivered nor dead-lettered, time offset must not advance
+	var cur cursor
+	a.sendEvents(ctx, []AuditEventSummary{{ID: 10, Type: "VLSI_USERLOGGEDIN", Time: 10000}}, &cur)
+	require.Zero(t, cur.time)
+	require.Equal(t, 1, a.attempts[10])
+}
+
+func TestAdapter_deadLetterToken(t *testing.T) {
+	ctx := logging.WithLogger(context.Background(), zaptest.NewLogger(t).Sugar())
+
+	var auth string
+	dls := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	t.Cleanup(dls.Close)
+
+	tr, err := ce.NewHTTP()
+	require.NoError(t, err)
+	ceClient, err := ce.NewClient(tr)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(path, []byte("dls-token"), 0o600))
+
+	a := &Adapter{
+		client:           ceClient,
+		sink:             "https://broker.example.com",
+		deadLetterSink:   dls.URL,
+		deadLetterClient: sinkauth.NewClient(ceClient, sinkauth.NewTokenSource(path)),
+	}
+
+	event := ce.NewEvent()
+	event.SetID("10")
+	event.SetType("com.vmware.horizon.vlsi_userloggedin.v0")
+	event.SetSource("http://api.horizon.corp.local")
+	require.NoError(t, a.deadLetter(ctx, event))
+	require.Equal(t, "Bearer dls-token", auth)
+}
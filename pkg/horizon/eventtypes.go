@@ -0,0 +1,58 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package horizon
+
+import (
+	_ "embed" // embed the event schema
+
+	"github.com/vmware-tanzu/sources-for-knative/pkg/cemapping"
+)
+
+// EventSchemaURL is the URL of the JSON schema of the data of the CloudEvents
+// produced by a HorizonSource
+const EventSchemaURL = "https://raw.githubusercontent.com/vmware-tanzu/sources-for-knative/main/pkg/horizon/schema/audit-event.json"
+
+// EventSchema is the JSON schema published at EventSchemaURL
+//
+//go:embed schema/audit-event.json
+var EventSchema []byte
+
+// EventTypeInfo is an audit event type of the Horizon event catalog
+type EventTypeInfo struct {
+	// Type is the Horizon event type, e.g. VLSI_USERLOGGEDIN
+	Type string
+	// Module is the Horizon component that logs the event
+	Module string
+	// Description is a short description of the event type
+	Description string
+}
+
+// EventData returns the data of the type and subject templates of a
+// cemapping.Mapper for the event type. The machine of the event is unknown.
+func (i EventTypeInfo) EventData() cemapping.EventData {
+	return cemapping.EventData{Type: i.Type}
+}
+
+// EventCatalog are the well-known Horizon audit event types. Horizon does not
+// publish its message catalog through the API, so events of other types are
+// delivered but not part of the catalog.
+var EventCatalog = []EventTypeInfo{
+	{Type: "ADMIN_USERLOGGEDOUT", Module: "Vlsi", Description: "User logged out from Horizon Console"},
+	{Type: "AGENT_CONNECTED", Module: "Agent", Description: "User connected to a machine"},
+	{Type: "AGENT_DISCONNECTED", Module: "Agent", Description: "User disconnected from a machine"},
+	{Type: "AGENT_ENDED", Module: "Agent", Description: "User logged off from a machine"},
+	{Type: "AGENT_RECONNECTED", Module: "Agent", Description: "User reconnected to a machine"},
+	{Type: "BROKER_DAILY_MAX_APP_USERS", Module: "Broker", Description: "Daily maximum of users with concurrent application sessions"},
+	{Type: "BROKER_DAILY_MAX_CCU_USERS", Module: "Broker", Description: "Daily maximum of concurrent connection sessions"},
+	{Type: "BROKER_DAILY_MAX_DESKTOP_SESSIONS", Module: "Broker", Description: "Daily maximum of concurrent desktop sessions"},
+	{Type: "BROKER_DAILY_MAX_NU_USERS", Module: "Broker", Description: "Daily maximum of named user sessions"},
+	{Type: "BROKER_SMALL_MEMORY", Module: "Broker", Description: "Connection server is configured with a small amount of physical memory"},
+	{Type: "BROKER_USERLOGGEDIN", Module: "Broker", Description: "User logged in to a connection server"},
+	{Type: "BROKER_USERLOGGEDOUT", Module: "Broker", Description: "User logged out from a connection server"},
+	{Type: "REST_AUTH_LOGIN_SUCCESS", Module: "Rest", Description: "User logged in to the Horizon Server REST API"},
+	{Type: "REST_AUTH_LOGOUT_SUCCESS", Module: "Rest", Description: "User logged out from the Horizon Server REST API"},
+	{Type: "VLSI_USERLOGGEDIN", Module: "Vlsi", Description: "User logged in to Horizon Console"},
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package horizon
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestEventSchema(t *testing.T) {
+	var schema struct {
+		ID         string                     `json:"$id"`
+		Properties map[string]json.RawMessage `json:"properties"`
+	}
+	if err := json.Unmarshal(EventSchema, &schema); err != nil {
+		t.Fatalf("unmarshal event schema: %v", err)
+	}
+
+	if schema.ID != EventSchemaURL {
+		t.Errorf("event schema $id = %q, want %q", schema.ID, EventSchemaURL)
+	}
+
+	var got []string
+	for name := range schema.Properties {
+		got = append(got, name)
+	}
+	sort.Strings(got)
+
+	var want []string
+	typ := reflect.TypeOf(AuditEventSummary{})
+	for i := 0; i < typ.NumField(); i++ {
+		name, _, _ := strings.Cut(typ.Field(i).Tag.Get("json"), ",")
+		want = append(want, name)
+	}
+	sort.Strings(want)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("event schema properties (-want, +got) = %s", diff)
+	}
+}
+
+func TestEventCatalog(t *testing.T) {
+	b, err := os.ReadFile("testdata/audit_events.golden")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var events []AuditEventSummary
+	if err := json.Unmarshal(b, &events); err != nil {
+		t.Fatal(err)
+	}
+
+	catalog := make(map[string]EventTypeInfo)
+	for _, info := range EventCatalog {
+		if _, ok := catalog[info.Type]; ok {
+			t.Errorf("event type %s is duplicated in the catalog", info.Type)
+		}
+		if info.Description == "" {
+			t.Errorf("event type %s has no description", info.Type)
+		}
+		catalog[info.Type] = info
+	}
+
+	for _, e := range events {
+		info, ok := catalog[e.Type]
+		if !ok {
+			t.Errorf("event type %s is not in the catalog", e.Type)
+		} else if info.Module != e.Module {
+			t.Errorf("event type %s module = %q, want %q", e.Type, info.Module, e.Module)
+		}
+	}
+}
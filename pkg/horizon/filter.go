@@ -0,0 +1,138 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package horizon
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Horizon audit event severities
+const (
+	SeverityInfo         = "INFO"
+	SeverityWarning      = "WARNING"
+	SeverityError        = "ERROR"
+	SeverityAuditSuccess = "AUDIT_SUCCESS"
+	SeverityAuditFail    = "AUDIT_FAIL"
+	SeverityUnknown      = "UNKNOWN"
+)
+
+// EventFilter selects the Horizon events retrieved by the adapter. An event
+// matches if, for every non-empty field, it equals one of the given values.
+type EventFilter struct {
+	Types                []string `json:"types,omitempty"`
+	Severities           []string `json:"severities,omitempty"`
+	Modules              []string `json:"modules,omitempty"`
+	DesktopPoolNames     []string `json:"desktopPoolNames,omitempty"`
+	ApplicationPoolNames []string `json:"applicationPoolNames,omitempty"`
+}
+
+// newEventFilter parses a JSON-encoded EventFilter. An empty string returns a
+// nil filter.
+func newEventFilter(s string) (*EventFilter, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	var f EventFilter
+	if err := json.Unmarshal([]byte(s), &f); err != nil {
+		return nil, fmt.Errorf("unmarshal event filter: %w", err)
+	}
+	return &f, nil
+}
+
+// filters returns the Horizon API filters for f, one per non-empty field.
+func (f *EventFilter) filters() []interface{} {
+	if f == nil {
+		return nil
+	}
+
+	var filters []interface{}
+	for _, field := range []struct {
+		name   string
+		values []string
+	}{
+		{name: "type", values: f.Types},
+		{name: "severity", values: f.Severities},
+		{name: "module", values: f.Modules},
+		{name: "desktop_pool_name", values: f.DesktopPoolNames},
+		{name: "application_pool_name", values: f.ApplicationPoolNames},
+	} {
+		if filter := anyOf(field.name, field.values); filter != nil {
+			filters = append(filters, filter)
+		}
+	}
+	return filters
+}
+
+// anyOf returns a filter matching any of the values for the named field or
+// nil if values is empty.
+func anyOf(name string, values []string) interface{} {
+	var equals []interface{}
+	for _, v := range values {
+		equals = append(equals, EqualsFilter{
+			Type:  "Equals",
+			Name:  name,
+			Value: v,
+		})
+	}
+
+	switch len(equals) {
+	case 0:
+		return nil
+	case 1:
+		return equals[0]
+	default:
+		return LogicalFilter{Type: "Or", Filters: equals}
+	}
+}
+
+// queryFilter returns the JSON-encoded query string combining the time range
+// starting at since, if not 0, with the event filter. An empty string is
+// returned if there is nothing to filter on.
+func queryFilter(since Timestamp, f *EventFilter) (string, error) {
+	filters := f.filters()
+	if since != 0 {
+		filters = append([]interface{}{betweenFilter(since, 0)}, filters...)
+	}
+
+	var filter interface{}
+	switch len(filters) {
+	case 0:
+		return "", nil
+	case 1:
+		filter = filters[0]
+	default:
+		filter = LogicalFilter{Type: "And", Filters: filters}
+	}
+
+	b, err := json.Marshal(filter)
+	if err != nil {
+		return "", fmt.Errorf("JSON marshal filter: %w", err)
+	}
+	return string(b), nil
+}
+
+// betweenFilter returns the time range filter for the given timestamp range.
+// Both values are interpreted as inclusive range values. If to is 0 an
+// arbitrary time (UTC) in the future is used as the upper range bound.
+func betweenFilter(from, to Timestamp) BetweenFilter {
+	// avoid small clock sync issues between client and server and use 1d as future
+	// timestamp buffer
+	timeBuffer := time.Hour * 24
+
+	if to == 0 {
+		to = Timestamp(time.Now().Add(timeBuffer).Unix() * 1000) // milliseconds
+	}
+
+	return BetweenFilter{
+		Type:      "Between",
+		Name:      "time",
+		FromValue: from,
+		ToValue:   to,
+	}
+}
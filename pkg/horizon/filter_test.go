@@ -0,0 +1,113 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package horizon
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_queryFilter(t *testing.T) {
+	tests := []struct {
+		name   string
+		since  Timestamp
+		filter *EventFilter
+		want   string
+	}{
+		{
+			name:   "no time range and no filter",
+			since:  0,
+			filter: nil,
+			want:   "",
+		},
+		{
+			name:   "empty filter",
+			since:  0,
+			filter: &EventFilter{},
+			want:   "",
+		},
+		{
+			name:   "single value",
+			since:  0,
+			filter: &EventFilter{Severities: []string{SeverityAuditFail}},
+			want:   `{"type":"Equals","name":"severity","value":"AUDIT_FAIL"}`,
+		},
+		{
+			name:  "multiple values and fields",
+			since: 0,
+			filter: &EventFilter{
+				Severities:       []string{SeverityAuditFail, SeverityError},
+				DesktopPoolNames: []string{"pool-01"},
+			},
+			want: `{"type":"And","filters":[` +
+				`{"type":"Or","filters":[{"type":"Equals","name":"severity","value":"AUDIT_FAIL"},{"type":"Equals","name":"severity","value":"ERROR"}]},` +
+				`{"type":"Equals","name":"desktop_pool_name","value":"pool-01"}]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := queryFilter(tt.since, tt.filter)
+			if err != nil {
+				t.Fatalf("queryFilter() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("queryFilter() (-want, +got) = %s", diff)
+			}
+		})
+	}
+}
+
+func Test_queryFilterWithTimeRange(t *testing.T) {
+	got, err := queryFilter(1000, &EventFilter{Types: []string{"VLSI_USERLOGGEDIN"}})
+	if err != nil {
+		t.Fatalf("queryFilter() error = %v", err)
+	}
+
+	var filter struct {
+		Type    string `json:"type"`
+		Filters []struct {
+			Type      string `json:"type"`
+			Name      string `json:"name"`
+			FromValue int64  `json:"fromValue"`
+			Value     string `json:"value"`
+		} `json:"filters"`
+	}
+	if err = json.Unmarshal([]byte(got), &filter); err != nil {
+		t.Fatalf("unmarshal filter %q: %v", got, err)
+	}
+
+	if filter.Type != "And" || len(filter.Filters) != 2 {
+		t.Fatalf("queryFilter() = %s, want And filter with time range and type", got)
+	}
+	if between := filter.Filters[0]; between.Type != "Between" || between.Name != "time" || between.FromValue != 1000 {
+		t.Errorf("queryFilter() time range = %+v, want Between time from 1000", between)
+	}
+	if equals := filter.Filters[1]; equals.Type != "Equals" || equals.Name != "type" || equals.Value != "VLSI_USERLOGGEDIN" {
+		t.Errorf("queryFilter() type filter = %+v, want Equals type VLSI_USERLOGGEDIN", equals)
+	}
+}
+
+func Test_newEventFilter(t *testing.T) {
+	f, err := newEventFilter("")
+	if err != nil || f != nil {
+		t.Errorf("newEventFilter(\"\") = %v, %v, want nil, nil", f, err)
+	}
+
+	f, err = newEventFilter(`{"severities":["ERROR"],"modules":["Broker"]}`)
+	if err != nil {
+		t.Fatalf("newEventFilter() error = %v", err)
+	}
+	want := &EventFilter{Severities: []string{"ERROR"}, Modules: []string{"Broker"}}
+	if diff := cmp.Diff(want, f); diff != "" {
+		t.Errorf("newEventFilter() (-want, +got) = %s", diff)
+	}
+
+	if _, err = newEventFilter("{"); err == nil {
+		t.Error("newEventFilter() with invalid JSON succeeded")
+	}
+}
@@ -25,15 +25,23 @@ import (
 )
 
 const (
-	// domainSecretKey is the key (filename) of the projected secret containing the
-	// Horizon Active Directory Domain to use
-	domainSecretKey = "domain"
+	// DomainSecretKey is the key (filename) of the projected secret containing
+	// the Horizon Active Directory Domain to use
+	DomainSecretKey = "domain"
 
 	// DefaultSecretMountPath is the default mount path of the Kubernetes Secret
 	// containing Horizon credentials
 	//nolint:gosec
 	DefaultSecretMountPath = "/var/bindings/horizon" // filepath.Join isn't const.
 
+	// VolumeName is the name of the volume holding the Kubernetes Secret
+	// injected by a HorizonBinding
+	VolumeName = "horizon-binding"
+
+	// DeadLetterSinkTokenName is the name of the token volume of the dead
+	// letter sink audience
+	DeadLetterSinkTokenName = "dead-letter-sink-token"
+
 	// HTTP client
 	defaultTimeout = time.Second * 5
 	defaultRetries = 3
@@ -47,6 +55,10 @@ const (
 
 var errTokenExpired = errors.New("refresh token expired")
 
+// ErrLoginRejected is returned when the Horizon API rejects a login, e.g.
+// because of invalid credentials
+var ErrLoginRejected = errors.New("horizon API login rejected")
+
 // Client gets events from the configured Horizon API REST server
 type Client interface {
 	GetEvents(ctx context.Context, since Timestamp) ([]AuditEventSummary, error)
@@ -57,6 +69,7 @@ type horizonClient struct {
 	client      *resty.Client
 	credentials AuthLoginRequest
 	tokens      AuthTokens
+	filter      *EventFilter
 	logger      *zap.SugaredLogger
 }
 
@@ -92,9 +105,9 @@ func newHorizonClient(ctx context.Context) (*horizonClient, error) {
 		return nil, fmt.Errorf("read secret key %q: %w", corev1.BasicAuthPasswordKey, err)
 	}
 
-	domain, err := readSecretKey(domainSecretKey)
+	domain, err := readSecretKey(DomainSecretKey)
 	if err != nil {
-		return nil, fmt.Errorf("read secret key %q: %w", domainSecretKey, err)
+		return nil, fmt.Errorf("read secret key %q: %w", DomainSecretKey, err)
 	}
 
 	creds := AuthLoginRequest{
@@ -119,11 +132,17 @@ func newHorizonClient(ctx context.Context) (*horizonClient, error) {
 		return nil, fmt.Errorf("process environment variables: %w", err)
 	}
 
+	filter, err := newEventFilter(env.EventFilter)
+	if err != nil {
+		return nil, fmt.Errorf("parse event filter: %w", err)
+	}
+
 	rc := newRESTClient(ctx, env.Address, env.Insecure)
 	c := horizonClient{
 		client:      rc,
 		logger:      logging.FromContext(ctx),
 		credentials: creds,
+		filter:      filter,
 	}
 
 	if env.Insecure {
@@ -183,7 +202,7 @@ func (h *horizonClient) login(ctx context.Context) error {
 	}
 
 	if !res.IsSuccess() {
-		return fmt.Errorf("horizon API login returned non-success status code: %d", res.StatusCode())
+		return fmt.Errorf("%w: non-success status code: %d", ErrLoginRejected, res.StatusCode())
 	}
 
 	var tokens AuthTokens
@@ -238,12 +257,17 @@ func (h *horizonClient) GetEvents(ctx context.Context, since Timestamp) ([]Audit
 		retries int
 		err     error
 
-		timeRange string
-		params    map[string]string
+		filter string
+		params map[string]string
 	)
 
 	// handle auth expired cases
 	for retries < 2 {
+		filter, err = queryFilter(since, h.filter)
+		if err != nil {
+			return nil, fmt.Errorf("create query filter: %w", err)
+		}
+
 		if since == 0 {
 			// return last (up to) 10 initial events if no timestamp is specified
 			params = map[string]string{
@@ -251,15 +275,12 @@ func (h *horizonClient) GetEvents(ctx context.Context, since Timestamp) ([]Audit
 				"page": "1",
 			}
 		} else {
-			timeRange, err = timeRangeFilter(since, 0)
-			h.logger.Debugw("using time range filter", "filter", timeRange)
-			if err != nil {
-				return nil, fmt.Errorf("create time range query filter: %w", err)
-			}
+			params = map[string]string{}
+		}
 
-			params = map[string]string{
-				"filter": timeRange,
-			}
+		if filter != "" {
+			h.logger.Debugw("using query filter", "filter", filter)
+			params["filter"] = filter
 		}
 
 		req := h.client.R().SetContext(ctx).SetQueryParams(params)
@@ -306,33 +327,6 @@ func (h *horizonClient) GetEvents(ctx context.Context, since Timestamp) ([]Audit
 	return nil, fmt.Errorf("get events status code: %d %s", res.StatusCode(), string(res.Body()))
 }
 
-// timeRangeFilter returns the JSON-encoded query string for the given timestamp
-// range. Both values are interpreted as inclusive range values. If to is 0 an
-// arbitrary time (UTC) in the future is used as the upper range bound.
-func timeRangeFilter(from, to Timestamp) (string, error) {
-	// avoid small clock sync issues between client and server and use 1d as future
-	// timestamp buffer
-	timeBuffer := time.Hour * 24
-
-	if to == 0 {
-		to = Timestamp(time.Now().Add(timeBuffer).Unix() * 1000) // milliseconds
-	}
-
-	f := BetweenFilter{
-		Type:      "Between",
-		Name:      "time",
-		FromValue: from,
-		ToValue:   to,
-	}
-
-	filter, err := json.Marshal(f)
-	if err != nil {
-		return "", fmt.Errorf("JSON marshal filter: %w", err)
-	}
-
-	return string(filter), nil
-}
-
 // Logout performs a logout against the Horizon API
 func (h *horizonClient) Logout(ctx context.Context) error {
 	request := RefreshTokenRequest{h.tokens.RefreshToken}
@@ -0,0 +1,61 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package horizon
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/vmware-tanzu/sources-for-knative/pkg/cemapping"
+)
+
+// entityTypeMachine is the entity type of the machine associated with an event
+const entityTypeMachine = "Machine"
+
+// DefaultCloudEventMapping maps Horizon events to a CloudEvent type with the
+// normalized Horizon event type, e.g. VLSI_USERLOGGEDIN is mapped to
+// com.vmware.horizon.vlsi_userloggedin.v0, the Horizon address as source and
+// the machine associated with the event as subject.
+var DefaultCloudEventMapping = cemapping.Config{
+	Type:    "com.vmware.horizon.{{ lower .Type }}.v0",
+	Source:  "{{ .Address }}",
+	Subject: "{{ .Entity }}",
+}
+
+// defaultMapper is used by adapters without a configured mapper
+var defaultMapper = cemapping.MustNew(cemapping.Config{}, DefaultCloudEventMapping)
+
+// newMapper returns the mapper for the JSON-encoded cemapping.Config and the
+// CloudEvent source of the Horizon server at address
+func newMapper(config, address string) (*cemapping.Mapper, string, error) {
+	var c cemapping.Config
+	if config != "" {
+		if err := json.Unmarshal([]byte(config), &c); err != nil {
+			return nil, "", fmt.Errorf("unmarshal CloudEvent mapping: %w", err)
+		}
+	}
+
+	m, err := cemapping.New(c, DefaultCloudEventMapping)
+	if err != nil {
+		return nil, "", err
+	}
+
+	source, err := m.Source(cemapping.SourceData{Address: address})
+	if err != nil {
+		return nil, "", err
+	}
+	return m, source, nil
+}
+
+// eventData returns the data of the type and subject templates for the event
+func eventData(e AuditEventSummary) cemapping.EventData {
+	d := cemapping.EventData{Type: e.Type}
+	if e.MachineID != "" {
+		d.Entity = e.MachineID
+		d.EntityType = entityTypeMachine
+	}
+	return d
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package horizon
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"knative.dev/pkg/logging"
+)
+
+// ErrTLS is returned by Probe when no TLS connection could be established
+// with the Horizon API, e.g. because of an untrusted certificate
+var ErrTLS = errors.New("TLS handshake with horizon API failed")
+
+// Probe verifies that the Horizon API at address accepts the given
+// credentials by logging in and out again. Requests are not retried and each
+// is bounded by timeout. The returned error wraps ErrTLS or ErrLoginRejected
+// if applicable.
+func Probe(ctx context.Context, address string, insecure bool, creds AuthLoginRequest, timeout time.Duration) error {
+	rc := newRESTClient(ctx, address, insecure).
+		SetRetryCount(0).
+		SetTimeout(timeout)
+
+	h := horizonClient{
+		client:      rc,
+		credentials: creds,
+		logger:      logging.FromContext(ctx),
+	}
+
+	if err := h.login(ctx); err != nil {
+		if isTLSError(err) {
+			return fmt.Errorf("%w: %v", ErrTLS, err)
+		}
+		return err
+	}
+
+	// don't leave sessions behind
+	if err := h.Logout(ctx); err != nil {
+		h.logger.Debugw("could not log out after probing horizon API", zap.Error(err))
+	}
+	return nil
+}
+
+func isTLSError(err error) bool {
+	var (
+		unknownAuthority x509.UnknownAuthorityError
+		hostname         x509.HostnameError
+		invalid          x509.CertificateInvalidError
+		recordHeader     tls.RecordHeaderError
+	)
+
+	return errors.As(err, &unknownAuthority) ||
+		errors.As(err, &hostname) ||
+		errors.As(err, &invalid) ||
+		errors.As(err, &recordHeader)
+}
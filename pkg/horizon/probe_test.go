@@ -0,0 +1,57 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package horizon
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"knative.dev/pkg/logging"
+)
+
+func TestProbe(t *testing.T) {
+	ctx := logging.WithLogger(context.Background(), zaptest.NewLogger(t).Sugar())
+
+	creds := AuthLoginRequest{
+		Domain:   testDomain,
+		Username: testUsername,
+		Password: testPassword,
+	}
+
+	t.Run("successful login", func(t *testing.T) {
+		ts := newTestServer(ctx)
+		defer ts.httpSrv.Close()
+
+		require.NoError(t, Probe(ctx, ts.httpSrv.URL, false, creds, time.Second))
+	})
+
+	t.Run("invalid credentials", func(t *testing.T) {
+		ts := newTestServer(ctx)
+		defer ts.httpSrv.Close()
+
+		invalid := creds
+		invalid.Password = "wrong"
+		err := Probe(ctx, ts.httpSrv.URL, false, invalid, time.Second)
+		require.ErrorIs(t, err, ErrLoginRejected)
+	})
+
+	t.Run("untrusted certificate", func(t *testing.T) {
+		ts := httptest.NewTLSServer(http.NotFoundHandler())
+		defer ts.Close()
+
+		err := Probe(ctx, ts.URL, false, creds, time.Second)
+		require.ErrorIs(t, err, ErrTLS)
+
+		// certificate is not verified
+		err = Probe(ctx, ts.URL, true, creds, time.Second)
+		require.ErrorIs(t, err, ErrLoginRejected)
+	})
+}
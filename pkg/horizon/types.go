@@ -90,6 +90,33 @@ type BetweenFilter struct {
 	ToValue   interface{} `json:"toValue,omitempty"`
 }
 
+// EqualsFilter matches if the value of the named field equals Value.
+type EqualsFilter struct {
+	Type  string      `json:"type,omitempty"`
+	Name  string      `json:"name,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// LogicalFilter combines Filters with an "And" or "Or" Type.
+type LogicalFilter struct {
+	Type    string        `json:"type,omitempty"`
+	Filters []interface{} `json:"filters,omitempty"`
+}
+
+// PositionField is the status field of a HorizonSource holding the Position of
+// its adapter
+const PositionField = "lastEventPosition"
+
+// Position is the position of the adapter in the Horizon event stream. Several
+// events can share the same millisecond, thus the IDs of all processed events
+// at Time are kept.
+type Position struct {
+	// Time of the last processed events
+	Time Timestamp `json:"time"`
+	// EventIDs of the processed events at Time
+	EventIDs []int64 `json:"eventIDs,omitempty"`
+}
+
 // Timestamp is time since unix epoch (UTC) in milliseconds (as defined by
 // Horizon spec)
 type Timestamp int64
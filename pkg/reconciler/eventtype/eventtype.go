@@ -0,0 +1,209 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package eventtype registers the CloudEvent types produced by a source as
+// Knative EventTypes of the broker the source delivers to.
+package eventtype
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/eventing/pkg/apis/eventing/v1beta2"
+	eventingclientset "knative.dev/eventing/pkg/client/clientset/versioned"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/logging"
+)
+
+const (
+	// SourceLabel is the label with the name of the source which registered
+	// an EventType
+	SourceLabel = "sources.tanzu.vmware.com/eventtype-source"
+
+	// DefaultInterval is the default time after which unchanged EventTypes
+	// are applied again, e.g. to restore deleted ones
+	DefaultInterval = time.Hour
+
+	brokerGroup = "eventing.knative.dev"
+	brokerKind  = "Broker"
+)
+
+// Broker returns the reference of the broker of sink, nil if sink does not
+// refer to a broker.
+func Broker(sink duckv1.Destination, namespace string) *duckv1.KReference {
+	ref := sink.Ref
+	if ref == nil || ref.Kind != brokerKind || !strings.HasPrefix(ref.APIVersion, brokerGroup+"/") {
+		return nil
+	}
+
+	ref = ref.DeepCopy()
+	if ref.Namespace == "" {
+		ref.Namespace = namespace
+	}
+	return ref
+}
+
+// Make returns the EventType of owner for the CloudEvent type of spec. The
+// name of the EventType is derived from the owner and the CloudEvent type.
+func Make(owner kmeta.OwnerRefable, spec v1beta2.EventTypeSpec) *v1beta2.EventType {
+	kind := owner.GetGroupVersionKind().Kind
+	sum := sha256.Sum256([]byte(kind + "/" + spec.Type))
+
+	return &v1beta2.EventType{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            kmeta.ChildName(owner.GetObjectMeta().GetName(), "-"+hex.EncodeToString(sum[:6])),
+			Namespace:       owner.GetObjectMeta().GetNamespace(),
+			Labels:          map[string]string{SourceLabel: owner.GetObjectMeta().GetName()},
+			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(owner)},
+		},
+		Spec: spec,
+	}
+}
+
+// Reconciler creates, updates and deletes the EventTypes of sources. The
+// EventTypes of a source are only applied again when they changed or the last
+// apply is older than Interval, since a vCenter event catalog has hundreds of
+// types.
+type Reconciler struct {
+	EventingClientSet eventingclientset.Interface
+	Interval          time.Duration
+
+	mu      sync.Mutex
+	applied map[types.UID]applied
+}
+
+// applied are the EventTypes of a source applied at the given time
+type applied struct {
+	hash string
+	time time.Time
+}
+
+// NewReconciler returns a Reconciler applying unchanged EventTypes again after
+// DefaultInterval
+func NewReconciler(client eventingclientset.Interface) *Reconciler {
+	return &Reconciler{
+		EventingClientSet: client,
+		Interval:          DefaultInterval,
+	}
+}
+
+// Reconcile makes the EventTypes controlled by owner match desired. All
+// EventTypes of owner are deleted if desired is empty.
+func (r *Reconciler) Reconcile(ctx context.Context, owner metav1.Object, desired []*v1beta2.EventType) error {
+	hash, err := hashOf(desired)
+	if err != nil {
+		return err
+	}
+
+	key := owner.GetUID()
+	r.mu.Lock()
+	last, ok := r.applied[key]
+	r.mu.Unlock()
+	if ok && last.hash == hash && time.Since(last.time) < r.Interval {
+		return nil
+	}
+
+	if err := r.apply(ctx, owner, desired); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.applied == nil {
+		r.applied = make(map[types.UID]applied)
+	}
+	r.applied[key] = applied{hash: hash, time: time.Now()}
+
+	// forget deleted sources
+	for k, a := range r.applied {
+		if time.Since(a.time) > 2*r.Interval {
+			delete(r.applied, k)
+		}
+	}
+	return nil
+}
+
+func (r *Reconciler) apply(ctx context.Context, owner metav1.Object, desired []*v1beta2.EventType) error {
+	ns := owner.GetNamespace()
+	client := r.EventingClientSet.EventingV1beta2().EventTypes(ns)
+
+	selector := labels.SelectorFromSet(labels.Set{SourceLabel: owner.GetName()})
+	list, err := client.List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return fmt.Errorf("failed to list eventtypes: %w", err)
+	}
+
+	existing := make(map[string]*v1beta2.EventType, len(list.Items))
+	for i := range list.Items {
+		if et := &list.Items[i]; metav1.IsControlledBy(et, owner) {
+			existing[et.Name] = et
+		}
+	}
+
+	var created, updated, deleted int
+	for _, want := range desired {
+		et, ok := existing[want.Name]
+		delete(existing, want.Name)
+
+		if !ok {
+			if _, err := client.Create(ctx, want, metav1.CreateOptions{}); err != nil {
+				return fmt.Errorf("failed to create eventtype %q: %w", want.Name, err)
+			}
+			created++
+			continue
+		}
+
+		if !equality.Semantic.DeepEqual(et.Spec, want.Spec) || !equality.Semantic.DeepEqual(et.Labels, want.Labels) {
+			et = et.DeepCopy()
+			et.Spec = want.Spec
+			et.Labels = want.Labels
+			if _, err := client.Update(ctx, et, metav1.UpdateOptions{}); err != nil {
+				return fmt.Errorf("failed to update eventtype %q: %w", et.Name, err)
+			}
+			updated++
+		}
+	}
+
+	for name, et := range existing {
+		err := client.Delete(ctx, name, metav1.DeleteOptions{
+			Preconditions: metav1.NewUIDPreconditions(string(et.UID)),
+		})
+		if err != nil && !apierrs.IsNotFound(err) {
+			return fmt.Errorf("failed to delete eventtype %q: %w", name, err)
+		}
+		deleted++
+	}
+
+	if created+updated+deleted > 0 {
+		logging.FromContext(ctx).Infof("Reconciled eventtypes: %d created, %d updated, %d deleted", created, updated, deleted)
+	}
+	return nil
+}
+
+// hashOf returns a hash of the EventTypes to detect changes
+func hashOf(eventTypes []*v1beta2.EventType) (string, error) {
+	h := sha256.New()
+	for _, et := range eventTypes {
+		b, err := json.Marshal(et)
+		if err != nil {
+			return "", fmt.Errorf("marshal eventtype %q: %w", et.Name, err)
+		}
+		h.Write(b)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package eventtype
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/eventing/pkg/apis/eventing/v1beta2"
+	eventingfake "knative.dev/eventing/pkg/client/clientset/versioned/fake"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	"github.com/vmware-tanzu/sources-for-knative/pkg/apis/sources/v1alpha1"
+)
+
+func TestBroker(t *testing.T) {
+	tests := []struct {
+		name string
+		sink duckv1.Destination
+		want *duckv1.KReference
+	}{{
+		name: "broker",
+		sink: duckv1.Destination{Ref: &duckv1.KReference{APIVersion: "eventing.knative.dev/v1", Kind: "Broker", Name: "default"}},
+		want: &duckv1.KReference{APIVersion: "eventing.knative.dev/v1", Kind: "Broker", Name: "default", Namespace: "ns"},
+	}, {
+		name: "broker in other namespace",
+		sink: duckv1.Destination{Ref: &duckv1.KReference{APIVersion: "eventing.knative.dev/v1", Kind: "Broker", Name: "default", Namespace: "other"}},
+		want: &duckv1.KReference{APIVersion: "eventing.knative.dev/v1", Kind: "Broker", Name: "default", Namespace: "other"},
+	}, {
+		name: "service",
+		sink: duckv1.Destination{Ref: &duckv1.KReference{APIVersion: "serving.knative.dev/v1", Kind: "Service", Name: "default"}},
+	}, {
+		name: "uri",
+		sink: duckv1.Destination{},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, Broker(tt.sink, "ns")); diff != "" {
+				t.Errorf("Broker() (-want, +got) = %s", diff)
+			}
+		})
+	}
+}
+
+func TestMake(t *testing.T) {
+	vms := &v1alpha1.VSphereSource{ObjectMeta: metav1.ObjectMeta{Name: "vsphere-01", Namespace: "ns", UID: "1234"}}
+	hs := &v1alpha1.HorizonSource{ObjectMeta: metav1.ObjectMeta{Name: "vsphere-01", Namespace: "ns", UID: "5678"}}
+
+	et := Make(vms, v1beta2.EventTypeSpec{Type: "com.vmware.vsphere.VmPoweredOnEvent.v0"})
+	if !metav1.IsControlledBy(et, vms) {
+		t.Errorf("Make() owner references = %v, want controlled by source", et.OwnerReferences)
+	}
+	if et.Namespace != "ns" || et.Labels[SourceLabel] != "vsphere-01" {
+		t.Errorf("Make() namespace = %q, labels = %v", et.Namespace, et.Labels)
+	}
+
+	other := Make(vms, v1beta2.EventTypeSpec{Type: "com.vmware.vsphere.VmPoweredOffEvent.v0"})
+	if other.Name == et.Name {
+		t.Errorf("Make() name %q is not unique per type", et.Name)
+	}
+	if got := Make(hs, et.Spec).Name; got == et.Name {
+		t.Errorf("Make() name %q is not unique per source kind", got)
+	}
+}
+
+func listNames(ctx context.Context, t *testing.T, r *Reconciler) []string {
+	t.Helper()
+
+	list, err := r.EventingClientSet.EventingV1beta2().EventTypes("ns").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, et := range list.Items {
+		names = append(names, et.Name+"="+et.Spec.Type)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestReconciler_Reconcile(t *testing.T) {
+	ctx := context.TODO()
+
+	vms := &v1alpha1.VSphereSource{ObjectMeta: metav1.ObjectMeta{Name: "vsphere-01", Namespace: "ns", UID: "1234"}}
+	broker := &duckv1.KReference{APIVersion: "eventing.knative.dev/v1", Kind: "Broker", Name: "default", Namespace: "ns"}
+	eventTypes := func(types ...string) []*v1beta2.EventType {
+		var ets []*v1beta2.EventType
+		for _, typ := range types {
+			ets = append(ets, Make(vms, v1beta2.EventTypeSpec{Type: typ, Reference: broker}))
+		}
+		return ets
+	}
+	names := func(ets []*v1beta2.EventType) []string {
+		var names []string
+		for _, et := range ets {
+			names = append(names, et.Name+"="+et.Spec.Type)
+		}
+		sort.Strings(names)
+		return names
+	}
+
+	// an EventType of another source with the same name
+	foreign := Make(&v1alpha1.VSphereSource{ObjectMeta: metav1.ObjectMeta{Name: "vsphere-01", Namespace: "ns", UID: "5678"}},
+		v1beta2.EventTypeSpec{Type: "foreign"})
+	r := NewReconciler(eventingfake.NewSimpleClientset(foreign))
+
+	desired := eventTypes("a", "b")
+	if err := r.Reconcile(ctx, vms, desired); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	want := append(names(desired), foreign.Name+"=foreign")
+	sort.Strings(want)
+	if diff := cmp.Diff(want, listNames(ctx, t, r)); diff != "" {
+		t.Errorf("created eventtypes (-want, +got) = %s", diff)
+	}
+
+	// unchanged EventTypes are not applied again before the interval passed
+	if err := r.EventingClientSet.EventingV1beta2().EventTypes("ns").Delete(ctx, desired[0].Name, metav1.DeleteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Reconcile(ctx, vms, desired); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if got := len(listNames(ctx, t, r)); got != 2 {
+		t.Errorf("eventtypes = %d after unchanged reconcile, want 2", got)
+	}
+	r.Interval = time.Nanosecond
+	if err := r.Reconcile(ctx, vms, desired); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if diff := cmp.Diff(want, listNames(ctx, t, r)); diff != "" {
+		t.Errorf("restored eventtypes (-want, +got) = %s", diff)
+	}
+	r.Interval = DefaultInterval
+
+	// changed specs are updated, obsolete EventTypes deleted
+	desired = eventTypes("b", "c")
+	desired[0].Spec.Description = "changed"
+	if err := r.Reconcile(ctx, vms, desired); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	want = append(names(desired), foreign.Name+"=foreign")
+	sort.Strings(want)
+	if diff := cmp.Diff(want, listNames(ctx, t, r)); diff != "" {
+		t.Errorf("reconciled eventtypes (-want, +got) = %s", diff)
+	}
+	et, err := r.EventingClientSet.EventingV1beta2().EventTypes("ns").Get(ctx, desired[0].Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if et.Spec.Description != "changed" {
+		t.Errorf("eventtype description = %q, want updated", et.Spec.Description)
+	}
+
+	// the sink is no broker anymore
+	if err := r.Reconcile(ctx, vms, nil); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if diff := cmp.Diff([]string{foreign.Name + "=foreign"}, listNames(ctx, t, r)); diff != "" {
+		t.Errorf("remaining eventtypes (-want, +got) = %s", diff)
+	}
+}
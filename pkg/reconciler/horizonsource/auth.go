@@ -0,0 +1,210 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package horizonsource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+
+	"github.com/vmware-tanzu/sources-for-knative/pkg/apis/sources/v1alpha1"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/horizon"
+)
+
+const (
+	// time after which the login of a source with unchanged secret, address
+	// and TLS settings is probed again
+	authProbeInterval = 10 * time.Minute
+	// timeout of each request to the Horizon API during a probe
+	authProbeTimeout = 5 * time.Second
+	// minimum delay before a rate-limited probe is attempted again
+	authProbeRetry = time.Second
+)
+
+// ProbeFunc logs in to the Horizon API at address with the given credentials
+type ProbeFunc func(ctx context.Context, address string, insecure bool, creds horizon.AuthLoginRequest, timeout time.Duration) error
+
+// AuthReconciler probes the Horizon API login of HorizonSources to set their
+// AuthReady condition. Probes run in the background, are rate-limited across
+// all sources and only repeated when the secret, address or TLS settings of a
+// source change or the last probe is older than Interval.
+type AuthReconciler struct {
+	Probe    ProbeFunc
+	Limiter  *rate.Limiter
+	Interval time.Duration
+	// Enqueue reconciles the source with the given key again once its probe
+	// completed, ignored if nil
+	Enqueue func(types.NamespacedName)
+
+	mu     sync.Mutex
+	probes map[types.NamespacedName]authProbe
+	// inputs of the running probes
+	running map[types.NamespacedName]string
+}
+
+// authProbe is the result of a probe with the given inputs
+type authProbe struct {
+	inputs string
+	time   time.Time
+	err    error
+}
+
+// NewAuthReconciler returns an AuthReconciler probing with horizon.Probe at
+// most once per second
+func NewAuthReconciler(enqueue func(types.NamespacedName)) *AuthReconciler {
+	return &AuthReconciler{
+		Probe:    horizon.Probe,
+		Limiter:  rate.NewLimiter(rate.Every(time.Second), 5),
+		Interval: authProbeInterval,
+		Enqueue:  enqueue,
+	}
+}
+
+// ReconcileAuth sets the AuthReady condition of src from the last probe of
+// the given credentials and starts a new probe if required. The source is
+// enqueued when the probe completes. A requeue error is returned to probe
+// again once the rate limit or the age of the last probe allows it.
+func (r *AuthReconciler) ReconcileAuth(ctx context.Context, src *v1alpha1.HorizonSource, secret *corev1.Secret, creds horizon.AuthLoginRequest) error {
+	key := types.NamespacedName{Namespace: src.Namespace, Name: src.Name}
+	inputs := fmt.Sprintf("%s/%s/%s/%t", secret.UID, secret.ResourceVersion, src.Spec.Address.String(), src.Spec.SkipTLSVerify)
+
+	r.mu.Lock()
+	last, ok := r.probes[key]
+	probing := r.running[key] == inputs
+	r.mu.Unlock()
+
+	current := ok && last.inputs == inputs
+	age := time.Since(last.time)
+
+	var requeue error
+	switch {
+	case probing:
+		// enqueued once the probe completes
+	case !current || age > r.Interval:
+		if r.Limiter.Allow() {
+			r.startProbe(ctx, key, inputs, src, creds)
+		} else {
+			// keep an outdated result until the probe is allowed
+			requeue = controller.NewRequeueAfter(r.retryAfter())
+		}
+	default:
+		requeue = controller.NewRequeueAfter(r.Interval - age)
+	}
+
+	switch {
+	case !current:
+		src.Status.MarkAuthUnknown("ProbePending", "Horizon API login has not been probed yet")
+	case last.err == nil:
+		src.Status.MarkAuthReady()
+	case errors.Is(last.err, horizon.ErrTLS):
+		src.Status.MarkAuthFailed("TLSError", "%v", last.err)
+	default:
+		src.Status.MarkAuthFailed("LoginFailed", "%v", last.err)
+	}
+	return requeue
+}
+
+// retryAfter returns the time until the rate limit allows the next probe
+func (r *AuthReconciler) retryAfter() time.Duration {
+	res := r.Limiter.Reserve()
+	defer res.Cancel()
+
+	if res.OK() && res.Delay() > authProbeRetry {
+		return res.Delay()
+	}
+	return authProbeRetry
+}
+
+// startProbe probes the login of src in the background, detached from the
+// reconciliation which started it
+func (r *AuthReconciler) startProbe(ctx context.Context, key types.NamespacedName, inputs string, src *v1alpha1.HorizonSource, creds horizon.AuthLoginRequest) {
+	r.mu.Lock()
+	if r.running == nil {
+		r.running = make(map[types.NamespacedName]string)
+	}
+	r.running[key] = inputs
+	r.mu.Unlock()
+
+	address, insecure := src.Spec.Address.String(), src.Spec.SkipTLSVerify
+	logger := logging.FromContext(ctx)
+	go func() {
+		// login and logout
+		ctx, cancel := context.WithTimeout(logging.WithLogger(context.Background(), logger), 2*authProbeTimeout)
+		defer cancel()
+
+		r.probe(ctx, key, inputs, address, insecure, creds)
+		if r.Enqueue != nil {
+			r.Enqueue(key)
+		}
+	}()
+}
+
+func (r *AuthReconciler) probe(ctx context.Context, key types.NamespacedName, inputs, address string, insecure bool, creds horizon.AuthLoginRequest) {
+	err := r.Probe(ctx, address, insecure, creds, authProbeTimeout)
+	if err != nil {
+		logging.FromContext(ctx).Infow("horizon API login probe failed", zap.Error(err))
+	}
+	result := authProbe{inputs: inputs, time: time.Now(), err: err}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if running, ok := r.running[key]; ok && running != inputs {
+		// superseded by a probe of changed inputs
+		return
+	}
+	delete(r.running, key)
+
+	if r.probes == nil {
+		r.probes = make(map[types.NamespacedName]authProbe)
+	}
+	r.probes[key] = result
+
+	// forget deleted sources
+	for k, p := range r.probes {
+		if time.Since(p.time) > 2*r.Interval {
+			delete(r.probes, k)
+		}
+	}
+}
+
+// credentialsFromSecret returns the Horizon API credentials in secret. An
+// error listing the missing keys is returned if any key is missing or empty.
+func credentialsFromSecret(secret *corev1.Secret) (horizon.AuthLoginRequest, error) {
+	creds := horizon.AuthLoginRequest{
+		Domain:   string(secret.Data[horizon.DomainSecretKey]),
+		Username: string(secret.Data[corev1.BasicAuthUsernameKey]),
+		Password: string(secret.Data[corev1.BasicAuthPasswordKey]),
+	}
+
+	var missing []string
+	for key, value := range map[string]string{
+		horizon.DomainSecretKey:     creds.Domain,
+		corev1.BasicAuthUsernameKey: creds.Username,
+		corev1.BasicAuthPasswordKey: creds.Password,
+	} {
+		if value == "" {
+			missing = append(missing, key)
+		}
+	}
+
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return creds, fmt.Errorf("secret %q is missing keys: %s", secret.Name, strings.Join(missing, ", "))
+	}
+	return creds, nil
+}
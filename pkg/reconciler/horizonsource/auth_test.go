@@ -0,0 +1,216 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package horizonsource
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/controller"
+
+	"github.com/vmware-tanzu/sources-for-knative/pkg/apis/sources/v1alpha1"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/horizon"
+)
+
+func TestAuthReconciler_ReconcileAuth(t *testing.T) {
+	ctx := context.TODO()
+
+	newSource := func() *v1alpha1.HorizonSource {
+		src := &v1alpha1.HorizonSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "horizon-01",
+				Namespace: "default",
+			},
+			Spec: v1alpha1.HorizonSourceSpec{
+				HorizonAuthSpec: v1alpha1.HorizonAuthSpec{
+					Address:   *apis.HTTPS("horizon-01.example.com"),
+					SecretRef: corev1.LocalObjectReference{Name: "horizon-creds"},
+				},
+			},
+		}
+		src.Status.InitializeConditions()
+		return src
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "horizon-creds",
+			Namespace:       "default",
+			UID:             "1234",
+			ResourceVersion: "1",
+		},
+	}
+	creds := horizon.AuthLoginRequest{Domain: "corp", Username: "user", Password: "pass"}
+
+	var (
+		mu       sync.Mutex
+		probes   int
+		probeErr error
+		// blocks probes while not nil
+		gate chan struct{}
+	)
+	enqueued := make(chan types.NamespacedName, 1)
+	r := &AuthReconciler{
+		Probe: func(ctx context.Context, address string, insecure bool, creds horizon.AuthLoginRequest, timeout time.Duration) error {
+			mu.Lock()
+			probes++
+			err, wait := probeErr, gate
+			mu.Unlock()
+			if wait != nil {
+				<-wait
+			}
+			return err
+		},
+		Limiter:  rate.NewLimiter(rate.Inf, 0),
+		Interval: time.Hour,
+		Enqueue: func(key types.NamespacedName) {
+			enqueued <- key
+		},
+	}
+
+	reconcile := func(t *testing.T, wantProbes int, wantStatus corev1.ConditionStatus, wantReason string, wantRequeue bool) {
+		t.Helper()
+
+		src := newSource()
+		err := r.ReconcileAuth(ctx, src, secret, creds)
+
+		// the number of probes is unknown while one runs
+		mu.Lock()
+		if wantProbes >= 0 && probes != wantProbes {
+			t.Errorf("probes = %d, want %d", probes, wantProbes)
+		}
+		mu.Unlock()
+		cond := src.Status.GetCondition(v1alpha1.HorizonSourceConditionAuthReady)
+		if cond == nil || cond.Status != wantStatus || cond.Reason != wantReason {
+			t.Errorf("AuthReady = %+v, want status %q and reason %q", cond, wantStatus, wantReason)
+		}
+		if requeue, after := controller.IsRequeueKey(err); requeue != wantRequeue || (requeue && after <= 0) {
+			t.Errorf("ReconcileAuth() = %v, want requeue %t", err, wantRequeue)
+		}
+	}
+
+	// waitProbe waits until the source is enqueued after its probe
+	waitProbe := func(t *testing.T) {
+		t.Helper()
+
+		select {
+		case key := <-enqueued:
+			if key.Name != "horizon-01" {
+				t.Errorf("enqueued %v, want horizon-01", key)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("source not enqueued after probe")
+		}
+	}
+
+	t.Run("successful login", func(t *testing.T) {
+		reconcile(t, -1, corev1.ConditionUnknown, "ProbePending", false)
+		waitProbe(t)
+		reconcile(t, 1, corev1.ConditionTrue, "", true)
+	})
+
+	t.Run("cached result", func(t *testing.T) {
+		probeErr = horizon.ErrLoginRejected
+		reconcile(t, 1, corev1.ConditionTrue, "", true)
+	})
+
+	t.Run("secret changed", func(t *testing.T) {
+		secret.ResourceVersion = "2"
+		reconcile(t, -1, corev1.ConditionUnknown, "ProbePending", false)
+		waitProbe(t)
+		reconcile(t, 2, corev1.ConditionFalse, "LoginFailed", true)
+	})
+
+	t.Run("probe in progress", func(t *testing.T) {
+		mu.Lock()
+		gate = make(chan struct{})
+		mu.Unlock()
+
+		secret.ResourceVersion = "3"
+		probeErr = fmt.Errorf("%w: x509: certificate signed by unknown authority", horizon.ErrTLS)
+		reconcile(t, -1, corev1.ConditionUnknown, "ProbePending", false)
+		// not probed again while the probe runs
+		reconcile(t, -1, corev1.ConditionUnknown, "ProbePending", false)
+
+		mu.Lock()
+		close(gate)
+		gate = nil
+		mu.Unlock()
+		waitProbe(t)
+		reconcile(t, 3, corev1.ConditionFalse, "TLSError", true)
+	})
+
+	t.Run("rate limited", func(t *testing.T) {
+		r.Limiter = rate.NewLimiter(0, 0)
+		secret.ResourceVersion = "4"
+		reconcile(t, 3, corev1.ConditionUnknown, "ProbePending", true)
+	})
+
+	t.Run("outdated result kept while rate limited", func(t *testing.T) {
+		secret.ResourceVersion = "3"
+		r.Interval = 0
+		reconcile(t, 3, corev1.ConditionFalse, "TLSError", true)
+	})
+}
+
+func Test_credentialsFromSecret(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    map[string][]byte
+		wantErr string
+	}{{
+		name: "complete",
+		data: map[string][]byte{
+			horizon.DomainSecretKey:     []byte("corp"),
+			corev1.BasicAuthUsernameKey: []byte("user"),
+			corev1.BasicAuthPasswordKey: []byte("pass"),
+		},
+	}, {
+		name: "empty password",
+		data: map[string][]byte{
+			horizon.DomainSecretKey:     []byte("corp"),
+			corev1.BasicAuthUsernameKey: []byte("user"),
+			corev1.BasicAuthPasswordKey: []byte(""),
+		},
+		wantErr: "missing keys: password",
+	}, {
+		name:    "empty secret",
+		wantErr: "missing keys: domain, password, username",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "horizon-creds"},
+				Data:       tt.data,
+			}
+
+			creds, err := credentialsFromSecret(secret)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("credentialsFromSecret() error = %v", err)
+				}
+				if creds.Domain != "corp" || creds.Username != "user" || creds.Password != "pass" {
+					t.Errorf("credentialsFromSecret() = %+v", creds)
+				}
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("credentialsFromSecret() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/vmware-tanzu/sources-for-knative/pkg/apis/sources/v1alpha1"
 
 	"github.com/kelseyhightower/envconfig"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/tools/cache"
 
 	"knative.dev/pkg/configmap"
@@ -21,13 +22,18 @@ import (
 	"knative.dev/pkg/logging"
 	"knative.dev/pkg/resolver"
 
+	eventingclient "knative.dev/eventing/pkg/client/injection/client"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	deploymentinformer "knative.dev/pkg/client/injection/kube/informers/apps/v1/deployment"
+	rbacinformer "knative.dev/pkg/client/injection/kube/informers/rbac/v1/rolebinding"
 
+	secretinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/secret"
 	sainformer "knative.dev/pkg/client/injection/kube/informers/core/v1/serviceaccount"
 
+	sourcesclient "github.com/vmware-tanzu/sources-for-knative/pkg/client/injection/client"
 	horizonsourceinformer "github.com/vmware-tanzu/sources-for-knative/pkg/client/injection/informers/sources/v1alpha1/horizonsource"
 	"github.com/vmware-tanzu/sources-for-knative/pkg/client/injection/reconciler/sources/v1alpha1/horizonsource"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/reconciler/eventtype"
 )
 
 const (
@@ -39,23 +45,48 @@ const (
 func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
 	ctx = controller.WithResyncPeriod(ctx, resyncPeriod)
 
+	horizonSourceInformer := horizonsourceinformer.Get(ctx)
+	saInformer := sainformer.Get(ctx)
+	deploymentInformer := deploymentinformer.Get(ctx)
+	secretInformer := secretinformer.Get(ctx)
+	rbInformer := rbacinformer.Get(ctx)
+
 	r := &Reconciler{
 		loggingContext: ctx,
-		kclient:        kubeclient.Get(ctx),
-		depl:           &DeploymentReconciler{KubeClientSet: kubeclient.Get(ctx)},
-		sa:             &ServiceAccountReconciler{KubeClientSet: kubeclient.Get(ctx)},
+		client:         sourcesclient.Get(ctx),
+		secretLister:   secretInformer.Lister(),
+		depl: &DeploymentReconciler{
+			KubeClientSet: kubeclient.Get(ctx),
+			Lister:        deploymentInformer.Lister(),
+		},
+		sa: &ServiceAccountReconciler{
+			KubeClientSet: kubeclient.Get(ctx),
+			Lister:        saInformer.Lister(),
+		},
+		rb: &RoleBindingReconciler{
+			KubeClientSet: kubeclient.Get(ctx),
+			Lister:        rbInformer.Lister(),
+		},
 	}
 
 	if err := envconfig.Process("", r); err != nil {
 		logging.FromContext(ctx).Panicf("required environment variable is not defined: %v", err)
 	}
 
-	impl := horizonsource.NewImpl(ctx, r)
+	if r.EventTypes {
+		r.eventTypes = eventtype.NewReconciler(eventingclient.Get(ctx))
+	}
+
+	impl := horizonsource.NewImpl(ctx, r, func(*controller.Impl) controller.Options {
+		// the Reconciler updates the status itself, see updateStatus
+		return controller.Options{SkipStatusUpdates: true}
+	})
 	r.sinkResolver = resolver.NewURIResolverFromTracker(ctx, impl.Tracker)
+	r.tracker = impl.Tracker
 
-	horizonSourceInformer := horizonsourceinformer.Get(ctx)
-	saInformer := sainformer.Get(ctx)
-	deploymentInformer := deploymentinformer.Get(ctx)
+	if r.AuthProbe {
+		r.auth = NewAuthReconciler(impl.EnqueueKey)
+	}
 
 	horizonSourceInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
 
@@ -69,6 +100,20 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
 	})
 
+	rbInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.FilterControllerGK(v1alpha1.Kind("HorizonSource")),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
+
+	// re-trigger the reconciliation of sources when their secret is created
+	// or changed
+	secretInformer.Informer().AddEventHandler(controller.HandleAll(
+		controller.EnsureTypeMeta(
+			r.tracker.OnChanged,
+			corev1.SchemeGroupVersion.WithKind("Secret"),
+		),
+	))
+
 	cmw.Watch(logging.ConfigMapName(), r.UpdateFromLoggingConfigMap)
 	cmw.Watch(metrics.ConfigMapName(), r.UpdateFromMetricsConfigMap)
 
@@ -19,6 +19,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/kubernetes"
+	appsv1listers "k8s.io/client-go/listers/apps/v1"
 
 	"knative.dev/pkg/kmeta"
 	"knative.dev/pkg/logging"
@@ -47,13 +48,14 @@ func newDeploymentUpdated(namespace, name string) pkgreconciler.Event {
 
 type DeploymentReconciler struct {
 	KubeClientSet kubernetes.Interface
+	Lister        appsv1listers.DeploymentLister
 }
 
 // ReconcileDeployment reconciles deployment resource (adapter) for HorizonSource
 func (r *DeploymentReconciler) ReconcileDeployment(ctx context.Context, owner kmeta.OwnerRefable, expected *appsv1.Deployment) (*appsv1.Deployment, pkgreconciler.Event) {
 	namespace := owner.GetObjectMeta().GetNamespace()
 
-	ra, err := r.KubeClientSet.AppsV1().Deployments(namespace).Get(ctx, expected.Name, metav1.GetOptions{})
+	ra, err := r.Lister.Deployments(namespace).Get(expected.Name)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			ra, err = r.KubeClientSet.AppsV1().Deployments(namespace).Create(ctx, expected, metav1.CreateOptions{})
@@ -70,10 +72,17 @@ func (r *DeploymentReconciler) ReconcileDeployment(ctx context.Context, owner km
 			ra.Name, owner.GetGroupVersionKind().Kind, owner.GetObjectMeta().GetName())
 	}
 
-	if podSpecSync(ctx, expected.Spec.Template.Spec, ra.Spec.Template.Spec) {
-		logging.FromContext(ctx).Debugw("updating receive adapter: pod template spec out of sync")
+	podSpecChanged := podSpecSync(ctx, expected.Spec.Template.Spec, ra.Spec.Template.Spec)
+	// the adapter is scaled to zero while the source is suspended
+	replicasChanged := !equality.Semantic.DeepEqual(expected.Spec.Replicas, ra.Spec.Replicas)
+	if podSpecChanged || replicasChanged {
+		logging.FromContext(ctx).Debugw("updating receive adapter: deployment spec out of sync",
+			zap.Bool("podSpecChanged", podSpecChanged), zap.Bool("replicasChanged", replicasChanged))
 
+		// don't modify the informer cache
+		ra = ra.DeepCopy()
 		ra.Spec.Template.Spec = expected.Spec.Template.Spec
+		ra.Spec.Replicas = expected.Spec.Replicas
 		ra, err = r.KubeClientSet.AppsV1().Deployments(namespace).Update(ctx, ra, metav1.UpdateOptions{})
 		if err != nil {
 			return ra, err
@@ -86,16 +95,14 @@ func (r *DeploymentReconciler) ReconcileDeployment(ctx context.Context, owner km
 }
 
 func (r *DeploymentReconciler) FindOwned(ctx context.Context, owner kmeta.OwnerRefable, selector labels.Selector) (*appsv1.Deployment, error) {
-	dl, err := r.KubeClientSet.AppsV1().Deployments(owner.GetObjectMeta().GetNamespace()).List(ctx, metav1.ListOptions{
-		LabelSelector: selector.String(),
-	})
+	dl, err := r.Lister.Deployments(owner.GetObjectMeta().GetNamespace()).List(selector)
 	if err != nil {
 		logging.FromContext(ctx).Error("Unable to list deployments: %v", zap.Error(err))
 		return nil, err
 	}
-	for i := range dl.Items {
-		if metav1.IsControlledBy(&dl.Items[i], owner.GetObjectMeta()) {
-			return &dl.Items[i], nil
+	for _, d := range dl {
+		if metav1.IsControlledBy(d, owner.GetObjectMeta()) {
+			return d, nil
 		}
 	}
 	return nil, apierrors.NewNotFound(schema.GroupResource{}, "")
@@ -114,6 +121,10 @@ func getContainer(name string, spec corev1.PodSpec) (int, *corev1.Container) {
 func podSpecSync(_ context.Context, expected corev1.PodSpec, now corev1.PodSpec) bool {
 	old := *now.DeepCopy()
 	syncContainers(expected, now)
+	// the secret volume follows spec.secretRef
+	if !equality.Semantic.DeepEqual(expected.Volumes, now.Volumes) {
+		now.Volumes = expected.Volumes
+	}
 	return !equality.Semantic.DeepEqual(old, now)
 }
 
@@ -147,5 +158,9 @@ func syncContainers(expected corev1.PodSpec, now corev1.PodSpec) {
 		if !equality.Semantic.DeepEqual(expEnvs, nowEnvs) {
 			now.Containers[n].Env = ec.Env
 		}
+
+		if !equality.Semantic.DeepEqual(ec.VolumeMounts, nc.VolumeMounts) {
+			now.Containers[n].VolumeMounts = ec.VolumeMounts
+		}
 	}
 }
@@ -0,0 +1,223 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package horizonsource
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	appsv1listers "k8s.io/client-go/listers/apps/v1"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/ptr"
+	pkgreconciler "knative.dev/pkg/reconciler"
+
+	"github.com/vmware-tanzu/sources-for-knative/pkg/apis/sources/v1alpha1"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/horizon"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/reconciler/horizonsource/resources"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/reconciler/horizonsource/resources/names"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/sinkauth"
+)
+
+func TestDeploymentReconciler_ReconcileDeployment(t *testing.T) {
+	ctx := context.TODO()
+	src := &v1alpha1.HorizonSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "horizon-01",
+			Namespace: "default",
+			UID:       "1234",
+		},
+		Spec: v1alpha1.HorizonSourceSpec{
+			SourceSpec: duckv1.SourceSpec{
+				Sink: duckv1.Destination{URI: apis.HTTP("sink.example.com")},
+			},
+			ServiceAccountName: "horizon-sa",
+			HorizonAuthSpec: v1alpha1.HorizonAuthSpec{
+				Address:   *apis.HTTP("horizon-01.example.com"),
+				SecretRef: corev1.LocalObjectReference{Name: "horizon-creds"},
+			},
+		},
+	}
+
+	newAdapter := func() *resources.ReceiveAdapterArgs {
+		return &resources.ReceiveAdapterArgs{
+			Image:   "adapter",
+			Labels:  resources.Labels(src.Name),
+			Source:  src,
+			SinkURI: src.Spec.Sink.URI.String(),
+		}
+	}
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+
+	reconcile := func(t *testing.T, r *DeploymentReconciler, wantReason string) {
+		t.Helper()
+
+		expected, err := resources.NewReceiveAdapter(ctx, newAdapter())
+		if err != nil {
+			t.Fatalf("NewReceiveAdapter() error = %v", err)
+		}
+
+		ra, err := r.ReconcileDeployment(ctx, src, expected)
+		if ra != nil {
+			// mimic the deployment informer
+			if err := indexer.Update(ra); err != nil {
+				t.Fatalf("update indexer: %v", err)
+			}
+		}
+		if wantReason == "" {
+			if err != nil {
+				t.Fatalf("ReconcileDeployment() error = %v, want nil", err)
+			}
+			return
+		}
+
+		var event *pkgreconciler.ReconcilerEvent
+		if !errors.As(err, &event) || event.EventType != corev1.EventTypeNormal || event.Reason != wantReason {
+			t.Fatalf("ReconcileDeployment() error = %v, want %s event", err, wantReason)
+		}
+	}
+
+	r := &DeploymentReconciler{
+		KubeClientSet: kubefake.NewSimpleClientset(),
+		Lister:        appsv1listers.NewDeploymentLister(indexer),
+	}
+	reconcile(t, r, "DeploymentCreated")
+
+	// unchanged spec does not update the deployment
+	reconcile(t, r, "")
+
+	// changed address and secret are rolled out
+	src.Spec.Address = *apis.HTTP("horizon-02.example.com")
+	src.Spec.SecretRef.Name = "horizon-creds-rotated"
+	reconcile(t, r, "DeploymentUpdated")
+
+	ra, err := r.KubeClientSet.AppsV1().Deployments(src.Namespace).Get(ctx, names.NewAdapterName(src.Name), metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("get deployment: %v", err)
+	}
+
+	podSpec := ra.Spec.Template.Spec
+	if got := podSpec.Volumes[0].Secret.SecretName; got != "horizon-creds-rotated" {
+		t.Errorf("secret volume = %q, want %q", got, "horizon-creds-rotated")
+	}
+	if got := podSpec.Containers[0].VolumeMounts[0].Name; got != "horizon-creds-rotated" {
+		t.Errorf("volume mount = %q, want %q", got, "horizon-creds-rotated")
+	}
+	for _, env := range podSpec.Containers[0].Env {
+		if env.Name == "HORIZON_URL" && env.Value != "http://horizon-02.example.com" {
+			t.Errorf("HORIZON_URL = %q, want %q", env.Value, "http://horizon-02.example.com")
+		}
+	}
+
+	reconcile(t, r, "")
+
+	// suspending the source scales the adapter to zero
+	src.Spec.Suspend = true
+	reconcile(t, r, "DeploymentUpdated")
+
+	ra, err = r.KubeClientSet.AppsV1().Deployments(src.Namespace).Get(ctx, names.NewAdapterName(src.Name), metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("get deployment: %v", err)
+	}
+	if got := *ra.Spec.Replicas; got != 0 {
+		t.Errorf("replicas of suspended source = %d, want 0", got)
+	}
+
+	src.Spec.Suspend = false
+	reconcile(t, r, "DeploymentUpdated")
+	reconcile(t, r, "")
+}
+
+func TestNewReceiveAdapter_sinkAuth(t *testing.T) {
+	ctx := context.TODO()
+	src := &v1alpha1.HorizonSource{
+		ObjectMeta: metav1.ObjectMeta{Name: "horizon-01", Namespace: "default", UID: "1234"},
+		Spec: v1alpha1.HorizonSourceSpec{
+			HorizonAuthSpec: v1alpha1.HorizonAuthSpec{
+				Address:   *apis.HTTPS("horizon-01.example.com"),
+				SecretRef: corev1.LocalObjectReference{Name: "horizon-creds"},
+			},
+		},
+	}
+
+	envs := func(c corev1.Container) map[string]string {
+		m := make(map[string]string, len(c.Env))
+		for _, env := range c.Env {
+			m[env.Name] = env.Value
+		}
+		return m
+	}
+
+	// sinks without TLS and OIDC leave the adapter unchanged
+	ra, err := resources.NewReceiveAdapter(ctx, &resources.ReceiveAdapterArgs{
+		Image:   "adapter",
+		Labels:  resources.Labels(src.Name),
+		Source:  src,
+		SinkURI: "http://sink.example.com",
+	})
+	if err != nil {
+		t.Fatalf("NewReceiveAdapter() error = %v", err)
+	}
+	env := envs(ra.Spec.Template.Spec.Containers[0])
+	if _, ok := env[sinkauth.CACertsEnv]; ok {
+		t.Errorf("env %s is set without sink CA certs", sinkauth.CACertsEnv)
+	}
+	if got := len(ra.Spec.Template.Spec.Volumes); got != 1 {
+		t.Errorf("volumes = %d without sink audience, want 1", got)
+	}
+
+	ra, err = resources.NewReceiveAdapter(ctx, &resources.ReceiveAdapterArgs{
+		Image:                  "adapter",
+		Labels:                 resources.Labels(src.Name),
+		Source:                 src,
+		SinkURI:                "https://broker.example.com",
+		DeadLetterSinkURI:      "https://dls.example.com",
+		CACerts:                sinkauth.JoinCACerts(ptr.String("sink-cert"), ptr.String("dls-cert")),
+		SinkAudience:           ptr.String("broker-audience"),
+		DeadLetterSinkAudience: ptr.String("dls-audience"),
+	})
+	if err != nil {
+		t.Fatalf("NewReceiveAdapter() error = %v", err)
+	}
+
+	adapter := ra.Spec.Template.Spec.Containers[0]
+	env = envs(adapter)
+	if got := env[sinkauth.CACertsEnv]; got != "sink-cert\ndls-cert\n" {
+		t.Errorf("%s = %q, want certs of sink and dead letter sink", sinkauth.CACertsEnv, got)
+	}
+	if env[sinkauth.AudienceEnv] != "broker-audience" || env["HORIZON_DEAD_LETTER_SINK_AUDIENCE"] != "dls-audience" {
+		t.Errorf("env = %v, want audiences of sink and dead letter sink", env)
+	}
+
+	audiences := make(map[string]string)
+	for _, v := range ra.Spec.Template.Spec.Volumes {
+		if v.Projected != nil {
+			audiences[v.Name] = v.Projected.Sources[0].ServiceAccountToken.Audience
+		}
+	}
+	want := map[string]string{
+		sinkauth.SinkTokenName:          "broker-audience",
+		horizon.DeadLetterSinkTokenName: "dls-audience",
+	}
+	if diff := cmp.Diff(want, audiences); diff != "" {
+		t.Errorf("token volumes (-want, +got) = %s", diff)
+	}
+	for _, m := range adapter.VolumeMounts[1:] {
+		if want := sinkauth.TokenVolumeMount(m.Name); m != want {
+			t.Errorf("volume mount = %v, want %v", m, want)
+		}
+	}
+	if got := len(adapter.VolumeMounts); got != 3 {
+		t.Errorf("volume mounts = %d, want credentials and 2 tokens", got)
+	}
+}
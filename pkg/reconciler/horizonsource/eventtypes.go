@@ -0,0 +1,33 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package horizonsource
+
+import (
+	"context"
+	"fmt"
+
+	"knative.dev/eventing/pkg/apis/eventing/v1beta2"
+
+	"github.com/vmware-tanzu/sources-for-knative/pkg/apis/sources/v1alpha1"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/reconciler/eventtype"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/reconciler/horizonsource/resources"
+)
+
+// reconcileEventTypes registers the event types of the Horizon catalog as
+// EventTypes of the sink broker of src, if any.
+func (r *Reconciler) reconcileEventTypes(ctx context.Context, src *v1alpha1.HorizonSource) error {
+	var desired []*v1beta2.EventType
+
+	if broker := eventtype.Broker(src.Spec.Sink, src.Namespace); broker != nil {
+		var err error
+		desired, err = resources.MakeEventTypes(src, broker)
+		if err != nil {
+			return fmt.Errorf("failed to make eventtypes: %w", err)
+		}
+	}
+
+	return r.eventTypes.Reconcile(ctx, src, desired)
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package horizonsource
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	eventingfake "knative.dev/eventing/pkg/client/clientset/versioned/fake"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	"github.com/vmware-tanzu/sources-for-knative/pkg/apis/sources/v1alpha1"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/horizon"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/reconciler/eventtype"
+)
+
+func TestReconciler_reconcileEventTypes(t *testing.T) {
+	ctx := context.TODO()
+
+	src := &v1alpha1.HorizonSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "horizon-01",
+			Namespace: "default",
+			UID:       "1234",
+		},
+		Spec: v1alpha1.HorizonSourceSpec{
+			SourceSpec: duckv1.SourceSpec{
+				Sink: duckv1.Destination{Ref: &duckv1.KReference{
+					APIVersion: "eventing.knative.dev/v1",
+					Kind:       "Broker",
+					Name:       "default",
+				}},
+			},
+			HorizonAuthSpec: v1alpha1.HorizonAuthSpec{
+				Address:   *apis.HTTPS("horizon-01.example.com"),
+				SecretRef: corev1.LocalObjectReference{Name: "horizon-creds"},
+			},
+		},
+	}
+
+	client := eventingfake.NewSimpleClientset()
+	r := &Reconciler{eventTypes: eventtype.NewReconciler(client)}
+
+	if err := r.reconcileEventTypes(ctx, src); err != nil {
+		t.Fatalf("reconcileEventTypes() error = %v", err)
+	}
+
+	list, err := client.EventingV1beta2().EventTypes("default").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Items) != len(horizon.EventCatalog) {
+		t.Errorf("eventtypes = %d, want %d", len(list.Items), len(horizon.EventCatalog))
+	}
+
+	found := false
+	for _, et := range list.Items {
+		if et.Spec.Schema.String() != horizon.EventSchemaURL {
+			t.Errorf("eventtype %s schema = %s, want %s", et.Spec.Type, et.Spec.Schema, horizon.EventSchemaURL)
+		}
+		if et.Spec.Source.String() != "https://horizon-01.example.com" {
+			t.Errorf("eventtype %s source = %s", et.Spec.Type, et.Spec.Source)
+		}
+		if et.Spec.Description == "" {
+			t.Errorf("eventtype %s has no description", et.Spec.Type)
+		}
+		if et.Spec.Type == "com.vmware.horizon.vlsi_userloggedin.v0" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("eventtype com.vmware.horizon.vlsi_userloggedin.v0 not registered")
+	}
+
+	// EventTypes are removed when the sink is no broker
+	src.Spec.Sink = duckv1.Destination{URI: apis.HTTP("sink.example.com")}
+	if err := r.reconcileEventTypes(ctx, src); err != nil {
+		t.Fatalf("reconcileEventTypes() error = %v", err)
+	}
+	list, err = client.EventingV1beta2().EventTypes("default").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Items) != 0 {
+		t.Errorf("eventtypes = %d without broker, want 0", len(list.Items))
+	}
+}
@@ -12,13 +12,15 @@ import (
 
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	corev1listers "k8s.io/client-go/listers/core/v1"
 	"knative.dev/pkg/metrics"
-
-	// k8s.io imports
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/tracker"
 
 	// knative.dev/pkg imports
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
 	pkgreconciler "knative.dev/pkg/reconciler"
 	"knative.dev/pkg/resolver"
@@ -27,8 +29,11 @@ import (
 	sourcesv1 "knative.dev/eventing/pkg/apis/sources/v1"
 
 	"github.com/vmware-tanzu/sources-for-knative/pkg/apis/sources/v1alpha1"
+	clientset "github.com/vmware-tanzu/sources-for-knative/pkg/client/clientset/versioned"
 	"github.com/vmware-tanzu/sources-for-knative/pkg/client/injection/reconciler/sources/v1alpha1/horizonsource"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/reconciler/eventtype"
 	"github.com/vmware-tanzu/sources-for-knative/pkg/reconciler/horizonsource/resources"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/sinkauth"
 )
 
 const (
@@ -38,12 +43,23 @@ const (
 // Reconciler reconciles a HorizonSource object
 type Reconciler struct {
 	ReceiveAdapterImage string `envconfig:"HORIZON_SOURCE_RA_IMAGE" required:"true"`
+	// probe the Horizon API login of sources to report their AuthReady condition
+	AuthProbe bool `envconfig:"HORIZON_SOURCE_AUTH_PROBE" default:"true"`
+	// register the event types of the Horizon catalog as EventTypes of sink
+	// brokers
+	EventTypes bool `envconfig:"HORIZON_SOURCE_EVENT_TYPES" default:"true"`
 
-	kclient kubernetes.Interface
+	client       clientset.Interface
+	secretLister corev1listers.SecretLister
+	tracker      tracker.Interface
 
 	// reconcilers
 	depl *DeploymentReconciler
 	sa   *ServiceAccountReconciler
+	rb   *RoleBindingReconciler
+	auth *AuthReconciler
+	// disabled if nil
+	eventTypes *eventtype.Reconciler
 
 	loggingContext context.Context
 	loggingConfig  *logging.Config
@@ -55,11 +71,38 @@ type Reconciler struct {
 // Check that our Reconciler implements Interface
 var _ horizonsource.Interface = (*Reconciler)(nil)
 
-// ReconcileKind implements Interface.ReconcileKind.
+// ReconcileKind implements Interface.ReconcileKind. The generated reconciler
+// is configured to skip status updates, since its write back of the status
+// would roll back the delivery status reported by the adapter, see
+// updateStatus.
 func (r *Reconciler) ReconcileKind(ctx context.Context, src *v1alpha1.HorizonSource) pkgreconciler.Event {
+	original := src.DeepCopy()
+
+	pkgreconciler.PreProcessReconcile(ctx, src)
+	event := r.reconcile(ctx, src)
+	pkgreconciler.PostProcessReconcile(ctx, src, original)
+
+	if !equality.Semantic.DeepEqual(original.Status, src.Status) {
+		if err := r.updateStatus(ctx, src); err != nil {
+			return fmt.Errorf("failed to update status: %w", err)
+		}
+	}
+	// check again whether events are flowing once they could lag
+	maxLag := v1alpha1.MaxEventLag(src.Spec.MaxEventLagSeconds)
+	if event == nil {
+		return controller.NewRequeueAfter(maxLag)
+	}
+	if requeue, after := controller.IsRequeueKey(event); requeue && after > maxLag {
+		return controller.NewRequeueAfter(maxLag)
+	}
+	return event
+}
+
+func (r *Reconciler) reconcile(ctx context.Context, src *v1alpha1.HorizonSource) pkgreconciler.Event {
 	ctx = sourcesv1.WithURIResolver(ctx, r.sinkResolver)
 
 	src.Status.InitializeConditions()
+	src.Status.PropagateEventsFlowing(src.Spec.MaxEventLagSeconds)
 
 	if err := src.Spec.Sink.Validate(ctx); err != nil {
 		src.Status.MarkNoSink("SinkMissing", "")
@@ -72,19 +115,67 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, src *v1alpha1.HorizonSou
 			dest.Ref.Namespace = src.GetNamespace()
 		}
 	}
-	sinkURI, err := r.sinkResolver.URIFromDestinationV1(ctx, *dest, src)
+	sink, err := r.sinkResolver.AddressableFromDestinationV1(ctx, *dest, src)
 	if err != nil {
 		src.Status.MarkNoSink("NotFound", "")
 		return fmt.Errorf("getting sink URI: %v", err)
 	}
-	src.Status.MarkSink(sinkURI)
+	src.Status.MarkSinkAddress(sink)
+
+	var (
+		deadLetterSinkURI string
+		deadLetterSink    = &duckv1.Addressable{}
+	)
+	if src.Spec.Delivery != nil && src.Spec.Delivery.DeadLetterSink != nil {
+		dls := src.Spec.Delivery.DeadLetterSink.DeepCopy()
+		if dls.Ref != nil && dls.Ref.Namespace == "" {
+			dls.Ref.Namespace = src.GetNamespace()
+		}
+		deadLetterSink, err = r.sinkResolver.AddressableFromDestinationV1(ctx, *dls, src)
+		if err != nil {
+			src.Status.MarkNoDeadLetterSink("NotFound", "Dead letter sink could not be resolved: %v", err)
+			return fmt.Errorf("getting dead letter sink URI: %v", err)
+		}
+		deadLetterSinkURI = deadLetterSink.URL.String()
+		src.Status.MarkDeadLetterSink(deadLetterSink.URL)
+	} else {
+		src.Status.MarkDeadLetterSinkNotConfigured()
+	}
 
-	_, err = r.kclient.CoreV1().Secrets(src.Namespace).Get(ctx, src.Spec.SecretRef.Name, metav1.GetOptions{})
+	// track the secret to reconcile again once it is created or changed
+	secretRef := tracker.Reference{
+		APIVersion: "v1",
+		Kind:       "Secret",
+		Namespace:  src.Namespace,
+		Name:       src.Spec.SecretRef.Name,
+	}
+	if err = r.tracker.TrackReference(secretRef, src); err != nil {
+		return fmt.Errorf("tracking secret %q: %w", src.Spec.SecretRef.Name, err)
+	}
+
+	secret, err := r.secretLister.Secrets(src.Namespace).Get(src.Spec.SecretRef.Name)
 	if err != nil {
+		if apierrors.IsNotFound(err) {
+			src.Status.MarkSecretNotReady("SecretNotFound", "Secret %q does not exist", src.Spec.SecretRef.Name)
+		}
 		logging.FromContext(ctx).Errorw("returning because required secret not found", zap.String("secret", src.Spec.SecretRef.Name), zap.Error(err))
 		return err
 	}
 
+	creds, err := credentialsFromSecret(secret)
+	if err != nil {
+		src.Status.MarkSecretNotReady("SecretKeyMissing", "%v", err)
+		logging.FromContext(ctx).Errorw("returning because required secret is incomplete", zap.String("secret", src.Spec.SecretRef.Name), zap.Error(err))
+		return err
+	}
+	src.Status.MarkSecretReady()
+
+	// returned unless reconciling the other resources fails
+	var authRequeue error
+	if r.auth != nil {
+		authRequeue = r.auth.ReconcileAuth(ctx, src, secret, creds)
+	}
+
 	labels := resources.Labels(src.Name)
 
 	// create serviceAccount
@@ -94,6 +185,13 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, src *v1alpha1.HorizonSou
 		return err
 	}
 
+	// allow the adapter to report its delivery status
+	_, err = r.rb.ReconcileRoleBinding(ctx, src, labels)
+	if err != nil {
+		logging.FromContext(ctx).Errorw("returning because of event from ReconcileRoleBinding", zap.Error(err))
+		return err
+	}
+
 	loggingConfig, err := logging.ConfigToJSON(r.loggingConfig)
 	if err != nil {
 		logging.FromContext(ctx).Error("returning because cannot convert logging config to JSON", zap.Error(err))
@@ -108,12 +206,17 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, src *v1alpha1.HorizonSou
 
 	// create adapter
 	args := resources.ReceiveAdapterArgs{
-		Image:         r.ReceiveAdapterImage,
-		Labels:        labels,
-		Source:        src,
-		SinkURI:       sinkURI.String(),
-		LoggingConfig: loggingConfig,
-		MetricsConfig: metricsConfig,
+		Image:             r.ReceiveAdapterImage,
+		Labels:            labels,
+		Source:            src,
+		SinkURI:           sink.URL.String(),
+		DeadLetterSinkURI: deadLetterSinkURI,
+		LoggingConfig:     loggingConfig,
+		MetricsConfig:     metricsConfig,
+
+		CACerts:                sinkauth.JoinCACerts(sink.CACerts, deadLetterSink.CACerts),
+		SinkAudience:           sink.Audience,
+		DeadLetterSinkAudience: deadLetterSink.Audience,
 	}
 	adapter, err := resources.NewReceiveAdapter(ctx, &args)
 	if err != nil {
@@ -126,12 +229,18 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, src *v1alpha1.HorizonSou
 		src.Status.PropagateDeploymentAvailability(ra)
 	}
 
+	if src.Spec.Suspend {
+		src.Status.MarkSuspended()
+	} else {
+		src.Status.ClearSuspended()
+	}
+
 	if err != nil {
 		// ignore normal reconcile events
 		var reconcileErr *pkgreconciler.ReconcilerEvent
 		if errors.As(err, &reconcileErr) {
 			if reconcileErr.EventType == corev1.EventTypeNormal {
-				return nil
+				return authRequeue
 			}
 			logging.FromContext(ctx).Errorw("returning because of non-normal event from ReconcileDeployment", zap.Error(err))
 			return err
@@ -141,7 +250,18 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, src *v1alpha1.HorizonSou
 		return err
 	}
 
-	return nil
+	if r.eventTypes != nil {
+		// registering event types is best-effort and never fails the
+		// reconciliation of the source
+		if err := r.reconcileEventTypes(ctx, src); err != nil {
+			logging.FromContext(ctx).Warnw("failed to register event types", zap.Error(err))
+			src.Status.MarkEventTypesNotRegistered("RegistrationFailed", "%v", err)
+		} else {
+			src.Status.MarkEventTypesRegistered()
+		}
+	}
+
+	return authRequeue
 }
 
 func (r *Reconciler) UpdateFromLoggingConfigMap(cfg *corev1.ConfigMap) {
@@ -9,6 +9,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"time"
 
 	v1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -19,17 +21,26 @@ import (
 	"github.com/vmware-tanzu/sources-for-knative/pkg/apis/sources/v1alpha1"
 	"github.com/vmware-tanzu/sources-for-knative/pkg/horizon"
 	"github.com/vmware-tanzu/sources-for-knative/pkg/reconciler/horizonsource/resources/names"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/sinkauth"
 )
 
 // ReceiveAdapterArgs are the arguments needed to create a Horizon source Receive Adapter.
-// Every field is required.
+// Every field except DeadLetterSinkURI, CACerts and the audiences is required.
 type ReceiveAdapterArgs struct {
-	Image         string
-	Labels        map[string]string
-	Source        *v1alpha1.HorizonSource
-	SinkURI       string
-	LoggingConfig string
-	MetricsConfig string
+	Image             string
+	Labels            map[string]string
+	Source            *v1alpha1.HorizonSource
+	SinkURI           string
+	DeadLetterSinkURI string
+	LoggingConfig     string
+	MetricsConfig     string
+
+	// CACerts are the CA certificates of the sink and dead letter sink
+	CACerts *string
+	// SinkAudience and DeadLetterSinkAudience are the OIDC audiences of the
+	// sink and dead letter sink
+	SinkAudience           *string
+	DeadLetterSinkAudience *string
 }
 
 // NewReceiveAdapter generates the Receive Adapter Deployment for Horizon
@@ -40,6 +51,42 @@ func NewReceiveAdapter(ctx context.Context, args *ReceiveAdapterArgs) (*v1.Deplo
 		return nil, err
 	}
 
+	// a suspended source runs no adapter
+	var replicas int32 = 1
+	if args.Source.Spec.Suspend {
+		replicas = 0
+	}
+
+	volumes := []corev1.Volume{
+		{
+			Name: args.Source.Spec.SecretRef.Name,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: args.Source.Spec.SecretRef.Name,
+					// set explicitly to compare with the defaulted volume
+					DefaultMode: ptr.Int32(corev1.SecretVolumeSourceDefaultMode),
+				},
+			},
+		},
+	}
+	volumeMounts := []corev1.VolumeMount{
+		{
+			Name:      args.Source.Spec.SecretRef.Name,
+			ReadOnly:  true,
+			MountPath: horizon.DefaultSecretMountPath,
+		},
+	}
+
+	// tokens for sinks requiring OIDC authentication
+	if a := args.SinkAudience; a != nil && *a != "" {
+		volumes = append(volumes, sinkauth.TokenVolume(sinkauth.SinkTokenName, *a))
+		volumeMounts = append(volumeMounts, sinkauth.TokenVolumeMount(sinkauth.SinkTokenName))
+	}
+	if a := args.DeadLetterSinkAudience; a != nil && *a != "" && args.DeadLetterSinkURI != "" {
+		volumes = append(volumes, sinkauth.TokenVolume(horizon.DeadLetterSinkTokenName, *a))
+		volumeMounts = append(volumeMounts, sinkauth.TokenVolumeMount(horizon.DeadLetterSinkTokenName))
+	}
+
 	return &v1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: args.Source.Namespace,
@@ -53,7 +100,7 @@ func NewReceiveAdapter(ctx context.Context, args *ReceiveAdapterArgs) (*v1.Deplo
 			Selector: &metav1.LabelSelector{
 				MatchLabels: args.Labels,
 			},
-			Replicas: ptr.Int32(1),
+			Replicas: ptr.Int32(replicas),
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: args.Labels,
@@ -67,25 +114,10 @@ func NewReceiveAdapter(ctx context.Context, args *ReceiveAdapterArgs) (*v1.Deplo
 							Env:   env,
 							// TODO (@mgasch): add resources
 							// Resources:                corev1.ResourceRequirements{},,
-							VolumeMounts: []corev1.VolumeMount{
-								{
-									Name:      args.Source.Spec.SecretRef.Name,
-									ReadOnly:  true,
-									MountPath: horizon.DefaultSecretMountPath,
-								},
-							},
-						},
-					},
-					Volumes: []corev1.Volume{
-						{
-							Name: args.Source.Spec.SecretRef.Name,
-							VolumeSource: corev1.VolumeSource{
-								Secret: &corev1.SecretVolumeSource{
-									SecretName: args.Source.Spec.SecretRef.Name,
-								},
-							},
+							VolumeMounts: volumeMounts,
 						},
 					},
+					Volumes: volumes,
 				},
 			},
 			Strategy: v1.DeploymentStrategy{
@@ -98,6 +130,11 @@ func NewReceiveAdapter(ctx context.Context, args *ReceiveAdapterArgs) (*v1.Deplo
 	}, nil
 }
 
+// seconds returns the duration string of the given seconds
+func seconds(s int64) string {
+	return (time.Duration(s) * time.Second).String()
+}
+
 func makeEnv(ctx context.Context, args *ReceiveAdapterArgs) ([]corev1.EnvVar, error) {
 	var ceOverrides string
 	if args.Source.Spec.CloudEventOverrides != nil {
@@ -109,7 +146,44 @@ func makeEnv(ctx context.Context, args *ReceiveAdapterArgs) ([]corev1.EnvVar, er
 		}
 	}
 
-	return []corev1.EnvVar{
+	var eventFilter string
+	if f := args.Source.Spec.Filter; f != nil {
+		b, err := json.Marshal(horizon.EventFilter{
+			Types:                f.Types,
+			Severities:           f.Severities,
+			Modules:              f.Modules,
+			DesktopPoolNames:     f.DesktopPoolNames,
+			ApplicationPoolNames: f.ApplicationPoolNames,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal event filter into JSON for %+v: %w", args.Source, err)
+		}
+		eventFilter = string(b)
+	}
+
+	var ceMapping string
+	if m := args.Source.Spec.CloudEventMapping; m != nil {
+		b, err := json.Marshal(m.Config())
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal CloudEvent mapping into JSON for %+v: %w", args.Source, err)
+		}
+		ceMapping = string(b)
+	}
+
+	retries := horizon.DefaultDeliveryRetries
+	if d := args.Source.Spec.Delivery; d != nil && d.Retry != nil {
+		retries = int(*d.Retry)
+	}
+
+	// unset values, e.g. of objects created before polling was defaulted,
+	// fall back to the adapter defaults
+	polling := args.Source.Spec.Polling
+	sendRetries := horizon.DefaultSendRetries
+	if polling.SendRetries != nil {
+		sendRetries = int(*polling.SendRetries)
+	}
+
+	env := []corev1.EnvVar{
 		{
 			Name:  "HORIZON_URL",
 			Value: args.Source.Spec.Address.String(),
@@ -118,6 +192,38 @@ func makeEnv(ctx context.Context, args *ReceiveAdapterArgs) ([]corev1.EnvVar, er
 			Name:  "HORIZON_INSECURE",
 			Value: fmt.Sprintf("%t", args.Source.Spec.SkipTLSVerify),
 		},
+		{
+			Name:  "HORIZON_EVENT_FILTER",
+			Value: eventFilter,
+		},
+		{
+			Name:  "HORIZON_CE_MAPPING",
+			Value: ceMapping,
+		},
+		{
+			Name:  "HORIZON_DEAD_LETTER_SINK",
+			Value: args.DeadLetterSinkURI,
+		},
+		{
+			Name:  "HORIZON_DELIVERY_RETRIES",
+			Value: strconv.Itoa(retries),
+		},
+		{
+			Name:  "HORIZON_POLL_INTERVAL",
+			Value: seconds(polling.IntervalSeconds),
+		},
+		{
+			Name:  "HORIZON_POLL_MIN_BACKOFF",
+			Value: seconds(polling.MinBackoffSeconds),
+		},
+		{
+			Name:  "HORIZON_POLL_MAX_BACKOFF",
+			Value: seconds(polling.MaxBackoffSeconds),
+		},
+		{
+			Name:  "HORIZON_SEND_RETRIES",
+			Value: strconv.Itoa(sendRetries),
+		},
 		{
 			Name:  "METRICS_DOMAIN",
 			Value: "knative.dev/eventing",
@@ -150,5 +256,18 @@ func makeEnv(ctx context.Context, args *ReceiveAdapterArgs) ([]corev1.EnvVar, er
 			Name:  "K_METRICS_CONFIG",
 			Value: args.MetricsConfig,
 		},
-	}, nil
+	}
+
+	// only set for TLS and OIDC sinks to leave the adapters of other sources
+	// unchanged
+	if c := args.CACerts; c != nil {
+		env = append(env, corev1.EnvVar{Name: sinkauth.CACertsEnv, Value: *c})
+	}
+	if a := args.SinkAudience; a != nil && *a != "" {
+		env = append(env, corev1.EnvVar{Name: sinkauth.AudienceEnv, Value: *a})
+	}
+	if a := args.DeadLetterSinkAudience; a != nil && *a != "" && args.DeadLetterSinkURI != "" {
+		env = append(env, corev1.EnvVar{Name: "HORIZON_DEAD_LETTER_SINK_AUDIENCE", Value: *a})
+	}
+	return env, nil
 }
@@ -0,0 +1,63 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resources
+
+import (
+	"fmt"
+
+	"knative.dev/eventing/pkg/apis/eventing/v1beta2"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	"github.com/vmware-tanzu/sources-for-knative/pkg/apis/sources/v1alpha1"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/cemapping"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/horizon"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/reconciler/eventtype"
+)
+
+// MakeEventTypes returns the EventTypes of the Horizon event catalog for the
+// broker, with the CloudEvent type and source of the mapping of src and the
+// schema of the event data. Catalog entries whose type cannot be mapped
+// without an event, e.g. because the type template uses the machine, are
+// skipped.
+func MakeEventTypes(src *v1alpha1.HorizonSource, broker *duckv1.KReference) ([]*v1beta2.EventType, error) {
+	mapper, err := cemapping.New(src.Spec.CloudEventMapping.Config(), horizon.DefaultCloudEventMapping)
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := mapper.Source(cemapping.SourceData{Address: src.Spec.Address.String()})
+	if err != nil {
+		return nil, err
+	}
+	sourceURL, err := apis.ParseURL(source)
+	if err != nil {
+		return nil, fmt.Errorf("parse CloudEvent source %q: %w", source, err)
+	}
+	schemaURL, err := apis.ParseURL(horizon.EventSchemaURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse event schema URL: %w", err)
+	}
+
+	seen := make(map[string]bool, len(horizon.EventCatalog))
+	eventTypes := make([]*v1beta2.EventType, 0, len(horizon.EventCatalog))
+	for _, info := range horizon.EventCatalog {
+		ceType, err := mapper.Type(info.EventData())
+		if err != nil || seen[ceType] {
+			continue
+		}
+		seen[ceType] = true
+
+		eventTypes = append(eventTypes, eventtype.Make(src, v1beta2.EventTypeSpec{
+			Type:        ceType,
+			Source:      sourceURL,
+			Schema:      schemaURL,
+			Reference:   broker,
+			Description: info.Description,
+		}))
+	}
+	return eventTypes, nil
+}
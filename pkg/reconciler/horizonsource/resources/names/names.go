@@ -15,3 +15,7 @@ import (
 func NewAdapterName(source string) string {
 	return kmeta.ChildName(source, "-adapter")
 }
+
+func NewRoleBindingName(source string) string {
+	return kmeta.ChildName(source, "-adapter")
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package horizonsource
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	rbacv1listers "k8s.io/client-go/listers/rbac/v1"
+	"knative.dev/pkg/logging"
+	pkgreconciler "knative.dev/pkg/reconciler"
+
+	"github.com/vmware-tanzu/sources-for-knative/pkg/apis/sources/v1alpha1"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/reconciler/horizonsource/resources"
+)
+
+// newRoleBindingCreated makes a reconciler event with event type Normal, and
+// reason RoleBindingCreated.
+func newRoleBindingCreated(namespace, name string) pkgreconciler.Event {
+	return pkgreconciler.NewEvent(corev1.EventTypeNormal, "RoleBindingCreated", "created role binding: \"%s/%s\"", namespace, name)
+}
+
+// newRoleBindingFailed makes a reconciler event with event type Warning, and
+// reason RoleBindingFailed.
+func newRoleBindingFailed(namespace, name string, err error) pkgreconciler.Event {
+	return pkgreconciler.NewEvent(corev1.EventTypeWarning, "RoleBindingFailed", "failed to create role binding: \"%s/%s\", %w", namespace, name, err)
+}
+
+type RoleBindingReconciler struct {
+	KubeClientSet kubernetes.Interface
+	Lister        rbacv1listers.RoleBindingLister
+}
+
+// ReconcileRoleBinding reconciles the role binding which allows the adapter of
+// a HorizonSource to report its delivery status
+func (r *RoleBindingReconciler) ReconcileRoleBinding(ctx context.Context, src *v1alpha1.HorizonSource, labels map[string]string) (*rbacv1.RoleBinding, pkgreconciler.Event) {
+	expected := resources.NewRoleBinding(src, labels)
+
+	rb, err := r.Lister.RoleBindings(src.Namespace).Get(expected.Name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			rb, err = r.KubeClientSet.RbacV1().RoleBindings(src.Namespace).Create(ctx, expected, metav1.CreateOptions{})
+			if err != nil {
+				return nil, newRoleBindingFailed(src.Namespace, expected.Name, err)
+			}
+			return rb, newRoleBindingCreated(rb.Namespace, rb.Name)
+		}
+		return nil, fmt.Errorf("error getting role binding %q: %v", expected.Name, err)
+	}
+
+	if !equality.Semantic.DeepEqual(rb.RoleRef, expected.RoleRef) {
+		// the roleRef of a role binding cannot be updated, so recreate it
+		err = r.KubeClientSet.RbacV1().RoleBindings(src.Namespace).Delete(ctx, expected.Name, metav1.DeleteOptions{
+			Preconditions: metav1.NewUIDPreconditions(string(rb.UID)),
+		})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("error deleting role binding %q: %v", expected.Name, err)
+		}
+		rb, err = r.KubeClientSet.RbacV1().RoleBindings(src.Namespace).Create(ctx, expected, metav1.CreateOptions{})
+		if err != nil {
+			return nil, newRoleBindingFailed(src.Namespace, expected.Name, err)
+		}
+		logging.FromContext(ctx).Infof("Recreated role binding %q", expected.Name)
+	} else if !equality.Semantic.DeepEqual(rb.Subjects, expected.Subjects) {
+		// replacing the subjects also removes the service account used before
+		// spec.serviceAccountName changed
+		rb = rb.DeepCopy()
+		rb.Subjects = expected.Subjects
+		rb, err = r.KubeClientSet.RbacV1().RoleBindings(src.Namespace).Update(ctx, rb, metav1.UpdateOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("error updating role binding %q: %v", expected.Name, err)
+		}
+		logging.FromContext(ctx).Infof("Updated role binding %q", expected.Name)
+	}
+	return rb, nil
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package horizonsource
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	rbacv1listers "k8s.io/client-go/listers/rbac/v1"
+	"k8s.io/client-go/tools/cache"
+	pkgreconciler "knative.dev/pkg/reconciler"
+
+	"github.com/vmware-tanzu/sources-for-knative/pkg/apis/sources/v1alpha1"
+)
+
+func TestRoleBindingReconciler_ReconcileRoleBinding(t *testing.T) {
+	ctx := context.TODO()
+	src := &v1alpha1.HorizonSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "horizon-01",
+			Namespace: "default",
+		},
+		Spec: v1alpha1.HorizonSourceSpec{
+			ServiceAccountName: "horizon-sa",
+		},
+	}
+
+	kubeclient := kubefake.NewSimpleClientset()
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	r := &RoleBindingReconciler{
+		KubeClientSet: kubeclient,
+		Lister:        rbacv1listers.NewRoleBindingLister(indexer),
+	}
+
+	// sync mimics the informer catching up with the changes of the client
+	sync := func() {
+		t.Helper()
+		list, err := kubeclient.RbacV1().RoleBindings(src.Namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		items := make([]interface{}, 0, len(list.Items))
+		for i := range list.Items {
+			items = append(items, &list.Items[i])
+		}
+		if err := indexer.Replace(items, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rb, err := r.ReconcileRoleBinding(ctx, src, nil)
+	var event *pkgreconciler.ReconcilerEvent
+	if !errors.As(err, &event) || event.EventType != corev1.EventTypeNormal || event.Reason != "RoleBindingCreated" {
+		t.Fatalf("ReconcileRoleBinding() error = %v, want RoleBindingCreated event", err)
+	}
+	if rb.Name != "horizon-01-adapter" {
+		t.Errorf("ReconcileRoleBinding() name = %q, want %q", rb.Name, "horizon-01-adapter")
+	}
+	if got := rb.Subjects[0].Name; got != "horizon-sa" {
+		t.Errorf("ReconcileRoleBinding() subject = %q, want %q", got, "horizon-sa")
+	}
+	if got := rb.RoleRef.Name; got != "horizon-source-adapter" {
+		t.Errorf("ReconcileRoleBinding() role = %q, want %q", got, "horizon-source-adapter")
+	}
+
+	// existing role binding is returned without event
+	sync()
+	if _, err = r.ReconcileRoleBinding(ctx, src, nil); err != nil {
+		t.Errorf("ReconcileRoleBinding() error = %v, want nil", err)
+	}
+
+	// the subjects follow spec.serviceAccountName
+	src.Spec.ServiceAccountName = "other-sa"
+	sync()
+	if rb, err = r.ReconcileRoleBinding(ctx, src, nil); err != nil {
+		t.Fatalf("ReconcileRoleBinding() error = %v, want nil", err)
+	}
+	if got := rb.Subjects; len(got) != 1 || got[0].Name != "other-sa" {
+		t.Errorf("ReconcileRoleBinding() subjects = %v, want only %q", got, "other-sa")
+	}
+
+	// a changed roleRef is recreated since it is immutable
+	rb = rb.DeepCopy()
+	rb.RoleRef.Name = "other-role"
+	if _, err = r.KubeClientSet.RbacV1().RoleBindings(src.Namespace).Update(ctx, rb, metav1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	sync()
+	if rb, err = r.ReconcileRoleBinding(ctx, src, nil); err != nil {
+		t.Fatalf("ReconcileRoleBinding() error = %v, want nil", err)
+	}
+	if got := rb.RoleRef.Name; got != "horizon-source-adapter" {
+		t.Errorf("ReconcileRoleBinding() role = %q, want %q", got, "horizon-source-adapter")
+	}
+}
@@ -13,6 +13,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
+	corev1listers "k8s.io/client-go/listers/core/v1"
 	pkgreconciler "knative.dev/pkg/reconciler"
 
 	"github.com/vmware-tanzu/sources-for-knative/pkg/apis/sources/v1alpha1"
@@ -33,6 +34,7 @@ func newServiceAccountFailed(namespace, name string, err error) pkgreconciler.Ev
 
 type ServiceAccountReconciler struct {
 	KubeClientSet kubernetes.Interface
+	Lister        corev1listers.ServiceAccountLister
 }
 
 // ReconcileServiceAccount reconciles service account resource for HorizonSource
@@ -40,7 +42,7 @@ func (s *ServiceAccountReconciler) ReconcileServiceAccount(ctx context.Context,
 	namespace := src.Namespace
 	saName := src.Spec.ServiceAccountName
 
-	sa, err := s.KubeClientSet.CoreV1().ServiceAccounts(namespace).Get(ctx, saName, metav1.GetOptions{})
+	sa, err := s.Lister.ServiceAccounts(namespace).Get(saName)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			sa, err = s.KubeClientSet.CoreV1().ServiceAccounts(namespace).Create(ctx, resources.NewServiceAccount(src, labels), metav1.CreateOptions{})
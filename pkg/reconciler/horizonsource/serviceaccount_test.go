@@ -7,12 +7,17 @@ package horizonsource
 
 import (
 	"context"
+	"errors"
 	"reflect"
 	"testing"
 
 	"github.com/vmware-tanzu/sources-for-knative/pkg/apis/sources/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
 	pkgreconciler "knative.dev/pkg/reconciler"
 )
 
@@ -92,3 +97,36 @@ func TestServiceAccountReconciler_ReconcileServiceAccount(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceAccountReconciler_ReconcileServiceAccountFromLister(t *testing.T) {
+	ctx := context.TODO()
+	src := &v1alpha1.HorizonSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "horizon-01",
+			Namespace: "default",
+		},
+		Spec: v1alpha1.HorizonSourceSpec{
+			ServiceAccountName: "horizon-sa",
+		},
+	}
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	s := &ServiceAccountReconciler{
+		KubeClientSet: kubefake.NewSimpleClientset(),
+		Lister:        corev1listers.NewServiceAccountLister(indexer),
+	}
+
+	sa, err := s.ReconcileServiceAccount(ctx, src, nil)
+	var event *pkgreconciler.ReconcilerEvent
+	if !errors.As(err, &event) || event.Reason != "ServiceAccountCreated" {
+		t.Fatalf("ReconcileServiceAccount() error = %v, want ServiceAccountCreated event", err)
+	}
+
+	// cached service account is returned without event
+	if err = indexer.Add(sa); err != nil {
+		t.Fatalf("add to indexer: %v", err)
+	}
+	if _, err = s.ReconcileServiceAccount(ctx, src, nil); err != nil {
+		t.Errorf("ReconcileServiceAccount() error = %v, want nil", err)
+	}
+}
@@ -0,0 +1,41 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package horizonsource
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	pkgreconciler "knative.dev/pkg/reconciler"
+
+	"github.com/vmware-tanzu/sources-for-knative/pkg/apis/sources/v1alpha1"
+)
+
+// updateStatus writes the status of desired. The delivery status reported by
+// the adapter is taken from a fresh read of the source in every attempt, so
+// that it is never rolled back by the copy the reconciler worked on.
+func (r *Reconciler) updateStatus(ctx context.Context, desired *v1alpha1.HorizonSource) error {
+	client := r.client.SourcesV1alpha1().HorizonSources(desired.Namespace)
+
+	return pkgreconciler.RetryUpdateConflicts(func(int) error {
+		existing, err := client.Get(ctx, desired.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		status := desired.Status.DeepCopy()
+		status.CopyAdapterStatus(&existing.Status)
+		status.PropagateEventsFlowing(desired.Spec.MaxEventLagSeconds)
+		if equality.Semantic.DeepEqual(existing.Status, *status) {
+			return nil
+		}
+
+		existing.Status = *status
+		_, err = client.UpdateStatus(ctx, existing, metav1.UpdateOptions{})
+		return err
+	})
+}
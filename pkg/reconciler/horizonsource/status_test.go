@@ -0,0 +1,89 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package horizonsource
+
+import (
+	"context"
+	"testing"
+
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgotesting "k8s.io/client-go/testing"
+
+	"github.com/vmware-tanzu/sources-for-knative/pkg/apis/sources/v1alpha1"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/client/clientset/versioned/fake"
+)
+
+func TestReconciler_updateStatus(t *testing.T) {
+	ctx := context.TODO()
+
+	stored := &v1alpha1.HorizonSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "horizon-01",
+			Namespace: "default",
+		},
+	}
+	stored.Status.EventsSentTotal = 1
+	stored.Status.LastEventID = "1"
+	client := fake.NewSimpleClientset(stored)
+	r := &Reconciler{client: client}
+
+	// the reconciler works on the copy it read before the adapter reports
+	desired := stored.DeepCopy()
+	desired.Status.InitializeConditions()
+	desired.Status.MarkSecretReady()
+
+	// the adapter reports a failed delivery between the read of updateStatus
+	// and its update, which fails with a conflict as on the API server
+	raced := false
+	client.PrependReactor("update", "horizonsources", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "status" || raced {
+			return false, nil, nil
+		}
+		raced = true
+
+		gvr := v1alpha1.SchemeGroupVersion.WithResource("horizonsources")
+		obj, err := client.Tracker().Get(gvr, stored.Namespace, stored.Name)
+		if err != nil {
+			return true, nil, err
+		}
+		reported := obj.(*v1alpha1.HorizonSource).DeepCopy()
+		reported.Status.EventsSentTotal = 2
+		reported.Status.LastEventID = "2"
+		reported.Status.LastEventPosition = &v1alpha1.HorizonEventPosition{Time: 2000, EventIDs: []int64{2}}
+		reported.Status.LastError = "sink unavailable"
+		if err := client.Tracker().Update(gvr, reported, stored.Namespace); err != nil {
+			return true, nil, err
+		}
+		return true, nil, apierrs.NewConflict(schema.GroupResource{Resource: "horizonsources"}, stored.Name, nil)
+	})
+
+	if err := r.updateStatus(ctx, desired); err != nil {
+		t.Fatalf("updateStatus() error = %v", err)
+	}
+	if !raced {
+		t.Fatal("adapter report did not race the status update")
+	}
+
+	got, err := client.SourcesV1alpha1().HorizonSources(stored.Namespace).Get(ctx, stored.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status.EventsSentTotal != 2 || got.Status.LastEventID != "2" || got.Status.LastError != "sink unavailable" {
+		t.Errorf("delivery status = %+v, %q, want status reported by adapter", got.Status.EventDeliveryStatus, got.Status.LastEventID)
+	}
+	if pos := got.Status.LastEventPosition; pos == nil || pos.Time != 2000 {
+		t.Errorf("position = %+v, want position reported by adapter", pos)
+	}
+	if c := got.Status.GetCondition(v1alpha1.HorizonSourceConditionEventsFlowing); c == nil || !c.IsFalse() {
+		t.Errorf("EventsFlowing condition = %v, want False", c)
+	}
+	if c := got.Status.GetCondition(v1alpha1.HorizonSourceConditionSecretReady); c == nil || !c.IsTrue() {
+		t.Errorf("SecretReady condition = %v, want True", c)
+	}
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2020 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vsphereactionsink
+
+import (
+	"context"
+
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/metrics"
+
+	"github.com/kelseyhightower/envconfig"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	deploymentinformer "knative.dev/pkg/client/injection/kube/informers/apps/v1/deployment"
+	serviceinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/service"
+	sainformer "knative.dev/pkg/client/injection/kube/informers/core/v1/serviceaccount"
+
+	"github.com/vmware-tanzu/sources-for-knative/pkg/apis/sources/v1alpha1"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/client/injection/client"
+	actionsinkinformer "github.com/vmware-tanzu/sources-for-knative/pkg/client/injection/informers/sources/v1alpha1/vsphereactionsink"
+	vspherebindinginformer "github.com/vmware-tanzu/sources-for-knative/pkg/client/injection/informers/sources/v1alpha1/vspherebinding"
+	actionsinkreconciler "github.com/vmware-tanzu/sources-for-knative/pkg/client/injection/reconciler/sources/v1alpha1/vsphereactionsink"
+)
+
+type envConfig struct {
+	VSphereActionSink string `envconfig:"VSPHERE_ACTION_SINK" required:"true"`
+}
+
+// NewController creates a Reconciler and returns the result of NewImpl.
+func NewController(
+	ctx context.Context,
+	cmw configmap.Watcher,
+) *controller.Impl {
+	logger := logging.FromContext(ctx)
+
+	actionsinkInformer := actionsinkinformer.Get(ctx)
+	deploymentInformer := deploymentinformer.Get(ctx)
+	serviceInformer := serviceinformer.Get(ctx)
+	vspherebindingInformer := vspherebindinginformer.Get(ctx)
+	saInformer := sainformer.Get(ctx)
+
+	var env envConfig
+	if err := envconfig.Process("", &env); err != nil {
+		logger.Fatalf("Unable to read environment config: %v", err)
+	}
+
+	r := &Reconciler{
+		kubeclient:           kubeclient.Get(ctx),
+		client:               client.Get(ctx),
+		deploymentLister:     deploymentInformer.Lister(),
+		serviceLister:        serviceInformer.Lister(),
+		vspherebindingLister: vspherebindingInformer.Lister(),
+		saLister:             saInformer.Lister(),
+		image:                env.VSphereActionSink,
+		loggingContext:       ctx,
+	}
+	impl := actionsinkreconciler.NewImpl(ctx, r)
+
+	logger.Info("Setting up event handlers.")
+
+	actionsinkInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
+
+	deploymentInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.FilterControllerGK(v1alpha1.Kind("VSphereActionSink")),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
+
+	serviceInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.FilterControllerGK(v1alpha1.Kind("VSphereActionSink")),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
+
+	vspherebindingInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.FilterControllerGK(v1alpha1.Kind("VSphereActionSink")),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
+
+	cmw.Watch(logging.ConfigMapName(), r.UpdateFromLoggingConfigMap)
+	cmw.Watch(metrics.ConfigMapName(), r.UpdateFromMetricsConfigMap)
+
+	return impl
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vsphereactionsink
+
+import (
+	"context"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	sourcesv1alpha1 "github.com/vmware-tanzu/sources-for-knative/pkg/apis/sources/v1alpha1"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/reconciler/vsphereactionsink/resources"
+)
+
+// deploymentDrifted returns whether the fields of existing that
+// resources.MakeDeployment sets differ from desired. The volume, volume mounts
+// and environment variables injected by the VSphereBinding of as and fields
+// defaulted by the API server are ignored.
+func deploymentDrifted(ctx context.Context, as *sourcesv1alpha1.VSphereActionSink, desired, existing *appsv1.Deployment) bool {
+	if !equality.Semantic.DeepEqual(desired.Spec.Replicas, existing.Spec.Replicas) {
+		return true
+	}
+
+	ps := &duckv1.WithPod{
+		Spec: duckv1.WithPodSpec{Template: duckv1.PodSpecable(*existing.Spec.Template.DeepCopy())},
+	}
+	resources.MakeVSphereBinding(ctx, as).Undo(ctx, ps)
+
+	want, got := desired.Spec.Template, ps.Spec.Template
+	if !equality.Semantic.DeepEqual(want.Labels, got.Labels) ||
+		want.Spec.ServiceAccountName != got.Spec.ServiceAccountName ||
+		len(want.Spec.Containers) != len(got.Spec.Containers) {
+		return true
+	}
+
+	for i, wc := range want.Spec.Containers {
+		gc := got.Spec.Containers[i]
+		if wc.Name != gc.Name || wc.Image != gc.Image ||
+			!equality.Semantic.DeepEqual(wc.Ports, gc.Ports) ||
+			!equality.Semantic.DeepEqual(wc.Env, gc.Env) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// serviceDrifted returns whether the ports or the selector of existing differ
+// from desired. Other fields, e.g. the cluster IP, are immutable or defaulted
+// by the API server.
+func serviceDrifted(desired, existing *corev1.Service) bool {
+	return !equality.Semantic.DeepEqual(desired.Spec.Ports, existing.Spec.Ports) ||
+		!equality.Semantic.DeepEqual(desired.Spec.Selector, existing.Spec.Selector)
+}
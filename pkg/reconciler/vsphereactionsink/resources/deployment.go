@@ -0,0 +1,92 @@
+/*
+Copyright 2020 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/ptr"
+
+	"github.com/vmware-tanzu/sources-for-knative/pkg/apis/sources/v1alpha1"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/reconciler/vsphereactionsink/resources/names"
+)
+
+const containerPort = 8080
+
+type ActionSinkArgs struct {
+	Image         string
+	LoggingConfig string
+	MetricsConfig string
+}
+
+func labels(as *v1alpha1.VSphereActionSink) map[string]string {
+	return map[string]string{
+		"vsphereactionsinks.sources.tanzu.vmware.com/name": as.Name,
+	}
+}
+
+func MakeDeployment(ctx context.Context, as *v1alpha1.VSphereActionSink, args ActionSinkArgs) (*appsv1.Deployment, error) {
+	actions, err := json.Marshal(as.Spec.Actions)
+	if err != nil {
+		return nil, fmt.Errorf("marshal actions: %w", err)
+	}
+
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            names.Deployment(as),
+			Namespace:       as.Namespace,
+			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(as)},
+			Labels:          labels(as),
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.Int32(1),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels(as),
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels(as),
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: names.ServiceAccount(as),
+					Containers: []corev1.Container{{
+						Name:  "actions",
+						Image: args.Image,
+						Ports: []corev1.ContainerPort{{
+							Name:          "http",
+							ContainerPort: containerPort,
+							Protocol:      corev1.ProtocolTCP,
+						}},
+						Env: []corev1.EnvVar{{
+							Name: "NAMESPACE",
+							ValueFrom: &corev1.EnvVarSource{
+								FieldRef: &corev1.ObjectFieldSelector{
+									APIVersion: "v1",
+									FieldPath:  "metadata.namespace",
+								},
+							},
+						}, {
+							Name:  "K_METRICS_CONFIG",
+							Value: args.MetricsConfig,
+						}, {
+							Name:  "K_LOGGING_CONFIG",
+							Value: args.LoggingConfig,
+						}, {
+							Name:  "VSPHERE_ACTIONS",
+							Value: string(actions),
+						}},
+					}},
+				},
+			},
+		},
+	}, nil
+}
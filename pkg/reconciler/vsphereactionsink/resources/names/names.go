@@ -0,0 +1,31 @@
+/*
+Copyright 2020 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package names
+
+import (
+	"knative.dev/pkg/kmeta"
+
+	"github.com/vmware-tanzu/sources-for-knative/pkg/apis/sources/v1alpha1"
+)
+
+func Deployment(as *v1alpha1.VSphereActionSink) string {
+	return kmeta.ChildName(as.Name, "-actions")
+}
+
+func Service(as *v1alpha1.VSphereActionSink) string {
+	return kmeta.ChildName(as.Name, "-actions")
+}
+
+func VSphereBinding(as *v1alpha1.VSphereActionSink) string {
+	return kmeta.ChildName(as.Name, "-vspherebinding")
+}
+
+func ServiceAccount(as *v1alpha1.VSphereActionSink) string {
+	if as.Spec.ServiceAccountName == "" {
+		return "default"
+	}
+	return as.Spec.ServiceAccountName
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2020 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package names
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/vmware-tanzu/sources-for-knative/pkg/apis/sources/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNames(t *testing.T) {
+	tests := []struct {
+		name string
+		as   *v1alpha1.VSphereActionSink
+		f    func(*v1alpha1.VSphereActionSink) string
+		want string
+	}{{
+		name: "Deployment too long",
+		as: &v1alpha1.VSphereActionSink{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: strings.Repeat("f", 63),
+			},
+		},
+		f:    Deployment,
+		want: "fffffffffffffffffffffff105d7597f637e83cc711605ac3ea4957-actions",
+	}, {
+		name: "Deployment",
+		as: &v1alpha1.VSphereActionSink{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "foo",
+			},
+		},
+		f:    Deployment,
+		want: "foo-actions",
+	}, {
+		name: "Service",
+		as: &v1alpha1.VSphereActionSink{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "foo",
+			},
+		},
+		f:    Service,
+		want: "foo-actions",
+	}, {
+		name: "vspherebinding",
+		as: &v1alpha1.VSphereActionSink{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "baz",
+			},
+		},
+		f:    VSphereBinding,
+		want: "baz-vspherebinding",
+	}, {
+		name: "empty service account",
+		as: &v1alpha1.VSphereActionSink{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "baz",
+			},
+		},
+		f:    ServiceAccount,
+		want: "default",
+	}, {
+		name: "custom service account name",
+		as: &v1alpha1.VSphereActionSink{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "baz",
+			},
+			Spec: v1alpha1.VSphereActionSinkSpec{ServiceAccountName: "test-svc-acc"},
+		},
+		f:    ServiceAccount,
+		want: "test-svc-acc",
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.f(test.as)
+			if got != test.want {
+				t.Errorf("%s() = %v, wanted %v", test.name, got, test.want)
+			}
+		})
+	}
+}
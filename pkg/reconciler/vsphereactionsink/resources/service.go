@@ -0,0 +1,40 @@
+/*
+Copyright 2020 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resources
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"knative.dev/pkg/kmeta"
+
+	"github.com/vmware-tanzu/sources-for-knative/pkg/apis/sources/v1alpha1"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/reconciler/vsphereactionsink/resources/names"
+)
+
+// MakeService creates the Service through which the VSphereActionSink
+// receives CloudEvents.
+func MakeService(ctx context.Context, as *v1alpha1.VSphereActionSink) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            names.Service(as),
+			Namespace:       as.Namespace,
+			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(as)},
+			Labels:          labels(as),
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: labels(as),
+			Ports: []corev1.ServicePort{{
+				Name:       "http",
+				Protocol:   corev1.ProtocolTCP,
+				Port:       80,
+				TargetPort: intstr.FromInt(containerPort),
+			}},
+		},
+	}
+}
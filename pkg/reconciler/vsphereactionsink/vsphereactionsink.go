@@ -0,0 +1,238 @@
+/*
+Copyright 2020 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vsphereactionsink
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	appsv1listers "k8s.io/client-go/listers/apps/v1"
+	corev1Listers "k8s.io/client-go/listers/core/v1"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/metrics"
+	"knative.dev/pkg/network"
+	"knative.dev/pkg/reconciler"
+
+	sourcesv1alpha1 "github.com/vmware-tanzu/sources-for-knative/pkg/apis/sources/v1alpha1"
+	clientset "github.com/vmware-tanzu/sources-for-knative/pkg/client/clientset/versioned"
+	actionsinkreconciler "github.com/vmware-tanzu/sources-for-knative/pkg/client/injection/reconciler/sources/v1alpha1/vsphereactionsink"
+	v1alpha1lister "github.com/vmware-tanzu/sources-for-knative/pkg/client/listers/sources/v1alpha1"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/reconciler/vsphereactionsink/resources"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/reconciler/vsphereactionsink/resources/names"
+)
+
+const (
+	component = "vsphereactionsink"
+)
+
+// Reconciler implements actionsinkreconciler.Interface for VSphereActionSink
+// resources.
+type Reconciler struct {
+	kubeclient kubernetes.Interface
+	client     clientset.Interface
+
+	deploymentLister     appsv1listers.DeploymentLister
+	serviceLister        corev1Listers.ServiceLister
+	vspherebindingLister v1alpha1lister.VSphereBindingLister
+	saLister             corev1Listers.ServiceAccountLister
+
+	loggingContext context.Context
+	image          string
+	loggingConfig  *logging.Config
+	metricsConfig  *metrics.ExporterOptions
+}
+
+// Check that our Reconciler implements Interface
+var _ actionsinkreconciler.Interface = (*Reconciler)(nil)
+
+// ReconcileKind implements Interface.ReconcileKind.
+func (r *Reconciler) ReconcileKind(ctx context.Context, as *sourcesv1alpha1.VSphereActionSink) reconciler.Event {
+	if err := r.reconcileVSphereBinding(ctx, as); err != nil {
+		return err
+	}
+	if err := r.reconcileServiceAccount(ctx, as); err != nil {
+		return err
+	}
+	if err := r.reconcileDeployment(ctx, as); err != nil {
+		return err
+	}
+	if err := r.reconcileService(ctx, as); err != nil {
+		return err
+	}
+	logging.FromContext(ctx).Infof("Reconciled vsphereactionsink %q", as.Name)
+
+	return nil
+}
+
+func (r *Reconciler) reconcileVSphereBinding(ctx context.Context, as *sourcesv1alpha1.VSphereActionSink) error {
+	ns := as.Namespace
+	vspherebindingName := names.VSphereBinding(as)
+
+	vspherebinding, err := r.vspherebindingLister.VSphereBindings(ns).Get(vspherebindingName)
+	if apierrs.IsNotFound(err) {
+		vspherebinding = resources.MakeVSphereBinding(ctx, as)
+		vspherebinding, err = r.client.SourcesV1alpha1().VSphereBindings(ns).Create(ctx, vspherebinding, metav1.CreateOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to create vspherebinding %q: %w", vspherebindingName, err)
+		}
+		logging.FromContext(ctx).Infof("Created vspherebinding %q", vspherebindingName)
+	} else if err != nil {
+		return fmt.Errorf("failed to get vspherebinding %q: %w", vspherebindingName, err)
+	} else {
+		// The vspherebinding exists, but make sure that it has the shape that we expect.
+		desiredVSphereBinding := resources.MakeVSphereBinding(ctx, as)
+		if !equality.Semantic.DeepEqual(vspherebinding.Spec, desiredVSphereBinding.Spec) {
+			vspherebinding = vspherebinding.DeepCopy()
+			vspherebinding.Spec = desiredVSphereBinding.Spec
+			vspherebinding, err = r.client.SourcesV1alpha1().VSphereBindings(ns).Update(ctx, vspherebinding, metav1.UpdateOptions{})
+			if err != nil {
+				return fmt.Errorf("failed to update vspherebinding %q: %w", vspherebindingName, err)
+			}
+			logging.FromContext(ctx).Infof("Updated vspherebinding %q", vspherebindingName)
+		}
+	}
+
+	// Reflect the state of the VSphereBinding in the VSphereActionSink
+	as.Status.PropagateAuthStatus(vspherebinding.Status.Status)
+
+	return nil
+}
+
+func (r *Reconciler) reconcileServiceAccount(ctx context.Context, as *sourcesv1alpha1.VSphereActionSink) error {
+	ns := as.Namespace
+	serviceAccountName := names.ServiceAccount(as)
+	_, err := r.saLister.ServiceAccounts(ns).Get(serviceAccountName)
+	if err != nil {
+		return fmt.Errorf("failed to get serviceaccount %q: %w", serviceAccountName, err)
+	}
+	return nil
+}
+
+func (r *Reconciler) reconcileDeployment(ctx context.Context, as *sourcesv1alpha1.VSphereActionSink) error {
+	ns := as.Namespace
+	deploymentName := names.Deployment(as)
+
+	loggingConfig, err := logging.ConfigToJSON(r.loggingConfig)
+	if err != nil {
+		return fmt.Errorf("marshal logging config to JSON: %w", err)
+	}
+
+	metricsConfig, err := metrics.OptionsToJSON(r.metricsConfig)
+	if err != nil {
+		return fmt.Errorf("marshal metrics config to JSON: %w", err)
+	}
+
+	args := resources.ActionSinkArgs{
+		Image:         r.image,
+		LoggingConfig: loggingConfig,
+		MetricsConfig: metricsConfig,
+	}
+
+	desiredDeployment, err := resources.MakeDeployment(ctx, as, args)
+	if err != nil {
+		return fmt.Errorf("failed to create deployment %q: %w", deploymentName, err)
+	}
+
+	deployment, err := r.deploymentLister.Deployments(ns).Get(deploymentName)
+	if apierrs.IsNotFound(err) {
+		deployment, err = r.kubeclient.AppsV1().Deployments(ns).Create(ctx, desiredDeployment, metav1.CreateOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to create deployment %q: %w", deploymentName, err)
+		}
+		logging.FromContext(ctx).Infof("Created deployment %q", deploymentName)
+	} else if err != nil {
+		return fmt.Errorf("failed to get deployment %q: %w", deploymentName, err)
+	} else {
+		// The deployment exists, but make sure that it has the shape that we expect.
+		if deploymentDrifted(ctx, as, desiredDeployment, deployment) {
+			deployment = deployment.DeepCopy()
+			deployment.Spec = desiredDeployment.Spec
+			deployment, err = r.kubeclient.AppsV1().Deployments(ns).Update(ctx, deployment, metav1.UpdateOptions{})
+			if err != nil {
+				return fmt.Errorf("failed to update deployment %q: %w", deploymentName, err)
+			}
+			logging.FromContext(ctx).Infof("Updated deployment %q", deploymentName)
+		}
+	}
+
+	// Reflect the state of the Deployment in the VSphereActionSink
+	as.Status.PropagateAdapterStatus(deployment.Status)
+
+	return nil
+}
+
+func (r *Reconciler) reconcileService(ctx context.Context, as *sourcesv1alpha1.VSphereActionSink) error {
+	ns := as.Namespace
+	serviceName := names.Service(as)
+	desiredService := resources.MakeService(ctx, as)
+
+	service, err := r.serviceLister.Services(ns).Get(serviceName)
+	if apierrs.IsNotFound(err) {
+		service, err = r.kubeclient.CoreV1().Services(ns).Create(ctx, desiredService, metav1.CreateOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to create service %q: %w", serviceName, err)
+		}
+		logging.FromContext(ctx).Infof("Created service %q", serviceName)
+	} else if err != nil {
+		return fmt.Errorf("failed to get service %q: %w", serviceName, err)
+	} else {
+		// The service exists, but make sure that it has the shape that we
+		// expect. Only the ports and selector are updated since other fields,
+		// e.g. the cluster IP, are immutable.
+		if serviceDrifted(desiredService, service) {
+			service = service.DeepCopy()
+			service.Spec.Ports = desiredService.Spec.Ports
+			service.Spec.Selector = desiredService.Spec.Selector
+			service, err = r.kubeclient.CoreV1().Services(ns).Update(ctx, service, metav1.UpdateOptions{})
+			if err != nil {
+				return fmt.Errorf("failed to update service %q: %w", serviceName, err)
+			}
+			logging.FromContext(ctx).Infof("Updated service %q", serviceName)
+		}
+	}
+
+	as.Status.MarkAddress(&apis.URL{
+		Scheme: "http",
+		Host:   network.GetServiceHostname(service.Name, service.Namespace),
+	})
+
+	return nil
+}
+
+func (r *Reconciler) UpdateFromLoggingConfigMap(cfg *corev1.ConfigMap) {
+	if cfg != nil {
+		delete(cfg.Data, "_example")
+	}
+
+	logcfg, err := logging.NewConfigFromConfigMap(cfg)
+	if err != nil {
+		logging.FromContext(r.loggingContext).Warn("failed to create logging config from configmap", zap.String("cfg.Name", cfg.Name))
+		return
+	}
+
+	r.loggingConfig = logcfg
+	logging.FromContext(r.loggingContext).Info("update from logging ConfigMap", zap.Any("ConfigMap", cfg))
+}
+
+func (r *Reconciler) UpdateFromMetricsConfigMap(cfg *corev1.ConfigMap) {
+	if cfg != nil {
+		delete(cfg.Data, "_example")
+	}
+
+	r.metricsConfig = &metrics.ExporterOptions{
+		Domain:    metrics.Domain(),
+		Component: component,
+		ConfigMap: cfg.Data,
+	}
+	logging.FromContext(r.loggingContext).Info("update from metrics ConfigMap", zap.Any("ConfigMap", cfg))
+}
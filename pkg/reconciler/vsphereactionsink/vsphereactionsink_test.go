@@ -0,0 +1,131 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vsphereactionsink
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	appsv1listers "k8s.io/client-go/listers/apps/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	clientgotesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	"github.com/vmware-tanzu/sources-for-knative/pkg/apis/sources/v1alpha1"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/client/clientset/versioned/fake"
+	v1alpha1lister "github.com/vmware-tanzu/sources-for-knative/pkg/client/listers/sources/v1alpha1"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/reconciler/vsphereactionsink/resources"
+)
+
+func newTestActionSink() *v1alpha1.VSphereActionSink {
+	return &v1alpha1.VSphereActionSink{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "actions-01",
+			Namespace: "default",
+			UID:       "1234",
+		},
+		Spec: v1alpha1.VSphereActionSinkSpec{
+			VAuthSpec: v1alpha1.VAuthSpec{
+				Address:   *apis.HTTP("vcenter.local"),
+				SecretRef: corev1.LocalObjectReference{Name: "vsphere-creds"},
+			},
+			Actions: []v1alpha1.VSphereAction{{
+				Name:      "power-on",
+				Operation: v1alpha1.VSphereActionPowerOn,
+			}},
+		},
+	}
+}
+
+// applyServerSide mimics the API server defaulting and the VSphereBinding
+// webhook injecting the vSphere credentials into d
+func applyServerSide(ctx context.Context, as *v1alpha1.VSphereActionSink, d *appsv1.Deployment) {
+	d.Spec.Template.Spec.DNSPolicy = corev1.DNSClusterFirst
+	d.Spec.Template.Spec.RestartPolicy = corev1.RestartPolicyAlways
+	for i := range d.Spec.Template.Spec.Containers {
+		c := &d.Spec.Template.Spec.Containers[i]
+		c.ImagePullPolicy = corev1.PullIfNotPresent
+		c.TerminationMessagePath = corev1.TerminationMessagePathDefault
+	}
+
+	ps := &duckv1.WithPod{Spec: duckv1.WithPodSpec{Template: duckv1.PodSpecable(d.Spec.Template)}}
+	resources.MakeVSphereBinding(ctx, as).Do(ctx, ps)
+	d.Spec.Template = corev1.PodTemplateSpec(ps.Spec.Template)
+}
+
+func TestReconciler_ReconcileKind(t *testing.T) {
+	ctx := context.TODO()
+	as := newTestActionSink()
+
+	deployment, err := resources.MakeDeployment(ctx, as, resources.ActionSinkArgs{Image: "actions"})
+	if err != nil {
+		t.Fatalf("MakeDeployment() error = %v", err)
+	}
+	applyServerSide(ctx, as, deployment)
+	service := resources.MakeService(ctx, as)
+	service.Spec.ClusterIP = "10.0.0.1"
+	binding := resources.MakeVSphereBinding(ctx, as)
+	sa := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: as.Namespace},
+	}
+
+	// the deployment and the service share their name
+	indexerOf := func(obj runtime.Object) cache.Indexer {
+		indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+		if err := indexer.Add(obj); err != nil {
+			t.Fatalf("add %T to indexer: %v", obj, err)
+		}
+		return indexer
+	}
+	kubeclient := kubefake.NewSimpleClientset(deployment, service, sa)
+	client := fake.NewSimpleClientset(binding)
+	r := &Reconciler{
+		kubeclient:           kubeclient,
+		client:               client,
+		deploymentLister:     appsv1listers.NewDeploymentLister(indexerOf(deployment)),
+		serviceLister:        corev1listers.NewServiceLister(indexerOf(service)),
+		vspherebindingLister: v1alpha1lister.NewVSphereBindingLister(indexerOf(binding)),
+		saLister:             corev1listers.NewServiceAccountLister(indexerOf(sa)),
+		image:                "actions",
+	}
+
+	if err := r.ReconcileKind(ctx, as); err != nil {
+		t.Fatalf("ReconcileKind() error = %v", err)
+	}
+	if actions := append(kubeclient.Actions(), client.Actions()...); len(actions) != 0 {
+		t.Errorf("ReconcileKind() of unchanged resources made requests: %v", actions)
+	}
+
+	r.image = "actions-v2"
+	if err := r.ReconcileKind(ctx, as); err != nil {
+		t.Fatalf("ReconcileKind() error = %v", err)
+	}
+
+	var updated []string
+	for _, action := range append(kubeclient.Actions(), client.Actions()...) {
+		if u, ok := action.(clientgotesting.UpdateAction); ok {
+			updated = append(updated, u.GetResource().Resource)
+		}
+	}
+	if len(updated) != 1 || updated[0] != "deployments" {
+		t.Errorf("ReconcileKind() updated %v, want [deployments]", updated)
+	}
+
+	got, err := kubeclient.AppsV1().Deployments(as.Namespace).Get(ctx, deployment.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("get deployment: %v", err)
+	}
+	if image := got.Spec.Template.Spec.Containers[0].Image; image != "actions-v2" {
+		t.Errorf("image = %q, want %q", image, "actions-v2")
+	}
+}
@@ -0,0 +1,284 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vspheresource
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/ptr"
+
+	"github.com/vmware-tanzu/sources-for-knative/pkg/apis/sources/v1alpha1"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/reconciler/vspheresource/resources"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/reconciler/vspheresource/resources/names"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/sinkauth"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/vsphere"
+)
+
+func newBackfillTestSource() *v1alpha1.VSphereSource {
+	vms := newDriftTestSource()
+	vms.Spec.CheckpointConfig.Store = &v1alpha1.VCheckpointStoreSpec{
+		Type:      v1alpha1.VCheckpointStoreFile,
+		ClaimName: "checkpoint",
+	}
+	vms.Spec.Backfill = &v1alpha1.VBackfillSpec{
+		StartTime: metav1.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndTime:   metav1.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+	return vms
+}
+
+// newBackfillTestJob returns the backfill job of vms as created by the API
+// server
+func newBackfillTestJob(t *testing.T, vms *v1alpha1.VSphereSource, conditions ...batchv1.JobCondition) *batchv1.Job {
+	t.Helper()
+
+	job, err := resources.MakeBackfillJob(context.TODO(), vms, resources.AdapterArgs{Image: "adapter"})
+	if err != nil {
+		t.Fatalf("MakeBackfillJob() error = %v", err)
+	}
+	job.UID = "5678"
+	job.Status.Conditions = conditions
+	return job
+}
+
+func envValue(c corev1.Container, name string) string {
+	for _, env := range c.Env {
+		if env.Name == name {
+			return env.Value
+		}
+	}
+	return ""
+}
+
+func TestReconciler_reconcileBackfill(t *testing.T) {
+	ctx := context.TODO()
+
+	t.Run("creates job", func(t *testing.T) {
+		vms := newBackfillTestSource()
+		r := newTestReconciler(t)
+		r.adapterImage = "adapter"
+
+		if err := r.reconcileBackfill(ctx, vms); err != nil {
+			t.Fatalf("reconcileBackfill() error = %v", err)
+		}
+
+		job, err := r.kubeclient.BatchV1().Jobs(vms.Namespace).Get(ctx, "vsphere-01-backfill", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("get job: %v", err)
+		}
+		if !metav1.IsControlledBy(job, vms) {
+			t.Errorf("job is not controlled by the source: %v", job.OwnerReferences)
+		}
+
+		adapter := job.Spec.Template.Spec.Containers[0]
+		if got, want := envValue(adapter, "VSPHERE_BACKFILL_START"), "2022-01-01T00:00:00Z"; got != want {
+			t.Errorf("VSPHERE_BACKFILL_START = %q, want %q", got, want)
+		}
+		if got, want := envValue(adapter, "VSPHERE_BACKFILL_END"), "2022-01-02T00:00:00Z"; got != want {
+			t.Errorf("VSPHERE_BACKFILL_END = %q, want %q", got, want)
+		}
+		// the pods of the job get the credentials, but not the checkpoint
+		// claim used by the deployment
+		volumes := job.Spec.Template.Spec.Volumes
+		if len(volumes) != 1 || volumes[0].Name != vsphere.VolumeName {
+			t.Errorf("volumes = %v, want only the credentials", volumes)
+		}
+
+		if job.Spec.BackoffLimit == nil || *job.Spec.BackoffLimit != 6 {
+			t.Errorf("job backoffLimit = %v, want 6", job.Spec.BackoffLimit)
+		}
+
+		if vms.Status.Backfill == nil || !vms.Status.Backfill.StartTime.Equal(&vms.Spec.Backfill.StartTime) {
+			t.Errorf("status.backfill = %v, want range of spec.backfill", vms.Status.Backfill)
+		}
+		if cond := vms.Status.GetCondition(v1alpha1.VSphereSourceConditionBackfillSucceeded); cond == nil || !cond.IsUnknown() {
+			t.Errorf("BackfillSucceeded condition = %v, want Unknown", cond)
+		}
+	})
+
+	t.Run("sink token", func(t *testing.T) {
+		vms := newBackfillTestSource()
+		vms.Status.SinkCACerts = ptr.String("cert")
+		vms.Status.SinkAudience = ptr.String("broker-audience")
+		r := newTestReconciler(t)
+		r.adapterImage = "adapter"
+
+		if err := r.reconcileBackfill(ctx, vms); err != nil {
+			t.Fatalf("reconcileBackfill() error = %v", err)
+		}
+		job, err := r.kubeclient.BatchV1().Jobs(vms.Namespace).Get(ctx, "vsphere-01-backfill", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("get job: %v", err)
+		}
+
+		adapter := job.Spec.Template.Spec.Containers[0]
+		if envValue(adapter, sinkauth.CACertsEnv) != "cert" || envValue(adapter, sinkauth.AudienceEnv) != "broker-audience" {
+			t.Errorf("env = %v, want sink CA certs and audience", adapter.Env)
+		}
+		// the job sends with a token like the deployment
+		volumes := job.Spec.Template.Spec.Volumes
+		if len(volumes) != 2 || volumes[0].Name != sinkauth.SinkTokenName ||
+			volumes[0].Projected.Sources[0].ServiceAccountToken.Audience != "broker-audience" {
+			t.Errorf("volumes = %v, want sink token and credentials", volumes)
+		}
+		if mounts := adapter.VolumeMounts; len(mounts) != 2 || mounts[0].Name != sinkauth.SinkTokenName {
+			t.Errorf("volume mounts = %v, want sink token and credentials", mounts)
+		}
+	})
+
+	t.Run("completed job", func(t *testing.T) {
+		vms := newBackfillTestSource()
+		vms.Status.Backfill = &v1alpha1.VBackfillStatus{
+			StartTime:  vms.Spec.Backfill.StartTime,
+			EndTime:    vms.Spec.Backfill.EndTime,
+			EventsSent: 42,
+		}
+		job := newBackfillTestJob(t, vms, batchv1.JobCondition{
+			Type:   batchv1.JobComplete,
+			Status: corev1.ConditionTrue,
+		})
+		r := newTestReconciler(t, job)
+		r.adapterImage = "adapter"
+
+		if err := r.reconcileBackfill(ctx, vms); err != nil {
+			t.Fatalf("reconcileBackfill() error = %v", err)
+		}
+		if actions := r.kubeclient.(*kubefake.Clientset).Actions(); len(actions) != 0 {
+			t.Errorf("reconcileBackfill() of unchanged job made requests: %v", actions)
+		}
+		if vms.Status.Backfill.EventsSent != 42 {
+			t.Errorf("status.backfill.eventsSent = %d, want progress to be kept", vms.Status.Backfill.EventsSent)
+		}
+		if cond := vms.Status.GetCondition(v1alpha1.VSphereSourceConditionBackfillSucceeded); cond == nil || !cond.IsTrue() {
+			t.Errorf("BackfillSucceeded condition = %v, want True", cond)
+		}
+	})
+
+	t.Run("range changed", func(t *testing.T) {
+		vms := newBackfillTestSource()
+		vms.Status.Backfill = &v1alpha1.VBackfillStatus{
+			StartTime:  vms.Spec.Backfill.StartTime,
+			EndTime:    vms.Spec.Backfill.EndTime,
+			EventsSent: 42,
+		}
+		r := newTestReconciler(t, newBackfillTestJob(t, vms))
+		r.adapterImage = "adapter"
+
+		vms.Spec.Backfill.EndTime = metav1.Date(2022, 1, 3, 0, 0, 0, 0, time.UTC)
+		if err := r.reconcileBackfill(ctx, vms); err != nil {
+			t.Fatalf("reconcileBackfill() error = %v", err)
+		}
+
+		job, err := r.kubeclient.BatchV1().Jobs(vms.Namespace).Get(ctx, "vsphere-01-backfill", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("get job: %v", err)
+		}
+		if got, want := job.Annotations[resources.BackfillEndAnnotation], "2022-01-03T00:00:00Z"; got != want {
+			t.Errorf("job end = %q, want %q", got, want)
+		}
+		if status := vms.Status.Backfill; status.EventsSent != 0 || !status.EndTime.Equal(&vms.Spec.Backfill.EndTime) {
+			t.Errorf("status.backfill = %v, want reset for the new range", status)
+		}
+	})
+
+	t.Run("sink changed", func(t *testing.T) {
+		vms := newBackfillTestSource()
+		r := newTestReconciler(t, newBackfillTestJob(t, vms))
+		r.adapterImage = "adapter"
+
+		vms.Status.SinkURI = apis.HTTP("other-sink.default.svc")
+		if err := r.reconcileBackfill(ctx, vms); err != nil {
+			t.Fatalf("reconcileBackfill() error = %v", err)
+		}
+
+		job, err := r.kubeclient.BatchV1().Jobs(vms.Namespace).Get(ctx, "vsphere-01-backfill", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("get job: %v", err)
+		}
+		if job.UID == "5678" {
+			t.Error("job was not replaced")
+		}
+		if got, want := envValue(job.Spec.Template.Spec.Containers[0], "K_SINK"), "http://other-sink.default.svc"; got != want {
+			t.Errorf("K_SINK = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("sink changed after completion", func(t *testing.T) {
+		vms := newBackfillTestSource()
+		r := newTestReconciler(t, newBackfillTestJob(t, vms, batchv1.JobCondition{
+			Type:   batchv1.JobComplete,
+			Status: corev1.ConditionTrue,
+		}))
+		r.adapterImage = "adapter"
+
+		vms.Status.SinkURI = apis.HTTP("other-sink.default.svc")
+		if err := r.reconcileBackfill(ctx, vms); err != nil {
+			t.Fatalf("reconcileBackfill() error = %v", err)
+		}
+		if actions := r.kubeclient.(*kubefake.Clientset).Actions(); len(actions) != 0 {
+			t.Errorf("reconcileBackfill() repeated a completed backfill: %v", actions)
+		}
+	})
+
+	t.Run("suspended", func(t *testing.T) {
+		vms := newBackfillTestSource()
+		r := newTestReconciler(t, newBackfillTestJob(t, vms))
+		r.adapterImage = "adapter"
+
+		vms.Spec.Suspend = true
+		if err := r.reconcileBackfill(ctx, vms); err != nil {
+			t.Fatalf("reconcileBackfill() error = %v", err)
+		}
+
+		job, err := r.kubeclient.BatchV1().Jobs(vms.Namespace).Get(ctx, "vsphere-01-backfill", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("get job: %v", err)
+		}
+		if job.Spec.Suspend == nil || !*job.Spec.Suspend {
+			t.Errorf("job suspend = %v, want true", job.Spec.Suspend)
+		}
+	})
+
+	t.Run("backfill removed", func(t *testing.T) {
+		vms := newBackfillTestSource()
+		r := newTestReconciler(t, newBackfillTestJob(t, vms))
+		vms.Spec.Backfill = nil
+		vms.Status.Backfill = &v1alpha1.VBackfillStatus{EventsSent: 42}
+
+		if err := r.reconcileBackfill(ctx, vms); err != nil {
+			t.Fatalf("reconcileBackfill() error = %v", err)
+		}
+
+		_, err := r.kubeclient.BatchV1().Jobs(vms.Namespace).Get(ctx, "vsphere-01-backfill", metav1.GetOptions{})
+		if !apierrs.IsNotFound(err) {
+			t.Errorf("get job error = %v, want not found", err)
+		}
+		if vms.Status.Backfill != nil {
+			t.Errorf("status.backfill = %v, want nil", vms.Status.Backfill)
+		}
+	})
+
+	t.Run("foreign job", func(t *testing.T) {
+		vms := newBackfillTestSource()
+		job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+			Namespace: vms.Namespace,
+			Name:      names.BackfillJob(vms),
+		}}
+		r := newTestReconciler(t, job)
+
+		if err := r.reconcileBackfill(ctx, vms); err == nil {
+			t.Error("reconcileBackfill() error = nil, want not owned error")
+		}
+	})
+}
@@ -20,6 +20,7 @@ import (
 	eventingclient "knative.dev/eventing/pkg/client/injection/client"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	deploymentinformer "knative.dev/pkg/client/injection/kube/informers/apps/v1/deployment"
+	jobinformer "knative.dev/pkg/client/injection/kube/informers/batch/v1/job"
 	cminformer "knative.dev/pkg/client/injection/kube/informers/core/v1/configmap"
 	sainformer "knative.dev/pkg/client/injection/kube/informers/core/v1/serviceaccount"
 	rbacinformer "knative.dev/pkg/client/injection/kube/informers/rbac/v1/rolebinding"
@@ -29,10 +30,14 @@ import (
 	vspherebindinginformer "github.com/vmware-tanzu/sources-for-knative/pkg/client/injection/informers/sources/v1alpha1/vspherebinding"
 	vsphereinformer "github.com/vmware-tanzu/sources-for-knative/pkg/client/injection/informers/sources/v1alpha1/vspheresource"
 	vspherereconciler "github.com/vmware-tanzu/sources-for-knative/pkg/client/injection/reconciler/sources/v1alpha1/vspheresource"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/reconciler/eventtype"
 )
 
 type envConfig struct {
 	VSphereAdapter string `envconfig:"VSPHERE_ADAPTER" required:"true"`
+	// register the event types of the vCenter catalog as EventTypes of
+	// sink brokers
+	EventTypes bool `envconfig:"VSPHERE_SOURCE_EVENT_TYPES" default:"true"`
 }
 
 // NewController creates a Reconciler and returns the result of NewImpl.
@@ -44,6 +49,7 @@ func NewController(
 
 	vsphereInformer := vsphereinformer.Get(ctx)
 	deploymentInformer := deploymentinformer.Get(ctx)
+	jobInformer := jobinformer.Get(ctx)
 	rbacInformer := rbacinformer.Get(ctx)
 	cmInformer := cminformer.Get(ctx)
 	vspherebindingInformer := vspherebindinginformer.Get(ctx)
@@ -59,24 +65,46 @@ func NewController(
 		eventingclient:       eventingclient.Get(ctx),
 		client:               client.Get(ctx),
 		deploymentLister:     deploymentInformer.Lister(),
+		jobLister:            jobInformer.Lister(),
 		vspherebindingLister: vspherebindingInformer.Lister(),
+		vsphereLister:        vsphereInformer.Lister(),
 		rbacLister:           rbacInformer.Lister(),
 		cmLister:             cmInformer.Lister(),
 		saLister:             saInformer.Lister(),
 		adapterImage:         env.VSphereAdapter,
 		loggingContext:       ctx,
 	}
-	impl := vspherereconciler.NewImpl(ctx, r)
+	if env.EventTypes {
+		r.eventTypes = eventtype.NewReconciler(r.eventingclient)
+		r.catalogs = NewCatalogCache()
+	}
+	impl := vspherereconciler.NewImpl(ctx, r, func(*controller.Impl) controller.Options {
+		// the Reconciler updates the status itself, see updateStatus
+		return controller.Options{SkipStatusUpdates: true}
+	})
 
 	logger.Info("Setting up event handlers.")
 
-	vsphereInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
+	vsphereInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: impl.Enqueue,
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			if !checkpointReport(oldObj, newObj) {
+				impl.Enqueue(newObj)
+			}
+		},
+		DeleteFunc: impl.Enqueue,
+	})
 
 	deploymentInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
 		FilterFunc: controller.FilterControllerGK(v1alpha1.Kind("VSphereSource")),
 		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
 	})
 
+	jobInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.FilterControllerGK(v1alpha1.Kind("VSphereSource")),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
+
 	saInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
 		FilterFunc: controller.FilterControllerGK(v1alpha1.Kind("VSphereSource")),
 		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
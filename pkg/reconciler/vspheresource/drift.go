@@ -0,0 +1,63 @@
+/*
+Copyright 2020 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vspheresource
+
+import (
+	"context"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/controller"
+
+	sourcesv1alpha1 "github.com/vmware-tanzu/sources-for-knative/pkg/apis/sources/v1alpha1"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/reconciler/vspheresource/resources"
+)
+
+// deploymentDrifted returns whether the fields of existing that
+// resources.MakeDeployment sets differ from desired. The volume, volume mounts
+// and environment variables injected by the VSphereBinding of vms and fields
+// defaulted by the API server are ignored.
+func deploymentDrifted(ctx context.Context, vms *sourcesv1alpha1.VSphereSource, desired, existing *appsv1.Deployment) bool {
+	if !equality.Semantic.DeepEqual(desired.Spec.Replicas, existing.Spec.Replicas) ||
+		desired.Spec.Strategy.Type != existing.Spec.Strategy.Type {
+		return true
+	}
+
+	ps := &duckv1.WithPod{
+		Spec: duckv1.WithPodSpec{Template: duckv1.PodSpecable(*existing.Spec.Template.DeepCopy())},
+	}
+	resources.MakeVSphereBinding(ctx, vms).Undo(ctx, ps)
+
+	want, got := desired.Spec.Template, ps.Spec.Template
+	if !equality.Semantic.DeepEqual(want.Labels, got.Labels) ||
+		want.Spec.ServiceAccountName != got.Spec.ServiceAccountName ||
+		!equality.Semantic.DeepEqual(want.Spec.TerminationGracePeriodSeconds, got.Spec.TerminationGracePeriodSeconds) ||
+		!equality.Semantic.DeepEqual(want.Spec.Volumes, got.Spec.Volumes) ||
+		len(want.Spec.Containers) != len(got.Spec.Containers) {
+		return true
+	}
+
+	for i, wc := range want.Spec.Containers {
+		gc := got.Spec.Containers[i]
+		if wc.Name != gc.Name || wc.Image != gc.Image ||
+			!equality.Semantic.DeepEqual(wc.Env, gc.Env) ||
+			!equality.Semantic.DeepEqual(wc.VolumeMounts, gc.VolumeMounts) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// recordDriftRepaired records a Normal event with the given reason on vms
+// after an owned resource was changed back to its desired state.
+func recordDriftRepaired(ctx context.Context, vms *sourcesv1alpha1.VSphereSource, reason, messageFmt string, args ...interface{}) {
+	if recorder := controller.GetEventRecorder(ctx); recorder != nil {
+		recorder.Eventf(vms, corev1.EventTypeNormal, reason, messageFmt, args...)
+	}
+}
@@ -0,0 +1,260 @@
+/*
+Copyright 2020 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vspheresource
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	appsv1listers "k8s.io/client-go/listers/apps/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/ptr"
+
+	"github.com/vmware-tanzu/sources-for-knative/pkg/apis/sources/v1alpha1"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/client/clientset/versioned/fake"
+	v1alpha1lister "github.com/vmware-tanzu/sources-for-knative/pkg/client/listers/sources/v1alpha1"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/reconciler/vspheresource/resources"
+)
+
+// applyServerSide mimics the API server defaulting and the VSphereBinding
+// webhook injecting the vSphere credentials into d
+func applyServerSide(ctx context.Context, t *testing.T, vms *v1alpha1.VSphereSource, d *appsv1.Deployment) {
+	t.Helper()
+
+	d.Spec.RevisionHistoryLimit = ptr.Int32(10)
+	d.Spec.ProgressDeadlineSeconds = ptr.Int32(600)
+	d.Spec.Template.Spec.DNSPolicy = corev1.DNSClusterFirst
+	d.Spec.Template.Spec.RestartPolicy = corev1.RestartPolicyAlways
+	d.Spec.Template.Spec.SchedulerName = corev1.DefaultSchedulerName
+	d.Spec.Template.Spec.SecurityContext = &corev1.PodSecurityContext{}
+	for i := range d.Spec.Template.Spec.Containers {
+		c := &d.Spec.Template.Spec.Containers[i]
+		c.ImagePullPolicy = corev1.PullIfNotPresent
+		c.TerminationMessagePath = corev1.TerminationMessagePathDefault
+		c.TerminationMessagePolicy = corev1.TerminationMessageReadFile
+	}
+
+	ps := &duckv1.WithPod{Spec: duckv1.WithPodSpec{Template: duckv1.PodSpecable(d.Spec.Template)}}
+	resources.MakeVSphereBinding(ctx, vms).Do(ctx, ps)
+	d.Spec.Template = corev1.PodTemplateSpec(ps.Spec.Template)
+
+	if len(d.Spec.Template.Spec.Volumes) == 0 {
+		t.Fatal("VSphereBinding did not inject a volume")
+	}
+}
+
+func newDriftTestSource() *v1alpha1.VSphereSource {
+	vms := newTestSource()
+	vms.Spec.VAuthSpec = v1alpha1.VAuthSpec{
+		SecretRef: corev1.LocalObjectReference{Name: "vsphere-creds"},
+	}
+	vms.Spec.PayloadEncoding = "application/xml"
+	return vms
+}
+
+func Test_deploymentDrifted(t *testing.T) {
+	ctx := context.TODO()
+	args := resources.AdapterArgs{Image: "adapter"}
+
+	tests := []struct {
+		name   string
+		mutate func(vms *v1alpha1.VSphereSource, d *appsv1.Deployment)
+		want   bool
+	}{{
+		name:   "in sync",
+		mutate: func(vms *v1alpha1.VSphereSource, d *appsv1.Deployment) {},
+		want:   false,
+	}, {
+		name: "restarted",
+		mutate: func(vms *v1alpha1.VSphereSource, d *appsv1.Deployment) {
+			d.Spec.Template.Annotations = map[string]string{"kubectl.kubernetes.io/restartedAt": "2022-01-01T00:00:00Z"}
+		},
+		want: false,
+	}, {
+		name: "image changed",
+		mutate: func(vms *v1alpha1.VSphereSource, d *appsv1.Deployment) {
+			d.Spec.Template.Spec.Containers[0].Image = "other"
+		},
+		want: true,
+	}, {
+		name: "replicas changed",
+		mutate: func(vms *v1alpha1.VSphereSource, d *appsv1.Deployment) {
+			d.Spec.Replicas = ptr.Int32(2)
+		},
+		want: true,
+	}, {
+		name: "CloudEvent overrides removed",
+		mutate: func(vms *v1alpha1.VSphereSource, d *appsv1.Deployment) {
+			for i, env := range d.Spec.Template.Spec.Containers[0].Env {
+				if env.Name == "K_CE_OVERRIDES" {
+					d.Spec.Template.Spec.Containers[0].Env[i].Value = `{"extensions":{"foo":"bar"}}`
+				}
+			}
+		},
+		want: true,
+	}, {
+		name: "service account changed",
+		mutate: func(vms *v1alpha1.VSphereSource, d *appsv1.Deployment) {
+			vms.Spec.ServiceAccountName = "custom-sa"
+		},
+		want: true,
+	}, {
+		name: "suspended",
+		mutate: func(vms *v1alpha1.VSphereSource, d *appsv1.Deployment) {
+			vms.Spec.Suspend = true
+		},
+		want: true,
+	}, {
+		name: "checkpoint store changed to configmap",
+		mutate: func(vms *v1alpha1.VSphereSource, d *appsv1.Deployment) {
+			vms.Spec.CheckpointConfig.Store = nil
+		},
+		want: true,
+	}, {
+		name: "sink CA certs resolved",
+		mutate: func(vms *v1alpha1.VSphereSource, d *appsv1.Deployment) {
+			vms.Status.SinkCACerts = ptr.String("cert")
+		},
+		want: true,
+	}, {
+		name: "sink audience resolved",
+		mutate: func(vms *v1alpha1.VSphereSource, d *appsv1.Deployment) {
+			vms.Status.SinkAudience = ptr.String("broker-audience")
+		},
+		want: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vms := newDriftTestSource()
+			vms.Spec.CheckpointConfig.Store = &v1alpha1.VCheckpointStoreSpec{
+				Type:      v1alpha1.VCheckpointStoreFile,
+				ClaimName: "checkpoints",
+			}
+
+			existing, err := resources.MakeDeployment(ctx, vms, args)
+			if err != nil {
+				t.Fatalf("MakeDeployment() error = %v", err)
+			}
+			applyServerSide(ctx, t, vms, existing)
+			tt.mutate(vms, existing)
+
+			desired, err := resources.MakeDeployment(ctx, vms, args)
+			if err != nil {
+				t.Fatalf("MakeDeployment() error = %v", err)
+			}
+
+			if got := deploymentDrifted(ctx, vms, desired, existing); got != tt.want {
+				t.Errorf("deploymentDrifted() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReconciler_reconcileDeploymentDrift(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	ctx := controller.WithEventRecorder(context.TODO(), recorder)
+	vms := newDriftTestSource()
+
+	existing, err := resources.MakeDeployment(ctx, vms, resources.AdapterArgs{Image: "adapter"})
+	if err != nil {
+		t.Fatalf("MakeDeployment() error = %v", err)
+	}
+	applyServerSide(ctx, t, vms, existing)
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	if err := indexer.Add(existing); err != nil {
+		t.Fatalf("add deployment to indexer: %v", err)
+	}
+	kubeclient := kubefake.NewSimpleClientset(existing)
+	r := &Reconciler{
+		kubeclient:       kubeclient,
+		deploymentLister: appsv1listers.NewDeploymentLister(indexer),
+		adapterImage:     "adapter",
+	}
+
+	if err := r.reconcileDeployment(ctx, vms); err != nil {
+		t.Fatalf("reconcileDeployment() error = %v", err)
+	}
+	if actions := kubeclient.Actions(); len(actions) != 0 {
+		t.Errorf("reconcileDeployment() of unchanged deployment made requests: %v", actions)
+	}
+
+	r.adapterImage = "adapter-v2"
+	if err := r.reconcileDeployment(ctx, vms); err != nil {
+		t.Fatalf("reconcileDeployment() error = %v", err)
+	}
+
+	got, err := kubeclient.AppsV1().Deployments(vms.Namespace).Get(ctx, existing.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("get deployment: %v", err)
+	}
+	if image := got.Spec.Template.Spec.Containers[0].Image; image != "adapter-v2" {
+		t.Errorf("image = %q, want %q", image, "adapter-v2")
+	}
+
+	select {
+	case event := <-recorder.Events:
+		if want := `Normal DeploymentUpdated Updated deployment "vsphere-01-adapter"`; event != want {
+			t.Errorf("event = %q, want %q", event, want)
+		}
+	default:
+		t.Error("no event recorded for the updated deployment")
+	}
+}
+
+func TestReconciler_reconcileVSphereBindingDrift(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	ctx := controller.WithEventRecorder(context.TODO(), recorder)
+	vms := newDriftTestSource()
+
+	existing := resources.MakeVSphereBinding(ctx, vms)
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	if err := indexer.Add(existing); err != nil {
+		t.Fatalf("add vspherebinding to indexer: %v", err)
+	}
+	client := fake.NewSimpleClientset(existing)
+	r := &Reconciler{
+		client:               client,
+		vspherebindingLister: v1alpha1lister.NewVSphereBindingLister(indexer),
+	}
+
+	if err := r.reconcileVSphereBinding(ctx, vms); err != nil {
+		t.Fatalf("reconcileVSphereBinding() error = %v", err)
+	}
+	if actions := client.Actions(); len(actions) != 0 {
+		t.Errorf("reconcileVSphereBinding() of unchanged binding made requests: %v", actions)
+	}
+
+	vms.Spec.SecretRef.Name = "vsphere-creds-rotated"
+	if err := r.reconcileVSphereBinding(ctx, vms); err != nil {
+		t.Fatalf("reconcileVSphereBinding() error = %v", err)
+	}
+
+	got, err := client.SourcesV1alpha1().VSphereBindings(vms.Namespace).Get(ctx, existing.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("get vspherebinding: %v", err)
+	}
+	if got.Spec.SecretRef.Name != "vsphere-creds-rotated" {
+		t.Errorf("secretRef = %q, want %q", got.Spec.SecretRef.Name, "vsphere-creds-rotated")
+	}
+
+	select {
+	case event := <-recorder.Events:
+		if want := `Normal VSphereBindingUpdated Updated vspherebinding "vsphere-01-vspherebinding"`; event != want {
+			t.Errorf("event = %q, want %q", event, want)
+		}
+	default:
+		t.Error("no event recorded for the updated vspherebinding")
+	}
+}
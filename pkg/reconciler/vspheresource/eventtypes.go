@@ -0,0 +1,169 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vspheresource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	vim "github.com/vmware/govmomi/vim25/types"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/eventing/pkg/apis/eventing/v1beta2"
+	"knative.dev/pkg/logging"
+
+	sourcesv1alpha1 "github.com/vmware-tanzu/sources-for-knative/pkg/apis/sources/v1alpha1"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/reconciler/eventtype"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/reconciler/vspheresource/resources"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/vsphere"
+)
+
+const (
+	// time after which the event catalog of a source with unchanged secret,
+	// address and TLS settings is read again
+	catalogInterval = time.Hour
+	// time after which a failed read of the event catalog is retried
+	catalogRetryInterval = time.Minute
+	// timeout of reading the event catalog of a vCenter
+	catalogTimeout = 30 * time.Second
+	// delay before a source whose catalog read was rate-limited is
+	// reconciled again
+	catalogPendingDelay = 10 * time.Second
+)
+
+// errCatalogPending is returned when the event catalog of a source was not
+// read yet because of the rate limit
+var errCatalogPending = errors.New("vCenter event catalog has not been read yet")
+
+// CatalogFunc logs in to the vCenter at address with user and returns its
+// about info and event catalog
+type CatalogFunc func(ctx context.Context, address string, insecure bool, user *url.Userinfo) (vim.AboutInfo, []vsphere.EventTypeInfo, error)
+
+// CatalogCache caches the event catalogs of the vCenters of VSphereSources.
+// Catalogs are read rate-limited across all sources and only read again when
+// the secret, address or TLS settings of a source change or the last read is
+// older than Interval.
+type CatalogCache struct {
+	Read     CatalogFunc
+	Limiter  *rate.Limiter
+	Interval time.Duration
+
+	mu       sync.Mutex
+	catalogs map[types.NamespacedName]catalogRead
+}
+
+// catalogRead is the result of reading the catalog with the given inputs
+type catalogRead struct {
+	inputs  string
+	time    time.Time
+	about   vim.AboutInfo
+	catalog []vsphere.EventTypeInfo
+	err     error
+}
+
+// NewCatalogCache returns a CatalogCache reading with vsphere.ReadEventCatalog
+// at most once per 10 seconds
+func NewCatalogCache() *CatalogCache {
+	return &CatalogCache{
+		Read:     vsphere.ReadEventCatalog,
+		Limiter:  rate.NewLimiter(rate.Every(10*time.Second), 3),
+		Interval: catalogInterval,
+	}
+}
+
+// Get returns the event catalog of the vCenter of vms, reading it with the
+// credentials in secret if required.
+func (c *CatalogCache) Get(ctx context.Context, vms *sourcesv1alpha1.VSphereSource, secret *corev1.Secret) (vim.AboutInfo, []vsphere.EventTypeInfo, error) {
+	key := types.NamespacedName{Namespace: vms.Namespace, Name: vms.Name}
+	inputs := fmt.Sprintf("%s/%s/%s/%t", secret.UID, secret.ResourceVersion, vms.Spec.Address.String(), vms.Spec.SkipTLSVerify)
+
+	c.mu.Lock()
+	last, ok := c.catalogs[key]
+	c.mu.Unlock()
+
+	current := ok && last.inputs == inputs
+	maxAge := c.Interval
+	if last.err != nil {
+		maxAge = catalogRetryInterval
+	}
+
+	if !current || time.Since(last.time) > maxAge {
+		if !c.Limiter.Allow() {
+			if !current {
+				return vim.AboutInfo{}, nil, errCatalogPending
+			}
+			// keep the outdated catalog until the next reconciliation
+		} else {
+			last = c.read(ctx, key, inputs, vms, secret)
+		}
+	}
+	return last.about, last.catalog, last.err
+}
+
+func (c *CatalogCache) read(ctx context.Context, key types.NamespacedName, inputs string, vms *sourcesv1alpha1.VSphereSource, secret *corev1.Secret) catalogRead {
+	ctx, cancel := context.WithTimeout(ctx, catalogTimeout)
+	defer cancel()
+
+	user := url.UserPassword(string(secret.Data[corev1.BasicAuthUsernameKey]), string(secret.Data[corev1.BasicAuthPasswordKey]))
+	about, catalog, err := c.Read(ctx, vms.Spec.Address.String(), vms.Spec.SkipTLSVerify, user)
+	if err != nil {
+		err = fmt.Errorf("read vCenter event catalog: %w", err)
+		logging.FromContext(ctx).Infow("could not read vCenter event catalog", zap.Error(err))
+	}
+	result := catalogRead{inputs: inputs, time: time.Now(), about: about, catalog: catalog, err: err}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.catalogs == nil {
+		c.catalogs = make(map[types.NamespacedName]catalogRead)
+	}
+	c.catalogs[key] = result
+
+	// forget deleted sources
+	for k, r := range c.catalogs {
+		if time.Since(r.time) > 2*c.Interval {
+			delete(c.catalogs, k)
+		}
+	}
+
+	return result
+}
+
+// reconcileEventTypes registers the event types of the vCenter catalog as
+// EventTypes of the sink broker of vms, if any.
+func (r *Reconciler) reconcileEventTypes(ctx context.Context, vms *sourcesv1alpha1.VSphereSource) error {
+	var desired []*v1beta2.EventType
+
+	if broker := eventtype.Broker(vms.Spec.Sink, vms.Namespace); broker != nil {
+		// the secret is read directly instead of caching all secrets of the
+		// cluster in an informer
+		name := vms.Spec.SecretRef.Name
+		secret, err := r.kubeclient.CoreV1().Secrets(vms.Namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to get secret %q: %w", name, err)
+		}
+
+		about, catalog, err := r.catalogs.Get(ctx, vms, secret)
+		if err != nil {
+			return err
+		}
+
+		desired, err = resources.MakeEventTypes(vms, broker, about, catalog)
+		if err != nil {
+			return fmt.Errorf("failed to make eventtypes: %w", err)
+		}
+	}
+
+	return r.eventTypes.Reconcile(ctx, vms, desired)
+}
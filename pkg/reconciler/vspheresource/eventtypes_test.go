@@ -0,0 +1,199 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vspheresource
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	vim "github.com/vmware/govmomi/vim25/types"
+	"golang.org/x/time/rate"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	eventingfake "knative.dev/eventing/pkg/client/clientset/versioned/fake"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	"github.com/vmware-tanzu/sources-for-knative/pkg/apis/sources/v1alpha1"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/reconciler/eventtype"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/reconciler/vspheresource/resources"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/vsphere"
+)
+
+const testInstanceUUID = "dbed6e0c-bd88-4ef6-b594-21283e1c677f"
+
+func newEventTypesTestSource() *v1alpha1.VSphereSource {
+	vms := newTestSource()
+	vms.Spec.Address = *apis.HTTPS("vcenter.example.com")
+	vms.Spec.SecretRef = corev1.LocalObjectReference{Name: "vsphere-credentials"}
+	vms.Spec.Sink = duckv1.Destination{Ref: &duckv1.KReference{
+		APIVersion: "eventing.knative.dev/v1",
+		Kind:       "Broker",
+		Name:       "default",
+	}}
+	return vms
+}
+
+func newTestSecret() *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "vsphere-credentials",
+			Namespace:       "default",
+			UID:             "5678",
+			ResourceVersion: "1",
+		},
+		Data: map[string][]byte{
+			corev1.BasicAuthUsernameKey: []byte("user"),
+			corev1.BasicAuthPasswordKey: []byte("pass"),
+		},
+	}
+}
+
+// newTestCatalogCache returns a cache counting the catalog reads
+func newTestCatalogCache(reads *int, readErr *error) *CatalogCache {
+	return &CatalogCache{
+		Read: func(ctx context.Context, address string, insecure bool, user *url.Userinfo) (vim.AboutInfo, []vsphere.EventTypeInfo, error) {
+			*reads++
+			if pass, _ := user.Password(); user.Username() != "user" || pass != "pass" {
+				return vim.AboutInfo{}, nil, errors.New("invalid credentials")
+			}
+			if *readErr != nil {
+				return vim.AboutInfo{}, nil, *readErr
+			}
+			return vim.AboutInfo{InstanceUuid: testInstanceUUID}, []vsphere.EventTypeInfo{
+				{Class: "event", Type: "VmPoweredOnEvent", Category: "info", Description: "VM powered on"},
+				{Class: "eventex", Type: "com.vmware.vc.HA.HostFailedEvent", Category: "error"},
+			}, nil
+		},
+		Limiter:  rate.NewLimiter(rate.Inf, 0),
+		Interval: time.Hour,
+	}
+}
+
+func TestCatalogCache_Get(t *testing.T) {
+	ctx := context.TODO()
+	vms := newEventTypesTestSource()
+	secret := newTestSecret()
+
+	var (
+		reads   int
+		readErr error
+	)
+	c := newTestCatalogCache(&reads, &readErr)
+
+	if _, catalog, err := c.Get(ctx, vms, secret); err != nil || len(catalog) != 2 {
+		t.Fatalf("Get() = %v, %v, want catalog", catalog, err)
+	}
+	if _, _, err := c.Get(ctx, vms, secret); err != nil || reads != 1 {
+		t.Errorf("Get() of cached catalog error = %v, reads = %d, want 1", err, reads)
+	}
+
+	// a changed secret is read again
+	secret.ResourceVersion = "2"
+	readErr = errors.New("connection refused")
+	if _, _, err := c.Get(ctx, vms, secret); err == nil || reads != 2 {
+		t.Errorf("Get() error = %v, reads = %d, want error after 2 reads", err, reads)
+	}
+
+	// failed reads are cached until the retry interval passed
+	readErr = nil
+	if _, _, err := c.Get(ctx, vms, secret); err == nil || reads != 2 {
+		t.Errorf("Get() error = %v, reads = %d, want cached error", err, reads)
+	}
+
+	// reads are rate-limited
+	c.Limiter = rate.NewLimiter(0, 0)
+	secret.ResourceVersion = "3"
+	if _, _, err := c.Get(ctx, vms, secret); !errors.Is(err, errCatalogPending) {
+		t.Errorf("Get() error = %v, want %v", err, errCatalogPending)
+	}
+}
+
+func TestReconciler_reconcileEventTypes(t *testing.T) {
+	ctx := context.TODO()
+
+	vms := newEventTypesTestSource()
+	secret := newTestSecret()
+
+	var (
+		reads   int
+		readErr error
+	)
+	client := eventingfake.NewSimpleClientset()
+	r := &Reconciler{
+		kubeclient: kubefake.NewSimpleClientset(secret),
+		eventTypes: eventtype.NewReconciler(client),
+		catalogs:   newTestCatalogCache(&reads, &readErr),
+	}
+
+	if err := r.reconcileEventTypes(ctx, vms); err != nil {
+		t.Fatalf("reconcileEventTypes() error = %v", err)
+	}
+
+	list, err := client.EventingV1beta2().EventTypes("default").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]string{}
+	for _, et := range list.Items {
+		if et.Spec.Source.String() != "https://vcenter.example.com" {
+			t.Errorf("eventtype %s source = %s", et.Spec.Type, et.Spec.Source)
+		}
+		if et.Spec.Reference == nil || et.Spec.Reference.Name != "default" || et.Spec.Reference.Namespace != "default" {
+			t.Errorf("eventtype %s reference = %v, want default broker", et.Spec.Type, et.Spec.Reference)
+		}
+		got[et.Spec.Type] = et.Labels[resources.EventCategoryLabel]
+	}
+	want := map[string]string{
+		"com.vmware.vsphere.VmPoweredOnEvent.v0":                 "info",
+		"com.vmware.vsphere.com.vmware.vc.HA.HostFailedEvent.v0": "error",
+	}
+	if len(got) != len(want) || got["com.vmware.vsphere.VmPoweredOnEvent.v0"] != "info" ||
+		got["com.vmware.vsphere.com.vmware.vc.HA.HostFailedEvent.v0"] != "error" {
+		t.Errorf("eventtypes = %v, want %v", got, want)
+	}
+
+	// a custom mapping changes the type and source
+	vms.Spec.CloudEventMapping = &v1alpha1.CloudEventMapping{
+		Type:   "com.example.{{ lower .Class }}",
+		Source: "urn:vcenter:{{ .InstanceUUID }}",
+	}
+	if err := r.reconcileEventTypes(ctx, vms); err != nil {
+		t.Fatalf("reconcileEventTypes() error = %v", err)
+	}
+	list, err = client.EventingV1beta2().EventTypes("default").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Items) != 2 {
+		t.Errorf("eventtypes = %d with custom mapping, want 2", len(list.Items))
+	}
+	for _, et := range list.Items {
+		if et.Spec.Source.String() != "urn:vcenter:"+testInstanceUUID {
+			t.Errorf("eventtype %s source = %s, want instance UUID", et.Spec.Type, et.Spec.Source)
+		}
+	}
+
+	// EventTypes are removed when the sink is no broker
+	vms.Spec.Sink = duckv1.Destination{URI: apis.HTTP("sink.example.com")}
+	if err := r.reconcileEventTypes(ctx, vms); err != nil {
+		t.Fatalf("reconcileEventTypes() error = %v", err)
+	}
+	list, err = client.EventingV1beta2().EventTypes("default").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Items) != 0 {
+		t.Errorf("eventtypes = %d without broker, want 0", len(list.Items))
+	}
+	if reads != 1 {
+		t.Errorf("catalog reads = %d, want 1", reads)
+	}
+}
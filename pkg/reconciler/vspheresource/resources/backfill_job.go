@@ -0,0 +1,134 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resources
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/ptr"
+
+	"github.com/vmware-tanzu/sources-for-knative/pkg/apis/sources/v1alpha1"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/reconciler/vspheresource/resources/names"
+)
+
+const (
+	// BackfillStartAnnotation and BackfillEndAnnotation hold the time range
+	// backfilled by a job
+	BackfillStartAnnotation = "vspheresources.sources.tanzu.vmware.com/backfill-start"
+	BackfillEndAnnotation   = "vspheresources.sources.tanzu.vmware.com/backfill-end"
+	// BackfillTemplateAnnotation holds a hash of the pod template of a job,
+	// since the template cannot be compared with the defaulted one of the
+	// existing job
+	BackfillTemplateAnnotation = "vspheresources.sources.tanzu.vmware.com/backfill-template"
+
+	// backfillBackoffLimit is the number of retries of a backfill Job before
+	// it fails. A retry resumes after the last event delivered before.
+	backfillBackoffLimit = 6
+)
+
+// MakeBackfillJob creates a Job running the adapter once to deliver the events
+// created in the time range of spec.backfill. The adapter does not use the
+// checkpoint store in backfill mode, so the checkpoint volume of the
+// deployment is not mounted. A failed pod is restarted up to
+// backfillBackoffLimit times and resumes from the progress in
+// status.backfill. The job is suspended while the source is.
+func MakeBackfillJob(ctx context.Context, vms *v1alpha1.VSphereSource, args AdapterArgs) (*batchv1.Job, error) {
+	deployment, err := MakeDeployment(ctx, vms, args)
+	if err != nil {
+		return nil, err
+	}
+
+	start := vms.Spec.Backfill.StartTime.UTC().Format(time.RFC3339)
+	end := vms.Spec.Backfill.EndTime.UTC().Format(time.RFC3339)
+
+	template := deployment.Spec.Template
+	template.Labels = map[string]string{
+		"vspheresources.sources.tanzu.vmware.com/backfill": vms.Name,
+	}
+	template.Spec.RestartPolicy = corev1.RestartPolicyOnFailure
+	template.Spec.Volumes = withoutVolume(template.Spec.Volumes, checkpointVolumeName)
+
+	adapter := &template.Spec.Containers[0]
+	adapter.VolumeMounts = withoutVolumeMount(adapter.VolumeMounts, checkpointVolumeName)
+	adapter.Env = append(adapter.Env, corev1.EnvVar{
+		Name:  "VSPHERE_BACKFILL_START",
+		Value: start,
+	}, corev1.EnvVar{
+		Name:  "VSPHERE_BACKFILL_END",
+		Value: end,
+	})
+
+	// The VSphereBinding only injects the credentials into the deployment,
+	// so they are added to the pods of the job here.
+	ps := &duckv1.WithPod{Spec: duckv1.WithPodSpec{Template: duckv1.PodSpecable(template)}}
+	MakeVSphereBinding(ctx, vms).Do(ctx, ps)
+
+	hash, err := templateHash(corev1.PodTemplateSpec(ps.Spec.Template))
+	if err != nil {
+		return nil, err
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            names.BackfillJob(vms),
+			Namespace:       vms.Namespace,
+			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(vms)},
+			Labels:          template.Labels,
+			Annotations: map[string]string{
+				BackfillStartAnnotation:    start,
+				BackfillEndAnnotation:      end,
+				BackfillTemplateAnnotation: hash,
+			},
+		},
+		Spec: batchv1.JobSpec{
+			// a suspended source also pauses its backfill
+			Suspend:      ptr.Bool(vms.Spec.Suspend),
+			BackoffLimit: ptr.Int32(backfillBackoffLimit),
+			Template:     corev1.PodTemplateSpec(ps.Spec.Template),
+		},
+	}, nil
+}
+
+// templateHash returns a hash of the given pod template
+func templateHash(template corev1.PodTemplateSpec) (string, error) {
+	b, err := json.Marshal(template)
+	if err != nil {
+		return "", fmt.Errorf("marshal pod template: %w", err)
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(b)), nil
+}
+
+// withoutVolume returns volumes without the volume with the given name
+func withoutVolume(volumes []corev1.Volume, name string) []corev1.Volume {
+	var result []corev1.Volume
+	for _, v := range volumes {
+		if v.Name != name {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// withoutVolumeMount returns mounts without the mount of the volume with the
+// given name
+func withoutVolumeMount(mounts []corev1.VolumeMount, name string) []corev1.VolumeMount {
+	var result []corev1.VolumeMount
+	for _, m := range mounts {
+		if m.Name != name {
+			result = append(result, m)
+		}
+	}
+	return result
+}
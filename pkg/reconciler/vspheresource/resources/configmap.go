@@ -16,13 +16,38 @@ import (
 	"github.com/vmware-tanzu/sources-for-knative/pkg/reconciler/vspheresource/resources/names"
 )
 
-// MakeConfigMap creates a ConfigMap owned by the VSphereSource
+const (
+	// SourceNameLabel holds the name of the VSphereSource of a retained
+	// checkpoint ConfigMap
+	SourceNameLabel = "vspheresources.sources.tanzu.vmware.com/name"
+	// SourceUIDLabel holds the UID of the VSphereSource using a retained
+	// checkpoint ConfigMap. A VSphereSource sets it to claim the retained
+	// checkpoint of a deleted one before copying it.
+	SourceUIDLabel = "vspheresources.sources.tanzu.vmware.com/uid"
+	// RetainedCheckpointLabel marks checkpoint ConfigMaps which are kept when
+	// their VSphereSource is deleted
+	RetainedCheckpointLabel = "vspheresources.sources.tanzu.vmware.com/retained-checkpoint"
+)
+
+// MakeConfigMap creates a ConfigMap owned by the VSphereSource. With the
+// Retain policy the ConfigMap is labeled instead of owned, so it outlives the
+// VSphereSource.
 func MakeConfigMap(ctx context.Context, vms *v1alpha1.VSphereSource) *corev1.ConfigMap {
-	return &corev1.ConfigMap{
+	cm := &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:            names.ConfigMap(vms),
 			Namespace:       vms.Namespace,
 			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(vms)},
 		},
 	}
+
+	if vms.Spec.CheckpointConfig.RetainPolicy == v1alpha1.VCheckpointRetainPolicyRetain {
+		cm.OwnerReferences = nil
+		cm.Labels = map[string]string{
+			SourceNameLabel:         vms.Name,
+			SourceUIDLabel:          string(vms.UID),
+			RetainedCheckpointLabel: "true",
+		}
+	}
+	return cm
 }
@@ -9,6 +9,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -21,9 +22,16 @@ import (
 
 	"github.com/vmware-tanzu/sources-for-knative/pkg/apis/sources/v1alpha1"
 	"github.com/vmware-tanzu/sources-for-knative/pkg/reconciler/vspheresource/resources/names"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/sinkauth"
 	"github.com/vmware-tanzu/sources-for-knative/pkg/vsphere"
 )
 
+const (
+	checkpointVolumeName = "checkpoint"
+	// must match the directory of the adapter's default VSPHERE_CHECKPOINT_FILE
+	checkpointMountPath = "/var/run/vsphere/checkpoint"
+)
+
 type AdapterArgs struct {
 	Image         string
 	LoggingConfig string
@@ -55,6 +63,56 @@ func MakeDeployment(ctx context.Context, vms *v1alpha1.VSphereSource, args Adapt
 		return nil, fmt.Errorf("marshal checkpoint config: %w", err)
 	}
 
+	var ceMapping string
+	if m := vms.Spec.CloudEventMapping; m != nil {
+		b, err := json.Marshal(m.Config())
+		if err != nil {
+			return nil, fmt.Errorf("marshal CloudEvent mapping: %w", err)
+		}
+		ceMapping = string(b)
+	}
+
+	// objects created before the grace period was defaulted
+	gracePeriod := vsphere.ShutdownDefaultGracePeriod
+	if vms.Spec.ShutdownGracePeriodSeconds > 0 {
+		gracePeriod = time.Second * time.Duration(vms.Spec.ShutdownGracePeriodSeconds)
+	}
+
+	storeType := v1alpha1.VCheckpointStoreConfigMap
+	var (
+		volumes      []corev1.Volume
+		volumeMounts []corev1.VolumeMount
+	)
+	if store := vms.Spec.CheckpointConfig.Store; store != nil {
+		storeType = store.Type
+		if store.Type == v1alpha1.VCheckpointStoreFile {
+			volumes = append(volumes, corev1.Volume{
+				Name: checkpointVolumeName,
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: store.ClaimName,
+					},
+				},
+			})
+			volumeMounts = append(volumeMounts, corev1.VolumeMount{
+				Name:      checkpointVolumeName,
+				MountPath: checkpointMountPath,
+			})
+		}
+	}
+
+	// a token for sinks requiring OIDC authentication
+	if a := vms.Status.SinkAudience; a != nil && *a != "" {
+		volumes = append(volumes, sinkauth.TokenVolume(sinkauth.SinkTokenName, *a))
+		volumeMounts = append(volumeMounts, sinkauth.TokenVolumeMount(sinkauth.SinkTokenName))
+	}
+
+	// a suspended source keeps its checkpoint but runs no adapter
+	var replicas int32 = 1
+	if vms.Spec.Suspend {
+		replicas = 0
+	}
+
 	return &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:            names.Deployment(vms),
@@ -63,7 +121,7 @@ func MakeDeployment(ctx context.Context, vms *v1alpha1.VSphereSource, args Adapt
 			Labels:          labels,
 		},
 		Spec: appsv1.DeploymentSpec{
-			Replicas: ptr.Int32(1),
+			Replicas: ptr.Int32(replicas),
 			Selector: &metav1.LabelSelector{
 				MatchLabels: labels,
 			},
@@ -73,21 +131,28 @@ func MakeDeployment(ctx context.Context, vms *v1alpha1.VSphereSource, args Adapt
 				},
 				Spec: corev1.PodSpec{
 					ServiceAccountName: names.ServiceAccount(vms),
+					// the adapter saves its checkpoint within the grace period
+					// and exits before it is killed
+					TerminationGracePeriodSeconds: ptr.Int64(int64((gracePeriod + vsphere.ShutdownTerminationMargin).Seconds())),
 					Containers: []corev1.Container{{
 						Name:  "adapter",
 						Image: args.Image,
-						Env: []corev1.EnvVar{{
+						// fields defaulted by the API server are set explicitly
+						// to detect drift of the deployment
+						Env: append([]corev1.EnvVar{{
 							Name: "NAMESPACE",
 							ValueFrom: &corev1.EnvVarSource{
 								FieldRef: &corev1.ObjectFieldSelector{
-									FieldPath: "metadata.namespace",
+									APIVersion: "v1",
+									FieldPath:  "metadata.namespace",
 								},
 							},
 						}, {
 							Name: "NAME",
 							ValueFrom: &corev1.EnvVarSource{
 								FieldRef: &corev1.ObjectFieldSelector{
-									FieldPath: "metadata.name",
+									APIVersion: "v1",
+									FieldPath:  "metadata.name",
 								},
 							},
 						}, {
@@ -111,8 +176,35 @@ func MakeDeployment(ctx context.Context, vms *v1alpha1.VSphereSource, args Adapt
 						}, {
 							Name:  "K_SINK",
 							Value: vms.Status.SinkURI.String(),
-						}},
+						}, {
+							Name:  "VSPHERE_SOURCE_NAME",
+							Value: vms.Name,
+						}, {
+							Name:  "VSPHERE_CHECKPOINT_STORE",
+							Value: string(storeType),
+						}, {
+							Name:  "VSPHERE_CHECKPOINT_MISMATCH_POLICY",
+							Value: string(vms.Spec.CheckpointConfig.MismatchPolicy),
+						}, {
+							Name:  "VSPHERE_CE_MAPPING",
+							Value: ceMapping,
+						}, {
+							Name:  "VSPHERE_SHUTDOWN_GRACE_PERIOD",
+							Value: gracePeriod.String(),
+						}, {
+							// unset values fall back to the adapter defaults
+							Name:  "VSPHERE_POLL_MIN_BACKOFF",
+							Value: (time.Second * time.Duration(vms.Spec.Polling.MinBackoffSeconds)).String(),
+						}, {
+							Name:  "VSPHERE_POLL_MAX_BACKOFF",
+							Value: (time.Second * time.Duration(vms.Spec.Polling.MaxBackoffSeconds)).String(),
+						}, {
+							Name:  "VSPHERE_POLL_BATCH_SIZE",
+							Value: strconv.Itoa(int(vms.Spec.Polling.BatchSize)),
+						}}, sinkEnv(vms)...),
+						VolumeMounts: volumeMounts,
 					}},
+					Volumes: volumes,
 				},
 			},
 			Strategy: appsv1.DeploymentStrategy{
@@ -124,3 +216,17 @@ func MakeDeployment(ctx context.Context, vms *v1alpha1.VSphereSource, args Adapt
 		},
 	}, nil
 }
+
+// sinkEnv returns the CA certificates and OIDC audience of the sink of vms.
+// They are only set for TLS and OIDC sinks to leave the adapters of other
+// sources unchanged.
+func sinkEnv(vms *v1alpha1.VSphereSource) []corev1.EnvVar {
+	var env []corev1.EnvVar
+	if c := vms.Status.SinkCACerts; c != nil {
+		env = append(env, corev1.EnvVar{Name: sinkauth.CACertsEnv, Value: *c})
+	}
+	if a := vms.Status.SinkAudience; a != nil && *a != "" {
+		env = append(env, corev1.EnvVar{Name: sinkauth.AudienceEnv, Value: *a})
+	}
+	return env
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resources
+
+import (
+	"fmt"
+
+	"github.com/vmware/govmomi/vim25/types"
+	"knative.dev/eventing/pkg/apis/eventing/v1beta2"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	"github.com/vmware-tanzu/sources-for-knative/pkg/apis/sources/v1alpha1"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/cemapping"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/reconciler/eventtype"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/vsphere"
+)
+
+// EventCategoryLabel is the label with the vCenter event category of an
+// EventType, i.e. info, warning, error or user
+const EventCategoryLabel = "sources.tanzu.vmware.com/event-category"
+
+// MakeEventTypes returns the EventTypes of the vCenter event catalog for the
+// broker, with the CloudEvent type and source of the mapping of vms. Catalog
+// entries whose type cannot be mapped without an event, e.g. because the type
+// template uses the entity, are skipped.
+func MakeEventTypes(vms *v1alpha1.VSphereSource, broker *duckv1.KReference, about types.AboutInfo, catalog []vsphere.EventTypeInfo) ([]*v1beta2.EventType, error) {
+	mapper, err := cemapping.New(vms.Spec.CloudEventMapping.Config(), vsphere.DefaultCloudEventMapping)
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := mapper.Source(cemapping.SourceData{
+		Address:      vms.Spec.Address.String(),
+		InstanceUUID: about.InstanceUuid,
+	})
+	if err != nil {
+		return nil, err
+	}
+	sourceURL, err := apis.ParseURL(source)
+	if err != nil {
+		return nil, fmt.Errorf("parse CloudEvent source %q: %w", source, err)
+	}
+
+	seen := make(map[string]bool, len(catalog))
+	eventTypes := make([]*v1beta2.EventType, 0, len(catalog))
+	for _, info := range catalog {
+		ceType, err := mapper.Type(info.EventData())
+		if err != nil || seen[ceType] {
+			continue
+		}
+		seen[ceType] = true
+
+		et := eventtype.Make(vms, v1beta2.EventTypeSpec{
+			Type:        ceType,
+			Source:      sourceURL,
+			Reference:   broker,
+			Description: info.Description,
+		})
+		if info.Category != "" {
+			et.Labels[EventCategoryLabel] = info.Category
+		}
+		eventTypes = append(eventTypes, et)
+	}
+	return eventTypes, nil
+}
@@ -27,9 +27,23 @@ func RoleBinding(vms *v1alpha1.VSphereSource) string {
 	return kmeta.ChildName(vms.Name, "-rolebinding")
 }
 
+// BackfillJob returns the name of the job backfilling the events of
+// spec.backfill.
+func BackfillJob(vms *v1alpha1.VSphereSource) string {
+	return kmeta.ChildName(vms.Name, "-backfill")
+}
+
+// ServiceAccount returns the name of the service account of the adapter, which
+// is the dedicated one created for vms unless spec.serviceAccountName is set.
 func ServiceAccount(vms *v1alpha1.VSphereSource) string {
 	if vms.Spec.ServiceAccountName == "" {
-		return "default"
+		return DedicatedServiceAccount(vms)
 	}
 	return vms.Spec.ServiceAccountName
 }
+
+// DedicatedServiceAccount returns the name of the service account created for
+// vms when spec.serviceAccountName is not set.
+func DedicatedServiceAccount(vms *v1alpha1.VSphereSource) string {
+	return kmeta.ChildName(vms.Name, "-serviceaccount")
+}
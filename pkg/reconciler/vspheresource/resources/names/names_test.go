@@ -73,6 +73,15 @@ func TestNames(t *testing.T) {
 		},
 		f:    RoleBinding,
 		want: "baz-rolebinding",
+	}, {
+		name: "backfill job",
+		vss: &v1alpha1.VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "baz",
+			},
+		},
+		f:    BackfillJob,
+		want: "baz-backfill",
 	}, {
 		name: "empty service account",
 		vss: &v1alpha1.VSphereSource{
@@ -82,7 +91,7 @@ func TestNames(t *testing.T) {
 			Spec: v1alpha1.VSphereSourceSpec{},
 		},
 		f:    ServiceAccount,
-		want: "default",
+		want: "baz-serviceaccount",
 	}, {
 		name: "custom service account name",
 		vss: &v1alpha1.VSphereSource{
@@ -93,6 +102,16 @@ func TestNames(t *testing.T) {
 		},
 		f:    ServiceAccount,
 		want: "test-svc-acc",
+	}, {
+		name: "dedicated service account",
+		vss: &v1alpha1.VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "baz",
+			},
+			Spec: v1alpha1.VSphereSourceSpec{ServiceAccountName: "test-svc-acc"},
+		},
+		f:    DedicatedServiceAccount,
+		want: "baz-serviceaccount",
 	}}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -15,13 +15,14 @@ import (
 	"knative.dev/pkg/kmeta"
 )
 
-// MakeServiceAccount creates a ServiceAccount object for the Namespace 'ns'.
+// MakeServiceAccount creates the dedicated ServiceAccount of the receive
+// adapter, which is used when vms does not specify a service account.
 func MakeServiceAccount(ctx context.Context, vms *v1alpha1.VSphereSource) *corev1.ServiceAccount {
 	return &corev1.ServiceAccount{
 		ObjectMeta: metav1.ObjectMeta{
 			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(vms)},
 			Namespace:       vms.Namespace,
-			Name:            names.ServiceAccount(vms),
+			Name:            names.DedicatedServiceAccount(vms),
 		},
 	}
 }
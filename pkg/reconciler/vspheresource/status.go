@@ -0,0 +1,65 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vspheresource
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/reconciler"
+
+	sourcesv1alpha1 "github.com/vmware-tanzu/sources-for-knative/pkg/apis/sources/v1alpha1"
+)
+
+// updateStatus writes the status of desired. The fields reported by the
+// adapter are taken from a fresh read of the source in every attempt: an
+// adapter report between the read and the update fails the update with a
+// conflict and is read by the next attempt, so it is never rolled back.
+func (r *Reconciler) updateStatus(ctx context.Context, desired *sourcesv1alpha1.VSphereSource) error {
+	client := r.client.SourcesV1alpha1().VSphereSources(desired.Namespace)
+
+	return reconciler.RetryUpdateConflicts(func(int) error {
+		existing, err := client.Get(ctx, desired.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		status := desired.Status.DeepCopy()
+		status.CopyAdapterStatus(&existing.Status)
+		status.PropagateEventsFlowing(desired.Spec.MaxEventLagSeconds)
+		status.PropagateCheckpointStatus(desired.Spec.CheckpointConfig.MismatchPolicy)
+		if equality.Semantic.DeepEqual(existing.Status, *status) {
+			return nil
+		}
+
+		existing.Status = *status
+		_, err = client.UpdateStatus(ctx, existing, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// checkpointReport returns whether the update of a source from oldObj to newObj
+// only changed the checkpoint saved by the adapter, which is no reason to
+// reconcile the source. Reports of the delivery status are reconciled to
+// update the EventsFlowing condition.
+func checkpointReport(oldObj, newObj interface{}) bool {
+	o, ok := oldObj.(*sourcesv1alpha1.VSphereSource)
+	if !ok {
+		return false
+	}
+	n, ok := newObj.(*sourcesv1alpha1.VSphereSource)
+	if !ok || o.ResourceVersion == n.ResourceVersion {
+		// resyncs are reconciled
+		return false
+	}
+
+	o, n = o.DeepCopy(), n.DeepCopy()
+	o.ResourceVersion, n.ResourceVersion = "", ""
+	o.ManagedFields, n.ManagedFields = nil, nil
+	o.Status.Checkpoint = n.Status.Checkpoint
+	return equality.Semantic.DeepEqual(o, n)
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vspheresource
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgotesting "k8s.io/client-go/testing"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	"github.com/vmware-tanzu/sources-for-knative/pkg/apis/sources/v1alpha1"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/client/clientset/versioned/fake"
+)
+
+func TestReconciler_updateStatus(t *testing.T) {
+	ctx := context.TODO()
+
+	stored := newTestSource()
+	stored.Status.Checkpoint = &runtime.RawExtension{Raw: []byte(`{"lastEventKey":1}`)}
+	stored.Status.Backfill = &v1alpha1.VBackfillStatus{
+		StartTime: metav1.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndTime:   metav1.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+	client := fake.NewSimpleClientset(stored)
+	r := &Reconciler{client: client}
+
+	// the reconciler works on the copy it read before the adapter reports
+	desired := stored.DeepCopy()
+	desired.Status.InitializeConditions()
+	desired.Status.PropagateAuthStatus(duckv1.Status{Conditions: duckv1.Conditions{{Type: apis.ConditionReady, Status: corev1.ConditionTrue}}})
+
+	// the adapter saves a checkpoint, reports a failed delivery, backfill
+	// progress and a checkpoint mismatch between the read of updateStatus and
+	// its update, which fails with a conflict as on the API server
+	adapterCheckpoint := &runtime.RawExtension{Raw: []byte(`{"lastEventKey":2}`)}
+	raced := false
+	client.PrependReactor("update", "vspheresources", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "status" || raced {
+			return false, nil, nil
+		}
+		raced = true
+
+		gvr := v1alpha1.SchemeGroupVersion.WithResource("vspheresources")
+		obj, err := client.Tracker().Get(gvr, stored.Namespace, stored.Name)
+		if err != nil {
+			return true, nil, err
+		}
+		reported := obj.(*v1alpha1.VSphereSource).DeepCopy()
+		reported.Status.Checkpoint = adapterCheckpoint
+		reported.Status.LastError = "sink unavailable"
+		reported.Status.Backfill.EventsSent = 42
+		reported.Status.CheckpointMismatch = "vCenter event keys were reset"
+		if err := client.Tracker().Update(gvr, reported, stored.Namespace); err != nil {
+			return true, nil, err
+		}
+		return true, nil, apierrs.NewConflict(schema.GroupResource{Resource: "vspheresources"}, stored.Name, nil)
+	})
+
+	if err := r.updateStatus(ctx, desired); err != nil {
+		t.Fatalf("updateStatus() error = %v", err)
+	}
+	if !raced {
+		t.Fatal("adapter report did not race the status update")
+	}
+
+	got, err := client.SourcesV1alpha1().VSphereSources(stored.Namespace).Get(ctx, stored.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got.Status.Checkpoint.Raw) != string(adapterCheckpoint.Raw) {
+		t.Errorf("status.checkpoint = %s, want checkpoint of adapter %s", got.Status.Checkpoint.Raw, adapterCheckpoint.Raw)
+	}
+	if got.Status.LastError != "sink unavailable" {
+		t.Errorf("status.lastError = %q, want error reported by adapter", got.Status.LastError)
+	}
+	if got.Status.Backfill.EventsSent != 42 {
+		t.Errorf("status.backfill.eventsSent = %d, want progress reported by adapter", got.Status.Backfill.EventsSent)
+	}
+	if c := got.Status.GetCondition(v1alpha1.VSphereSourceConditionCheckpointValid); c == nil || c.Reason != "CheckpointReset" {
+		t.Errorf("CheckpointValid condition = %v, want reset checkpoint", c)
+	}
+	if c := got.Status.GetCondition(v1alpha1.VSphereSourceConditionEventsFlowing); c == nil || !c.IsFalse() {
+		t.Errorf("EventsFlowing condition = %v, want False", c)
+	}
+	if c := got.Status.GetCondition(v1alpha1.VSphereSourceConditionAuthReady); c == nil || !c.IsTrue() {
+		t.Errorf("AuthReady condition = %v, want True", c)
+	}
+}
+
+func TestAdapterReport(t *testing.T) {
+	old := newTestSource()
+	old.ResourceVersion = "1"
+
+	checkpoint := old.DeepCopy()
+	checkpoint.ResourceVersion = "2"
+	checkpoint.Status.Checkpoint = &runtime.RawExtension{Raw: []byte(`{}`)}
+
+	delivery := checkpoint.DeepCopy()
+	delivery.ResourceVersion = "3"
+	delivery.Status.LastError = "sink unavailable"
+
+	spec := checkpoint.DeepCopy()
+	spec.ResourceVersion = "3"
+	spec.Spec.SecretRef.Name = "vsphere-credentials"
+
+	tests := []struct {
+		name     string
+		old, new *v1alpha1.VSphereSource
+		want     bool
+	}{
+		{name: "resync", old: old, new: old.DeepCopy(), want: false},
+		{name: "checkpoint", old: old, new: checkpoint, want: true},
+		{name: "delivery", old: checkpoint, new: delivery, want: false},
+		{name: "spec", old: checkpoint, new: spec, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := checkpointReport(tt.old, tt.new); got != tt.want {
+				t.Errorf("checkpointReport() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
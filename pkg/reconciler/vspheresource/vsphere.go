@@ -7,17 +7,26 @@ package vspheresource
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes"
 	appsv1listers "k8s.io/client-go/listers/apps/v1"
+	batchv1listers "k8s.io/client-go/listers/batch/v1"
 	corev1Listers "k8s.io/client-go/listers/core/v1"
 	rbacv1listers "k8s.io/client-go/listers/rbac/v1"
 	eventingclientset "knative.dev/eventing/pkg/client/clientset/versioned"
+	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
 	"knative.dev/pkg/metrics"
 	"knative.dev/pkg/reconciler"
@@ -27,8 +36,10 @@ import (
 	clientset "github.com/vmware-tanzu/sources-for-knative/pkg/client/clientset/versioned"
 	vspherereconciler "github.com/vmware-tanzu/sources-for-knative/pkg/client/injection/reconciler/sources/v1alpha1/vspheresource"
 	v1alpha1lister "github.com/vmware-tanzu/sources-for-knative/pkg/client/listers/sources/v1alpha1"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/reconciler/eventtype"
 	"github.com/vmware-tanzu/sources-for-knative/pkg/reconciler/vspheresource/resources"
 	"github.com/vmware-tanzu/sources-for-knative/pkg/reconciler/vspheresource/resources/names"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/vsphere"
 )
 
 const (
@@ -45,11 +56,18 @@ type Reconciler struct {
 	client         clientset.Interface
 
 	deploymentLister     appsv1listers.DeploymentLister
+	jobLister            batchv1listers.JobLister
 	vspherebindingLister v1alpha1lister.VSphereBindingLister
+	vsphereLister        v1alpha1lister.VSphereSourceLister
 	rbacLister           rbacv1listers.RoleBindingLister
 	cmLister             corev1Listers.ConfigMapLister
 	saLister             corev1Listers.ServiceAccountLister
 
+	// register the event types of the vCenter catalog for sink brokers,
+	// disabled if nil
+	eventTypes *eventtype.Reconciler
+	catalogs   *CatalogCache
+
 	loggingContext context.Context
 	adapterImage   string
 	loggingConfig  *logging.Config
@@ -59,8 +77,33 @@ type Reconciler struct {
 // Check that our Reconciler implements Interface
 var _ vspherereconciler.Interface = (*Reconciler)(nil)
 
-// ReconcileKind implements Interface.ReconcileKind.
+// ReconcileKind implements Interface.ReconcileKind. The generated reconciler
+// is configured to skip status updates, since its write back of the status
+// would roll back the fields reported by the adapter, see updateStatus.
 func (r *Reconciler) ReconcileKind(ctx context.Context, vms *sourcesv1alpha1.VSphereSource) reconciler.Event {
+	original := vms.DeepCopy()
+
+	reconciler.PreProcessReconcile(ctx, vms)
+	event := r.reconcile(ctx, vms)
+	reconciler.PostProcessReconcile(ctx, vms, original)
+
+	if !equality.Semantic.DeepEqual(original.Status, vms.Status) {
+		if err := r.updateStatus(ctx, vms); err != nil {
+			return fmt.Errorf("failed to update status: %w", err)
+		}
+	}
+	if event == nil {
+		// check again whether events are flowing once they could lag
+		return controller.NewRequeueAfter(sourcesv1alpha1.MaxEventLag(vms.Spec.MaxEventLagSeconds))
+	}
+	return event
+}
+
+func (r *Reconciler) reconcile(ctx context.Context, vms *sourcesv1alpha1.VSphereSource) reconciler.Event {
+	// Reflect the delivery status reported by the adapter
+	vms.Status.PropagateEventsFlowing(vms.Spec.MaxEventLagSeconds)
+	vms.Status.PropagateCheckpointStatus(vms.Spec.CheckpointConfig.MismatchPolicy)
+
 	if err := r.reconcileVSphereBinding(ctx, vms); err != nil {
 		return err
 	}
@@ -78,15 +121,45 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, vms *sourcesv1alpha1.VSp
 		return err
 	}
 
-	uri, err := r.resolver.URIFromDestinationV1(ctx, vms.Spec.Sink, vms)
+	sink, err := r.resolver.AddressableFromDestinationV1(ctx, vms.Spec.Sink, vms)
 	if err != nil {
 		return err
 	}
-	vms.Status.SinkURI = uri
+	vms.Status.SinkURI = sink.URL
+	vms.Status.SinkCACerts = sink.CACerts
+	vms.Status.SinkAudience = sink.Audience
 
 	if err = r.reconcileDeployment(ctx, vms); err != nil {
 		return err
 	}
+	if err = r.deleteReplacedServiceAccount(ctx, vms); err != nil {
+		return err
+	}
+	if err = r.reconcileBackfill(ctx, vms); err != nil {
+		return err
+	}
+
+	if vms.Spec.Suspend {
+		vms.Status.MarkSuspended()
+	} else {
+		vms.Status.ClearSuspended()
+	}
+
+	if r.eventTypes != nil {
+		// registering event types is best-effort and never fails the
+		// reconciliation of the source
+		switch err = r.reconcileEventTypes(ctx, vms); {
+		case errors.Is(err, errCatalogPending):
+			vms.Status.MarkEventTypesPending("CatalogPending", "%v", err)
+			return controller.NewRequeueAfter(catalogPendingDelay)
+		case err != nil:
+			logging.FromContext(ctx).Warnw("Failed to register event types", zap.Error(err))
+			vms.Status.MarkEventTypesNotRegistered("RegistrationFailed", "%v", err)
+			return controller.NewRequeueAfter(catalogRetryInterval)
+		default:
+			vms.Status.MarkEventTypesRegistered()
+		}
+	}
 	logging.FromContext(ctx).Infof("Reconciled vspheresource %q", vms.Name)
 
 	return nil
@@ -109,11 +182,15 @@ func (r *Reconciler) reconcileVSphereBinding(ctx context.Context, vms *sourcesv1
 	} else {
 		// The vspherebinding exists, but make sure that it has the shape that we expect.
 		desiredVSphereBinding := resources.MakeVSphereBinding(ctx, vms)
-		vspherebinding = vspherebinding.DeepCopy()
-		vspherebinding.Spec = desiredVSphereBinding.Spec
-		vspherebinding, err = r.client.SourcesV1alpha1().VSphereBindings(ns).Update(ctx, vspherebinding, metav1.UpdateOptions{})
-		if err != nil {
-			return fmt.Errorf("failed to create vspherebinding %q: %w", vspherebindingName, err)
+		if !equality.Semantic.DeepEqual(vspherebinding.Spec, desiredVSphereBinding.Spec) {
+			vspherebinding = vspherebinding.DeepCopy()
+			vspherebinding.Spec = desiredVSphereBinding.Spec
+			vspherebinding, err = r.client.SourcesV1alpha1().VSphereBindings(ns).Update(ctx, vspherebinding, metav1.UpdateOptions{})
+			if err != nil {
+				return fmt.Errorf("failed to update vspherebinding %q: %w", vspherebindingName, err)
+			}
+			logging.FromContext(ctx).Infof("Updated vspherebinding %q", vspherebindingName)
+			recordDriftRepaired(ctx, vms, "VSphereBindingUpdated", "Updated vspherebinding %q", vspherebindingName)
 		}
 	}
 
@@ -126,70 +203,364 @@ func (r *Reconciler) reconcileVSphereBinding(ctx context.Context, vms *sourcesv1
 func (r *Reconciler) reconcileConfigMap(ctx context.Context, vms *sourcesv1alpha1.VSphereSource) error {
 	ns := vms.Namespace
 	name := names.ConfigMap(vms)
+	desired := resources.MakeConfigMap(ctx, vms)
 
-	_, err := r.cmLister.ConfigMaps(ns).Get(name)
+	cm, err := r.cmLister.ConfigMaps(ns).Get(name)
 	// Note that we only create the configmap if it does not exist so that we get the
 	// OwnerRefs set up properly so it gets Garbage Collected.
 	if apierrs.IsNotFound(err) {
-		cm := resources.MakeConfigMap(ctx, vms)
-		_, err := r.kubeclient.CoreV1().ConfigMaps(ns).Create(ctx, cm, metav1.CreateOptions{})
+		retained, err := r.findRetainedCheckpoint(vms)
+		if err != nil {
+			return err
+		}
+		if retained != nil {
+			if retained, err = r.claimRetainedCheckpoint(ctx, vms, retained); err != nil {
+				return err
+			}
+			desired.Data = retained.Data
+		}
+
+		_, err = r.kubeclient.CoreV1().ConfigMaps(ns).Create(ctx, desired, metav1.CreateOptions{})
 		if err != nil {
 			return fmt.Errorf("failed to create configmap %q: %w", name, err)
 		}
 		logging.FromContext(ctx).Infof("Created configmap %q", name)
+
+		if retained != nil {
+			// A retained checkpoint is adopted only once.
+			err := r.kubeclient.CoreV1().ConfigMaps(ns).Delete(ctx, retained.Name, metav1.DeleteOptions{
+				Preconditions: metav1.NewUIDPreconditions(string(retained.UID)),
+			})
+			if err != nil && !apierrs.IsNotFound(err) {
+				return fmt.Errorf("failed to delete configmap %q: %w", retained.Name, err)
+			}
+			logging.FromContext(ctx).Infof("Adopted retained checkpoint of configmap %q", retained.Name)
+			if recorder := controller.GetEventRecorder(ctx); recorder != nil {
+				recorder.Eventf(vms, corev1.EventTypeNormal, "CheckpointAdopted",
+					"Adopted retained checkpoint of configmap %q", retained.Name)
+			}
+		}
+		return nil
 	} else if err != nil {
 		return fmt.Errorf("failed to get configmap %q: %w", name, err)
 	}
 
+	owned := metav1.IsControlledBy(cm, vms)
+	if !owned && metav1.GetControllerOf(cm) != nil {
+		return fmt.Errorf("configmap %q is not owned by vspheresource %q", name, vms.Name)
+	}
+
+	// The retain policy decides whether the configmap is owned or labeled.
+	if owned != (len(desired.OwnerReferences) > 0) ||
+		cm.Labels[resources.RetainedCheckpointLabel] != desired.Labels[resources.RetainedCheckpointLabel] ||
+		cm.Labels[resources.SourceUIDLabel] != desired.Labels[resources.SourceUIDLabel] {
+		cm = cm.DeepCopy()
+		if uid := cm.Labels[resources.SourceUIDLabel]; !owned && uid != string(vms.UID) {
+			uids, err := r.sourceUIDs(ns)
+			if err != nil {
+				return err
+			}
+			if uids[uid] {
+				return fmt.Errorf("configmap %q is claimed by another vspheresource", name)
+			}
+			// The retained checkpoint of a deleted vspheresource with the
+			// same name is adopted only for the same vCenter.
+			resetForeignCheckpoint(ctx, vms, cm)
+		}
+		cm.OwnerReferences = desired.OwnerReferences
+		cm.Labels = desired.Labels
+		// The update fails with a conflict if the configmap was claimed by
+		// another vspheresource since it was read.
+		_, err := r.kubeclient.CoreV1().ConfigMaps(ns).Update(ctx, cm, metav1.UpdateOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to update configmap %q: %w", name, err)
+		}
+		logging.FromContext(ctx).Infof("Updated configmap %q", name)
+	}
+
 	return nil
 }
 
-func (r *Reconciler) reconcileServiceAccount(ctx context.Context, vms *sourcesv1alpha1.VSphereSource) error {
+// findRetainedCheckpoint returns the retained checkpoint configmap of a deleted
+// vspheresource for the vCenter of vms with the most recent checkpoint, or nil
+// if there is none.
+func (r *Reconciler) findRetainedCheckpoint(vms *sourcesv1alpha1.VSphereSource) (*corev1.ConfigMap, error) {
 	ns := vms.Namespace
-	serviceAccountName := names.ServiceAccount(vms)
-	_, err := r.saLister.ServiceAccounts(ns).Get(serviceAccountName)
+	selector := labels.SelectorFromSet(labels.Set{resources.RetainedCheckpointLabel: "true"})
+	cms, err := r.cmLister.ConfigMaps(ns).List(selector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list retained checkpoints: %w", err)
+	}
+
+	uids, err := r.sourceUIDs(ns)
 	if err != nil {
-		return fmt.Errorf("failed to get serviceaccount %q: %w", serviceAccountName, err)
+		return nil, err
+	}
+
+	var (
+		found     *corev1.ConfigMap
+		foundTime time.Time
+	)
+	for _, cm := range cms {
+		if metav1.GetControllerOf(cm) != nil {
+			continue
+		}
+		// the checkpoint was claimed by another existing vspheresource
+		if uid := cm.Labels[resources.SourceUIDLabel]; uid != string(vms.UID) && uids[uid] {
+			continue
+		}
+		// the checkpoint of an existing vspheresource is still in use
+		_, err := r.vsphereLister.VSphereSources(ns).Get(cm.Labels[resources.SourceNameLabel])
+		if err == nil {
+			continue
+		} else if !apierrs.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get vspheresource %q: %w", cm.Labels[resources.SourceNameLabel], err)
+		}
+
+		vcenter, last, err := vsphere.ConfigMapCheckpoint(cm.Data)
+		if err != nil || !vsphere.SameVCenter(vcenter, vms.Spec.Address.String()) {
+			continue
+		}
+		if found == nil || last.After(foundTime) {
+			found, foundTime = cm, last
+		}
+	}
+	return found, nil
+}
+
+// claimRetainedCheckpoint labels the retained checkpoint configmap with the UID
+// of vms before its checkpoint is copied. The update fails with a conflict if
+// another vspheresource claimed it since it was read, so a retained checkpoint
+// is only adopted by one vspheresource.
+func (r *Reconciler) claimRetainedCheckpoint(ctx context.Context, vms *sourcesv1alpha1.VSphereSource, cm *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+	if cm.Labels[resources.SourceUIDLabel] == string(vms.UID) {
+		return cm, nil
+	}
+
+	claimed := cm.DeepCopy()
+	claimed.Labels[resources.SourceUIDLabel] = string(vms.UID)
+	claimed, err := r.kubeclient.CoreV1().ConfigMaps(cm.Namespace).Update(ctx, claimed, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim configmap %q: %w", cm.Name, err)
+	}
+	return claimed, nil
+}
+
+// sourceUIDs returns the UIDs of the vspheresources in the namespace
+func (r *Reconciler) sourceUIDs(ns string) (map[string]bool, error) {
+	sources, err := r.vsphereLister.VSphereSources(ns).List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list vspheresources: %w", err)
+	}
+	uids := make(map[string]bool, len(sources))
+	for _, src := range sources {
+		uids[string(src.UID)] = true
+	}
+	return uids, nil
+}
+
+// resetForeignCheckpoint removes the checkpoint of a vCenter other than the one
+// of vms from the configmap.
+func resetForeignCheckpoint(ctx context.Context, vms *sourcesv1alpha1.VSphereSource, cm *corev1.ConfigMap) {
+	vcenter, _, err := vsphere.ConfigMapCheckpoint(cm.Data)
+	if err != nil || vsphere.SameVCenter(vcenter, vms.Spec.Address.String()) {
+		return
+	}
+
+	cm.Data = nil
+	logging.FromContext(ctx).Infof("Reset checkpoint of vCenter %q in configmap %q", vcenter, cm.Name)
+	if recorder := controller.GetEventRecorder(ctx); recorder != nil {
+		recorder.Eventf(vms, corev1.EventTypeWarning, "CheckpointReset",
+			"Reset retained checkpoint of vCenter %q in configmap %q", vcenter, cm.Name)
+	}
+}
+
+func (r *Reconciler) reconcileServiceAccount(ctx context.Context, vms *sourcesv1alpha1.VSphereSource) error {
+	ns := vms.Namespace
+
+	if vms.Spec.ServiceAccountName != "" {
+		// The adapter runs as the specified service account. The dedicated
+		// one that may have been created before is deleted once the adapter
+		// was rolled out, see deleteReplacedServiceAccount.
+		name := vms.Spec.ServiceAccountName
+		if _, err := r.saLister.ServiceAccounts(ns).Get(name); err != nil {
+			return fmt.Errorf("failed to get serviceaccount %q: %w", name, err)
+		}
+		return nil
+	}
+
+	name := names.DedicatedServiceAccount(vms)
+	sa, err := r.saLister.ServiceAccounts(ns).Get(name)
+	if apierrs.IsNotFound(err) {
+		sa = resources.MakeServiceAccount(ctx, vms)
+		_, err := r.kubeclient.CoreV1().ServiceAccounts(ns).Create(ctx, sa, metav1.CreateOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to create serviceaccount %q: %w", name, err)
+		}
+		logging.FromContext(ctx).Infof("Created serviceaccount %q", name)
+	} else if err != nil {
+		return fmt.Errorf("failed to get serviceaccount %q: %w", name, err)
+	} else if !metav1.IsControlledBy(sa, vms) {
+		return fmt.Errorf("serviceaccount %q is not owned by vspheresource %q", name, vms.Name)
+	}
+
+	return nil
+}
+
+// deleteReplacedServiceAccount deletes the dedicated service account once all
+// adapter pods run as spec.serviceAccountName, so that the pods of the
+// previous rollout keep their credentials until they are replaced.
+func (r *Reconciler) deleteReplacedServiceAccount(ctx context.Context, vms *sourcesv1alpha1.VSphereSource) error {
+	if vms.Spec.ServiceAccountName == "" {
+		return nil
+	}
+
+	name := names.Deployment(vms)
+	deployment, err := r.deploymentLister.Deployments(vms.Namespace).Get(name)
+	if apierrs.IsNotFound(err) {
+		// a deployment created in this reconcile is not listed yet
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to get deployment %q: %w", name, err)
 	}
+	if !rolledOut(deployment, vms.Spec.ServiceAccountName) {
+		return nil
+	}
+	return r.deleteDedicatedServiceAccount(ctx, vms)
+}
+
+// rolledOut returns whether all pods of the deployment run the current
+// template with the given service account.
+func rolledOut(d *appsv1.Deployment, serviceAccount string) bool {
+	replicas := int32(1)
+	if d.Spec.Replicas != nil {
+		replicas = *d.Spec.Replicas
+	}
+	return d.Spec.Template.Spec.ServiceAccountName == serviceAccount &&
+		d.Status.ObservedGeneration >= d.Generation &&
+		d.Status.UpdatedReplicas == replicas && d.Status.Replicas == replicas
+}
+
+func (r *Reconciler) deleteDedicatedServiceAccount(ctx context.Context, vms *sourcesv1alpha1.VSphereSource) error {
+	ns := vms.Namespace
+	name := names.DedicatedServiceAccount(vms)
+
+	sa, err := r.saLister.ServiceAccounts(ns).Get(name)
+	if apierrs.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to get serviceaccount %q: %w", name, err)
+	}
+	// Never delete a service account we did not create.
+	if !metav1.IsControlledBy(sa, vms) {
+		return nil
+	}
+
+	err = r.kubeclient.CoreV1().ServiceAccounts(ns).Delete(ctx, name, metav1.DeleteOptions{
+		Preconditions: metav1.NewUIDPreconditions(string(sa.UID)),
+	})
+	if err != nil && !apierrs.IsNotFound(err) {
+		return fmt.Errorf("failed to delete serviceaccount %q: %w", name, err)
+	}
+	logging.FromContext(ctx).Infof("Deleted serviceaccount %q", name)
 	return nil
 }
 
 func (r *Reconciler) reconcileRoleBinding(ctx context.Context, vms *sourcesv1alpha1.VSphereSource) error {
 	ns := vms.Namespace
 	name := names.RoleBinding(vms)
-	_, err := r.rbacLister.RoleBindings(ns).Get(name)
+	desired := resources.MakeRoleBinding(ctx, vms)
+
+	// The pods running as the dedicated service account keep access to the
+	// checkpoint until it is deleted, see deleteReplacedServiceAccount.
+	if vms.Spec.ServiceAccountName != "" {
+		dedicated := names.DedicatedServiceAccount(vms)
+		sa, err := r.saLister.ServiceAccounts(ns).Get(dedicated)
+		if err != nil && !apierrs.IsNotFound(err) {
+			return fmt.Errorf("failed to get serviceaccount %q: %w", dedicated, err)
+		}
+		if sa != nil && metav1.IsControlledBy(sa, vms) {
+			desired.Subjects = append(desired.Subjects, rbacv1.Subject{
+				Kind:      rbacv1.ServiceAccountKind,
+				Namespace: ns,
+				Name:      dedicated,
+			})
+		}
+	}
+
+	roleBinding, err := r.rbacLister.RoleBindings(ns).Get(name)
 	if apierrs.IsNotFound(err) {
-		roleBinding := resources.MakeRoleBinding(ctx, vms)
-		_, err := r.kubeclient.RbacV1().RoleBindings(ns).Create(ctx, roleBinding, metav1.CreateOptions{})
+		_, err := r.kubeclient.RbacV1().RoleBindings(ns).Create(ctx, desired, metav1.CreateOptions{})
 		if err != nil {
 			return fmt.Errorf("failed to create rolebinding %q: %w", name, err)
 		}
 		logging.FromContext(ctx).Infof("Created rolebinding %q", name)
+		return nil
 	} else if err != nil {
 		return fmt.Errorf("failed to get rolebinding %q: %w", name, err)
 	}
-	// TODO: diff the roleref / subjects and update as necessary.
+
+	if !metav1.IsControlledBy(roleBinding, vms) {
+		return fmt.Errorf("rolebinding %q is not owned by vspheresource %q", name, vms.Name)
+	}
+
+	if !equality.Semantic.DeepEqual(roleBinding.RoleRef, desired.RoleRef) {
+		// The roleRef of a rolebinding cannot be updated, so recreate it.
+		err := r.kubeclient.RbacV1().RoleBindings(ns).Delete(ctx, name, metav1.DeleteOptions{
+			Preconditions: metav1.NewUIDPreconditions(string(roleBinding.UID)),
+		})
+		if err != nil && !apierrs.IsNotFound(err) {
+			return fmt.Errorf("failed to delete rolebinding %q: %w", name, err)
+		}
+		_, err = r.kubeclient.RbacV1().RoleBindings(ns).Create(ctx, desired, metav1.CreateOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to create rolebinding %q: %w", name, err)
+		}
+		logging.FromContext(ctx).Infof("Recreated rolebinding %q", name)
+		recordDriftRepaired(ctx, vms, "RoleBindingRecreated", "Recreated rolebinding %q", name)
+	} else if !equality.Semantic.DeepEqual(roleBinding.Subjects, desired.Subjects) {
+		// Replacing the subjects also removes the dedicated service account
+		// once it was deleted after spec.serviceAccountName changed.
+		roleBinding = roleBinding.DeepCopy()
+		roleBinding.Subjects = desired.Subjects
+		_, err := r.kubeclient.RbacV1().RoleBindings(ns).Update(ctx, roleBinding, metav1.UpdateOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to update rolebinding %q: %w", name, err)
+		}
+		logging.FromContext(ctx).Infof("Updated rolebinding %q", name)
+		recordDriftRepaired(ctx, vms, "RoleBindingUpdated", "Updated rolebinding %q", name)
+	}
+
 	return nil
 }
 
-func (r *Reconciler) reconcileDeployment(ctx context.Context, vms *sourcesv1alpha1.VSphereSource) error {
-	ns := vms.Namespace
-	deploymentName := names.Deployment(vms)
-
+// adapterArgs returns the arguments of the adapter deployment and backfill
+// job.
+func (r *Reconciler) adapterArgs() (resources.AdapterArgs, error) {
 	loggingConfig, err := logging.ConfigToJSON(r.loggingConfig)
 	if err != nil {
-		return fmt.Errorf("marshal logging config to JSON: %w", err)
+		return resources.AdapterArgs{}, fmt.Errorf("marshal logging config to JSON: %w", err)
 	}
 
 	metricsConfig, err := metrics.OptionsToJSON(r.metricsConfig)
 	if err != nil {
-		return fmt.Errorf("marshal metrics config to JSON: %w", err)
+		return resources.AdapterArgs{}, fmt.Errorf("marshal metrics config to JSON: %w", err)
 	}
 
-	args := resources.AdapterArgs{
+	return resources.AdapterArgs{
 		Image:         r.adapterImage,
 		LoggingConfig: loggingConfig,
 		MetricsConfig: metricsConfig,
+	}, nil
+}
+
+func (r *Reconciler) reconcileDeployment(ctx context.Context, vms *sourcesv1alpha1.VSphereSource) error {
+	ns := vms.Namespace
+	deploymentName := names.Deployment(vms)
+
+	args, err := r.adapterArgs()
+	if err != nil {
+		return err
 	}
 
 	deployment, err := r.deploymentLister.Deployments(ns).Get(deploymentName)
@@ -213,13 +584,16 @@ func (r *Reconciler) reconcileDeployment(ctx context.Context, vms *sourcesv1alph
 			return fmt.Errorf("failed to create deployment %q: %w", deploymentName, err)
 		}
 
-		deployment = deployment.DeepCopy()
-		deployment.Spec = desiredDeployment.Spec
-		deployment, err = r.kubeclient.AppsV1().Deployments(ns).Update(ctx, deployment, metav1.UpdateOptions{})
-		if err != nil {
-			return fmt.Errorf("failed to create deployment %q: %w", deploymentName, err)
+		if deploymentDrifted(ctx, vms, desiredDeployment, deployment) {
+			deployment = deployment.DeepCopy()
+			deployment.Spec = desiredDeployment.Spec
+			deployment, err = r.kubeclient.AppsV1().Deployments(ns).Update(ctx, deployment, metav1.UpdateOptions{})
+			if err != nil {
+				return fmt.Errorf("failed to update deployment %q: %w", deploymentName, err)
+			}
+			logging.FromContext(ctx).Infof("Updated deployment %q", deploymentName)
+			recordDriftRepaired(ctx, vms, "DeploymentUpdated", "Updated deployment %q", deploymentName)
 		}
-		logging.FromContext(ctx).Infof("Updated deployment %q", deploymentName)
 	}
 
 	// Reflect the state of the Adapter Deployment in the VSphereSource
@@ -228,6 +602,115 @@ func (r *Reconciler) reconcileDeployment(ctx context.Context, vms *sourcesv1alph
 	return nil
 }
 
+func (r *Reconciler) reconcileBackfill(ctx context.Context, vms *sourcesv1alpha1.VSphereSource) error {
+	ns := vms.Namespace
+	name := names.BackfillJob(vms)
+
+	job, err := r.jobLister.Jobs(ns).Get(name)
+	if err != nil && !apierrs.IsNotFound(err) {
+		return fmt.Errorf("failed to get job %q: %w", name, err)
+	}
+	if job != nil && !metav1.IsControlledBy(job, vms) {
+		return fmt.Errorf("job %q is not owned by vspheresource %q", name, vms.Name)
+	}
+
+	backfill := vms.Spec.Backfill
+	if backfill == nil {
+		vms.Status.ClearBackfill()
+		if job != nil {
+			return r.deleteBackfillJob(ctx, job)
+		}
+		return nil
+	}
+
+	// The progress reported by the adapter is reset for a new time range.
+	if status := vms.Status.Backfill; status == nil ||
+		!status.StartTime.Equal(&backfill.StartTime) || !status.EndTime.Equal(&backfill.EndTime) {
+		vms.Status.Backfill = &sourcesv1alpha1.VBackfillStatus{
+			StartTime: backfill.StartTime,
+			EndTime:   backfill.EndTime,
+		}
+	}
+
+	args, err := r.adapterArgs()
+	if err != nil {
+		return err
+	}
+	desired, err := resources.MakeBackfillJob(ctx, vms, args)
+	if err != nil {
+		return fmt.Errorf("failed to create job %q: %w", name, err)
+	}
+
+	if job != nil && backfillJobChanged(desired, job) {
+		// The pod template of a job cannot be updated, so a changed time
+		// range or configuration of the adapter, e.g. its sink, replaces the
+		// job.
+		if err := r.deleteBackfillJob(ctx, job); err != nil {
+			return err
+		}
+		job = nil
+	}
+
+	if job == nil {
+		job, err = r.kubeclient.BatchV1().Jobs(ns).Create(ctx, desired, metav1.CreateOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to create job %q: %w", name, err)
+		}
+		logging.FromContext(ctx).Infof("Created job %q", name)
+	} else if !equality.Semantic.DeepEqual(job.Spec.Suspend, desired.Spec.Suspend) {
+		job = job.DeepCopy()
+		job.Spec.Suspend = desired.Spec.Suspend
+		job, err = r.kubeclient.BatchV1().Jobs(ns).Update(ctx, job, metav1.UpdateOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to update job %q: %w", name, err)
+		}
+		logging.FromContext(ctx).Infof("Updated job %q", name)
+	}
+
+	// Reflect the state of the backfill Job in the VSphereSource
+	vms.Status.PropagateBackfillStatus(job.Status)
+
+	return nil
+}
+
+// backfillJobChanged returns whether the existing job backfills another time
+// range than the desired one, or runs another pod template before it finished.
+// A finished backfill of the same range is not repeated for a changed
+// template.
+func backfillJobChanged(desired, existing *batchv1.Job) bool {
+	for _, key := range []string{resources.BackfillStartAnnotation, resources.BackfillEndAnnotation} {
+		if existing.Annotations[key] != desired.Annotations[key] {
+			return true
+		}
+	}
+	key := resources.BackfillTemplateAnnotation
+	return existing.Annotations[key] != desired.Annotations[key] && !jobFinished(existing)
+}
+
+// jobFinished returns whether the job completed or failed
+func jobFinished(job *batchv1.Job) bool {
+	for _, cond := range job.Status.Conditions {
+		if (cond.Type == batchv1.JobComplete || cond.Type == batchv1.JobFailed) && cond.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Reconciler) deleteBackfillJob(ctx context.Context, job *batchv1.Job) error {
+	// The pods of the job are deleted by the garbage collector.
+	propagation := metav1.DeletePropagationBackground
+	err := r.kubeclient.BatchV1().Jobs(job.Namespace).Delete(ctx, job.Name, metav1.DeleteOptions{
+		Preconditions:     metav1.NewUIDPreconditions(string(job.UID)),
+		PropagationPolicy: &propagation,
+	})
+	if err != nil && !apierrs.IsNotFound(err) {
+		return fmt.Errorf("failed to delete job %q: %w", job.Name, err)
+	}
+	logging.FromContext(ctx).Infof("Deleted job %q", job.Name)
+	return nil
+}
+
 func (r *Reconciler) UpdateFromLoggingConfigMap(cfg *corev1.ConfigMap) {
 	if cfg != nil {
 		delete(cfg.Data, "_example")
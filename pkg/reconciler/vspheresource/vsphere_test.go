@@ -0,0 +1,538 @@
+/*
+Copyright 2020 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vspheresource
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	appsv1listers "k8s.io/client-go/listers/apps/v1"
+	batchv1listers "k8s.io/client-go/listers/batch/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	rbacv1listers "k8s.io/client-go/listers/rbac/v1"
+	clientgotesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/ptr"
+
+	"github.com/vmware-tanzu/sources-for-knative/pkg/apis/sources/v1alpha1"
+	v1alpha1lister "github.com/vmware-tanzu/sources-for-knative/pkg/client/listers/sources/v1alpha1"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/reconciler/vspheresource/resources"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/reconciler/vspheresource/resources/names"
+)
+
+func newTestSource() *v1alpha1.VSphereSource {
+	return &v1alpha1.VSphereSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "vsphere-01",
+			Namespace: "default",
+			UID:       "1234",
+		},
+	}
+}
+
+// newTestReconciler returns a Reconciler whose listers are populated with objs
+func newTestReconciler(t *testing.T, objs ...runtime.Object) *Reconciler {
+	t.Helper()
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, obj := range objs {
+		if err := indexer.Add(obj); err != nil {
+			t.Fatalf("add %T to indexer: %v", obj, err)
+		}
+	}
+
+	return &Reconciler{
+		kubeclient:       kubefake.NewSimpleClientset(objs...),
+		deploymentLister: appsv1listers.NewDeploymentLister(indexer),
+		saLister:         corev1listers.NewServiceAccountLister(indexer),
+		rbacLister:       rbacv1listers.NewRoleBindingLister(indexer),
+		jobLister:        batchv1listers.NewJobLister(indexer),
+		cmLister:         corev1listers.NewConfigMapLister(indexer),
+	}
+}
+
+func TestReconciler_reconcileServiceAccount(t *testing.T) {
+	ctx := context.TODO()
+
+	t.Run("creates dedicated service account", func(t *testing.T) {
+		vms := newTestSource()
+		r := newTestReconciler(t)
+
+		if err := r.reconcileServiceAccount(ctx, vms); err != nil {
+			t.Fatalf("reconcileServiceAccount() error = %v", err)
+		}
+
+		sa, err := r.kubeclient.CoreV1().ServiceAccounts(vms.Namespace).Get(ctx, "vsphere-01-serviceaccount", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("get serviceaccount: %v", err)
+		}
+		if !metav1.IsControlledBy(sa, vms) {
+			t.Errorf("serviceaccount is not controlled by the source: %v", sa.OwnerReferences)
+		}
+	})
+
+	t.Run("foreign service account", func(t *testing.T) {
+		vms := newTestSource()
+		sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{
+			Namespace: vms.Namespace,
+			Name:      names.DedicatedServiceAccount(vms),
+		}}
+		r := newTestReconciler(t, sa)
+
+		if err := r.reconcileServiceAccount(ctx, vms); err == nil {
+			t.Error("reconcileServiceAccount() error = nil, want not owned error")
+		}
+	})
+
+	t.Run("custom service account does not exist", func(t *testing.T) {
+		vms := newTestSource()
+		vms.Spec.ServiceAccountName = "custom-sa"
+		r := newTestReconciler(t)
+
+		if err := r.reconcileServiceAccount(ctx, vms); !apierrs.IsNotFound(err) {
+			t.Errorf("reconcileServiceAccount() error = %v, want not found", err)
+		}
+	})
+
+	t.Run("custom service account keeps dedicated one", func(t *testing.T) {
+		vms := newTestSource()
+		vms.Spec.ServiceAccountName = "custom-sa"
+		custom := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{
+			Namespace: vms.Namespace,
+			Name:      "custom-sa",
+		}}
+		r := newTestReconciler(t, custom, resources.MakeServiceAccount(ctx, vms))
+
+		if err := r.reconcileServiceAccount(ctx, vms); err != nil {
+			t.Fatalf("reconcileServiceAccount() error = %v", err)
+		}
+
+		// the adapter still runs as the dedicated service account
+		if _, err := r.kubeclient.CoreV1().ServiceAccounts(vms.Namespace).Get(ctx, names.DedicatedServiceAccount(vms), metav1.GetOptions{}); err != nil {
+			t.Errorf("get dedicated serviceaccount error = %v, want kept", err)
+		}
+	})
+}
+
+func TestReconciler_deleteReplacedServiceAccount(t *testing.T) {
+	ctx := context.TODO()
+
+	// newDeployment returns an adapter deployment with the given service
+	// account, of whose replicas pods updated run the current template
+	newDeployment := func(vms *v1alpha1.VSphereSource, serviceAccount string, replicas, updated int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:  vms.Namespace,
+				Name:       names.Deployment(vms),
+				Generation: 2,
+			},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: ptr.Int32(1),
+				Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{ServiceAccountName: serviceAccount}},
+			},
+			Status: appsv1.DeploymentStatus{
+				ObservedGeneration: 2,
+				Replicas:           replicas,
+				UpdatedReplicas:    updated,
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		deployment  func(vms *v1alpha1.VSphereSource) *appsv1.Deployment
+		wantDeleted bool
+	}{{
+		name: "deployment not updated",
+		deployment: func(vms *v1alpha1.VSphereSource) *appsv1.Deployment {
+			return newDeployment(vms, names.DedicatedServiceAccount(vms), 1, 1)
+		},
+	}, {
+		name: "rollout in progress",
+		deployment: func(vms *v1alpha1.VSphereSource) *appsv1.Deployment {
+			return newDeployment(vms, "custom-sa", 2, 1)
+		},
+	}, {
+		name: "rollout complete",
+		deployment: func(vms *v1alpha1.VSphereSource) *appsv1.Deployment {
+			return newDeployment(vms, "custom-sa", 1, 1)
+		},
+		wantDeleted: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vms := newTestSource()
+			vms.Spec.ServiceAccountName = "custom-sa"
+			r := newTestReconciler(t, tt.deployment(vms), resources.MakeServiceAccount(ctx, vms))
+
+			if err := r.deleteReplacedServiceAccount(ctx, vms); err != nil {
+				t.Fatalf("deleteReplacedServiceAccount() error = %v", err)
+			}
+
+			_, err := r.kubeclient.CoreV1().ServiceAccounts(vms.Namespace).Get(ctx, names.DedicatedServiceAccount(vms), metav1.GetOptions{})
+			if deleted := apierrs.IsNotFound(err); deleted != tt.wantDeleted {
+				t.Errorf("dedicated serviceaccount deleted = %v (error %v), want %v", deleted, err, tt.wantDeleted)
+			}
+		})
+	}
+}
+
+func TestReconciler_reconcileRoleBinding(t *testing.T) {
+	ctx := context.TODO()
+
+	tests := []struct {
+		name    string
+		mutate  func(rb *rbacv1.RoleBinding)
+		wantErr bool
+	}{{
+		name:   "unchanged",
+		mutate: func(rb *rbacv1.RoleBinding) {},
+	}, {
+		name: "stale subjects",
+		mutate: func(rb *rbacv1.RoleBinding) {
+			rb.Subjects = []rbacv1.Subject{{
+				Kind:      "ServiceAccount",
+				Namespace: rb.Namespace,
+				Name:      "default",
+			}, {
+				Kind:      "ServiceAccount",
+				Namespace: rb.Namespace,
+				Name:      "old-sa",
+			}}
+		},
+	}, {
+		name: "changed roleRef",
+		mutate: func(rb *rbacv1.RoleBinding) {
+			rb.RoleRef.Name = "cluster-admin"
+		},
+	}, {
+		name: "not owned",
+		mutate: func(rb *rbacv1.RoleBinding) {
+			rb.OwnerReferences = nil
+		},
+		wantErr: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vms := newTestSource()
+			vms.Spec.ServiceAccountName = "custom-sa"
+
+			existing := resources.MakeRoleBinding(ctx, vms)
+			tt.mutate(existing)
+			r := newTestReconciler(t, existing)
+
+			err := r.reconcileRoleBinding(ctx, vms)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("reconcileRoleBinding() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("reconcileRoleBinding() error = %v", err)
+			}
+
+			got, err := r.kubeclient.RbacV1().RoleBindings(vms.Namespace).Get(ctx, names.RoleBinding(vms), metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("get rolebinding: %v", err)
+			}
+			want := resources.MakeRoleBinding(ctx, vms)
+			if diff := cmp.Diff(want.RoleRef, got.RoleRef); diff != "" {
+				t.Errorf("roleRef (-want, +got) = %v", diff)
+			}
+			if diff := cmp.Diff(want.Subjects, got.Subjects); diff != "" {
+				t.Errorf("subjects (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
+func TestReconciler_reconcileRoleBindingCreated(t *testing.T) {
+	ctx := context.TODO()
+	vms := newTestSource()
+	r := newTestReconciler(t)
+
+	if err := r.reconcileRoleBinding(ctx, vms); err != nil {
+		t.Fatalf("reconcileRoleBinding() error = %v", err)
+	}
+
+	got, err := r.kubeclient.RbacV1().RoleBindings(vms.Namespace).Get(ctx, names.RoleBinding(vms), metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("get rolebinding: %v", err)
+	}
+	if len(got.Subjects) != 1 || got.Subjects[0].Name != "vsphere-01-serviceaccount" {
+		t.Errorf("subjects = %v, want the dedicated service account", got.Subjects)
+	}
+}
+
+func TestReconciler_reconcileRoleBindingReplacedServiceAccount(t *testing.T) {
+	ctx := context.TODO()
+	vms := newTestSource()
+	vms.Spec.ServiceAccountName = "custom-sa"
+	r := newTestReconciler(t, resources.MakeServiceAccount(ctx, vms))
+
+	if err := r.reconcileRoleBinding(ctx, vms); err != nil {
+		t.Fatalf("reconcileRoleBinding() error = %v", err)
+	}
+
+	got, err := r.kubeclient.RbacV1().RoleBindings(vms.Namespace).Get(ctx, names.RoleBinding(vms), metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("get rolebinding: %v", err)
+	}
+	// the pods of the previous rollout keep access until the dedicated
+	// service account is deleted
+	if len(got.Subjects) != 2 || got.Subjects[0].Name != "custom-sa" || got.Subjects[1].Name != "vsphere-01-serviceaccount" {
+		t.Errorf("subjects = %v, want the custom and the dedicated service account", got.Subjects)
+	}
+}
+
+// newSourceLister returns a VSphereSourceLister populated with srcs
+func newSourceLister(t *testing.T, srcs ...*v1alpha1.VSphereSource) v1alpha1lister.VSphereSourceLister {
+	t.Helper()
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, src := range srcs {
+		if err := indexer.Add(src); err != nil {
+			t.Fatalf("add vspheresource to indexer: %v", err)
+		}
+	}
+	return v1alpha1lister.NewVSphereSourceLister(indexer)
+}
+
+// newRetainedCheckpoint returns a retained checkpoint configmap of the deleted
+// source with the given name
+func newRetainedCheckpoint(source, vcenter string, last time.Time) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      source + "-configmap",
+			UID:       types.UID(source),
+			Labels: map[string]string{
+				resources.SourceNameLabel:         source,
+				resources.RetainedCheckpointLabel: "true",
+			},
+		},
+		Data: map[string]string{
+			"checkpoint": fmt.Sprintf(`{"vCenter":%q,"lastEventKey":1,"lastEventKeyTimestamp":%q}`,
+				vcenter, last.Format(time.RFC3339)),
+		},
+	}
+}
+
+func TestReconciler_reconcileConfigMap(t *testing.T) {
+	ctx := context.TODO()
+	address := apis.URL{Scheme: "https", Host: "vcenter.local"}
+	last := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("creates owned configmap", func(t *testing.T) {
+		vms := newTestSource()
+		r := newTestReconciler(t)
+		r.vsphereLister = newSourceLister(t)
+
+		if err := r.reconcileConfigMap(ctx, vms); err != nil {
+			t.Fatalf("reconcileConfigMap() error = %v", err)
+		}
+
+		cm, err := r.kubeclient.CoreV1().ConfigMaps(vms.Namespace).Get(ctx, "vsphere-01-configmap", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("get configmap: %v", err)
+		}
+		if !metav1.IsControlledBy(cm, vms) {
+			t.Errorf("configmap is not controlled by the source: %v", cm.OwnerReferences)
+		}
+	})
+
+	t.Run("creates retained configmap", func(t *testing.T) {
+		vms := newTestSource()
+		vms.Spec.CheckpointConfig.RetainPolicy = v1alpha1.VCheckpointRetainPolicyRetain
+		r := newTestReconciler(t)
+		r.vsphereLister = newSourceLister(t)
+
+		if err := r.reconcileConfigMap(ctx, vms); err != nil {
+			t.Fatalf("reconcileConfigMap() error = %v", err)
+		}
+
+		cm, err := r.kubeclient.CoreV1().ConfigMaps(vms.Namespace).Get(ctx, "vsphere-01-configmap", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("get configmap: %v", err)
+		}
+		if len(cm.OwnerReferences) != 0 {
+			t.Errorf("retained configmap has owner references: %v", cm.OwnerReferences)
+		}
+		if cm.Labels[resources.RetainedCheckpointLabel] != "true" || cm.Labels[resources.SourceNameLabel] != vms.Name {
+			t.Errorf("retained configmap labels = %v", cm.Labels)
+		}
+	})
+
+	t.Run("adopts retained checkpoint", func(t *testing.T) {
+		vms := newTestSource()
+		vms.Spec.Address = address
+		live := newTestSource()
+		live.Name = "live"
+
+		older := newRetainedCheckpoint("older", "https://vcenter.local/sdk", last.Add(-time.Hour))
+		latest := newRetainedCheckpoint("latest", "https://vcenter.local/sdk", last)
+		other := newRetainedCheckpoint("other", "https://other.local/sdk", last.Add(time.Hour))
+		inUse := newRetainedCheckpoint("live", "https://vcenter.local/sdk", last.Add(time.Hour))
+		r := newTestReconciler(t, older, latest, other, inUse)
+		r.vsphereLister = newSourceLister(t, live)
+
+		if err := r.reconcileConfigMap(ctx, vms); err != nil {
+			t.Fatalf("reconcileConfigMap() error = %v", err)
+		}
+
+		cm, err := r.kubeclient.CoreV1().ConfigMaps(vms.Namespace).Get(ctx, "vsphere-01-configmap", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("get configmap: %v", err)
+		}
+		if diff := cmp.Diff(latest.Data, cm.Data); diff != "" {
+			t.Errorf("adopted checkpoint (-want, +got) = %s", diff)
+		}
+		_, err = r.kubeclient.CoreV1().ConfigMaps(vms.Namespace).Get(ctx, latest.Name, metav1.GetOptions{})
+		if !apierrs.IsNotFound(err) {
+			t.Errorf("get adopted configmap error = %v, want not found", err)
+		}
+		for _, kept := range []*corev1.ConfigMap{older, other, inUse} {
+			if _, err := r.kubeclient.CoreV1().ConfigMaps(vms.Namespace).Get(ctx, kept.Name, metav1.GetOptions{}); err != nil {
+				t.Errorf("get configmap %q: %v", kept.Name, err)
+			}
+		}
+	})
+
+	t.Run("skips claimed retained checkpoint", func(t *testing.T) {
+		vms := newTestSource()
+		vms.Spec.Address = address
+		claimer := newTestSource()
+		claimer.Name, claimer.UID = "claimer", "5678"
+
+		claimed := newRetainedCheckpoint("deleted", "https://vcenter.local/sdk", last)
+		claimed.Labels[resources.SourceUIDLabel] = string(claimer.UID)
+		r := newTestReconciler(t, claimed)
+		r.vsphereLister = newSourceLister(t, claimer)
+
+		if err := r.reconcileConfigMap(ctx, vms); err != nil {
+			t.Fatalf("reconcileConfigMap() error = %v", err)
+		}
+		cm, err := r.kubeclient.CoreV1().ConfigMaps(vms.Namespace).Get(ctx, "vsphere-01-configmap", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("get configmap: %v", err)
+		}
+		if len(cm.Data) != 0 {
+			t.Errorf("configmap data = %v, want claimed checkpoint not to be copied", cm.Data)
+		}
+	})
+
+	t.Run("claim conflict", func(t *testing.T) {
+		vms := newTestSource()
+		vms.Spec.Address = address
+		retained := newRetainedCheckpoint("deleted", "https://vcenter.local/sdk", last)
+		r := newTestReconciler(t, retained)
+		r.vsphereLister = newSourceLister(t)
+
+		// another vspheresource claimed the checkpoint since it was listed
+		r.kubeclient.(*kubefake.Clientset).PrependReactor("update", "configmaps", func(clientgotesting.Action) (bool, runtime.Object, error) {
+			return true, nil, apierrs.NewConflict(corev1.Resource("configmaps"), retained.Name, nil)
+		})
+
+		if err := r.reconcileConfigMap(ctx, vms); !apierrs.IsConflict(err) {
+			t.Fatalf("reconcileConfigMap() error = %v, want conflict", err)
+		}
+		if _, err := r.kubeclient.CoreV1().ConfigMaps(vms.Namespace).Get(ctx, "vsphere-01-configmap", metav1.GetOptions{}); !apierrs.IsNotFound(err) {
+			t.Errorf("get configmap error = %v, want not found", err)
+		}
+	})
+
+	t.Run("adopts retained checkpoint with the same name", func(t *testing.T) {
+		vms := newTestSource()
+		vms.Spec.Address = address
+		vms.Spec.CheckpointConfig.RetainPolicy = v1alpha1.VCheckpointRetainPolicyRetain
+		retained := newRetainedCheckpoint(vms.Name, "https://vcenter.local/sdk", last)
+		r := newTestReconciler(t, retained)
+		r.vsphereLister = newSourceLister(t, vms)
+
+		if err := r.reconcileConfigMap(ctx, vms); err != nil {
+			t.Fatalf("reconcileConfigMap() error = %v", err)
+		}
+		cm, err := r.kubeclient.CoreV1().ConfigMaps(vms.Namespace).Get(ctx, retained.Name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("get configmap: %v", err)
+		}
+		if diff := cmp.Diff(retained.Data, cm.Data); diff != "" {
+			t.Errorf("adopted checkpoint (-want, +got) = %s", diff)
+		}
+		if cm.Labels[resources.SourceUIDLabel] != string(vms.UID) {
+			t.Errorf("configmap labels = %v, want claimed by source", cm.Labels)
+		}
+	})
+
+	t.Run("resets retained checkpoint of other vCenter with the same name", func(t *testing.T) {
+		vms := newTestSource()
+		vms.Spec.Address = address
+		retained := newRetainedCheckpoint(vms.Name, "https://other.local/sdk", last)
+		r := newTestReconciler(t, retained)
+		r.vsphereLister = newSourceLister(t, vms)
+
+		if err := r.reconcileConfigMap(ctx, vms); err != nil {
+			t.Fatalf("reconcileConfigMap() error = %v", err)
+		}
+		cm, err := r.kubeclient.CoreV1().ConfigMaps(vms.Namespace).Get(ctx, retained.Name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("get configmap: %v", err)
+		}
+		if len(cm.Data) != 0 {
+			t.Errorf("configmap data = %v, want checkpoint of other vCenter to be reset", cm.Data)
+		}
+		if !metav1.IsControlledBy(cm, vms) {
+			t.Errorf("configmap is not controlled by the source: %v", cm.OwnerReferences)
+		}
+	})
+
+	t.Run("retain policy changed", func(t *testing.T) {
+		vms := newTestSource()
+		existing := resources.MakeConfigMap(ctx, vms)
+		r := newTestReconciler(t, existing)
+		r.vsphereLister = newSourceLister(t)
+
+		vms.Spec.CheckpointConfig.RetainPolicy = v1alpha1.VCheckpointRetainPolicyRetain
+		if err := r.reconcileConfigMap(ctx, vms); err != nil {
+			t.Fatalf("reconcileConfigMap() error = %v", err)
+		}
+
+		cm, err := r.kubeclient.CoreV1().ConfigMaps(vms.Namespace).Get(ctx, existing.Name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("get configmap: %v", err)
+		}
+		if len(cm.OwnerReferences) != 0 || cm.Labels[resources.RetainedCheckpointLabel] != "true" {
+			t.Errorf("configmap = %v, want retained", cm.ObjectMeta)
+		}
+	})
+
+	t.Run("foreign configmap", func(t *testing.T) {
+		vms := newTestSource()
+		other := newTestSource()
+		other.UID = "other"
+		r := newTestReconciler(t, resources.MakeConfigMap(ctx, other))
+		r.vsphereLister = newSourceLister(t)
+
+		if err := r.reconcileConfigMap(ctx, vms); err == nil {
+			t.Error("reconcileConfigMap() error = nil, want not owned error")
+		}
+	})
+}
@@ -0,0 +1,17 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package sinkauth authenticates source adapters towards sinks which require
+// OIDC tokens. The controllers mount a service account token with the audience
+// of the sink into the adapter pod, which the kubelet refreshes before it
+// expires:
+//
+//	spec.Volumes = append(spec.Volumes, sinkauth.TokenVolume(sinkauth.SinkTokenName, audience))
+//	container.VolumeMounts = append(container.VolumeMounts, sinkauth.TokenVolumeMount(sinkauth.SinkTokenName))
+//
+// The adapters send events with the mounted token as bearer token:
+//
+//	client = sinkauth.NewClient(client, sinkauth.ForAudience(env.Audience, sinkauth.SinkTokenName))
+package sinkauth
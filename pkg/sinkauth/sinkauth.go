@@ -0,0 +1,189 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sinkauth
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/protocol"
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/ptr"
+)
+
+const (
+	// CACertsEnv is the adapter environment variable with the CA certificates
+	// in PEM format the adapter trusts when sending to HTTPS sinks
+	CACertsEnv = "K_CA_CERTS"
+	// AudienceEnv is the adapter environment variable with the OIDC audience
+	// of the sink, empty if the sink does not require tokens
+	AudienceEnv = "K_AUDIENCE"
+
+	// SinkTokenName is the name of the token volume of the sink audience
+	SinkTokenName = "sink-token"
+	// TokenMountPath is the directory the token volumes are mounted to
+	TokenMountPath = "/var/run/secrets/sources.tanzu.vmware.com"
+
+	tokenFile = "token"
+	// lifetime of the projected tokens, refreshed by the kubelet after 80%
+	tokenExpirationSeconds = 3600
+	// DefaultTokenInterval is the default time after which a TokenSource
+	// reads the token file again
+	DefaultTokenInterval = time.Minute
+)
+
+// TokenVolume returns the volume with the given name projecting a service
+// account token for audience
+func TokenVolume(name, audience string) corev1.Volume {
+	return corev1.Volume{
+		Name: name,
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{{
+					ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+						Audience:          audience,
+						ExpirationSeconds: ptr.Int64(tokenExpirationSeconds),
+						Path:              tokenFile,
+					},
+				}},
+				// set explicitly to compare with the defaulted volume
+				DefaultMode: ptr.Int32(corev1.ProjectedVolumeSourceDefaultMode),
+			},
+		},
+	}
+}
+
+// TokenVolumeMount returns the mount of the token volume with the given name
+func TokenVolumeMount(name string) corev1.VolumeMount {
+	return corev1.VolumeMount{
+		Name:      name,
+		ReadOnly:  true,
+		MountPath: filepath.Join(TokenMountPath, name),
+	}
+}
+
+// TokenPath returns the path of the token in the mounted token volume with the
+// given name
+func TokenPath(name string) string {
+	return filepath.Join(TokenMountPath, name, tokenFile)
+}
+
+// JoinCACerts returns the concatenation of the given PEM encoded CA
+// certificates, nil if none is set
+func JoinCACerts(certs ...*string) *string {
+	var pems []string
+	for _, c := range certs {
+		if c != nil && strings.TrimSpace(*c) != "" {
+			pems = append(pems, strings.TrimSpace(*c))
+		}
+	}
+	if len(pems) == 0 {
+		return nil
+	}
+	joined := strings.Join(pems, "\n") + "\n"
+	return &joined
+}
+
+// TokenSource reads the token from the file at Path. The token is cached for
+// Interval since the kubelet refreshes the file long before the token expires.
+type TokenSource struct {
+	Path     string
+	Interval time.Duration
+
+	mu    sync.Mutex
+	token string
+	read  time.Time
+}
+
+// NewTokenSource returns a TokenSource reading the token file at path every
+// DefaultTokenInterval
+func NewTokenSource(path string) *TokenSource {
+	return &TokenSource{
+		Path:     path,
+		Interval: DefaultTokenInterval,
+	}
+}
+
+// ForAudience returns a TokenSource of the mounted token volume with the given
+// name, nil if audience is empty since the sink requires no tokens
+func ForAudience(audience, name string) *TokenSource {
+	if audience == "" {
+		return nil
+	}
+	return NewTokenSource(TokenPath(name))
+}
+
+// Token returns the current token
+func (s *TokenSource) Token() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && time.Since(s.read) < s.Interval {
+		return s.token, nil
+	}
+
+	b, err := os.ReadFile(s.Path)
+	if err != nil {
+		return "", fmt.Errorf("read sink token: %w", err)
+	}
+	token := strings.TrimSpace(string(b))
+	if token == "" {
+		return "", fmt.Errorf("read sink token: file %q is empty", s.Path)
+	}
+
+	s.token, s.read = token, time.Now()
+	return token, nil
+}
+
+// NewClient returns a client sending events with the token of tokens as bearer
+// token. If tokens is nil, c is returned.
+func NewClient(c cloudevents.Client, tokens *TokenSource) cloudevents.Client {
+	if tokens == nil {
+		return c
+	}
+	return &client{Client: c, tokens: tokens}
+}
+
+type client struct {
+	cloudevents.Client
+	tokens *TokenSource
+}
+
+// Send implements client.Send
+func (c *client) Send(ctx context.Context, event cloudevents.Event) protocol.Result {
+	ctx, err := c.withToken(ctx)
+	if err != nil {
+		return err
+	}
+	return c.Client.Send(ctx, event)
+}
+
+// Request implements client.Request
+func (c *client) Request(ctx context.Context, event cloudevents.Event) (*cloudevents.Event, protocol.Result) {
+	ctx, err := c.withToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return c.Client.Request(ctx, event)
+}
+
+// withToken returns ctx with the authorization header of the current token
+func (c *client) withToken(ctx context.Context) (context.Context, error) {
+	token, err := c.tokens.Token()
+	if err != nil {
+		return ctx, err
+	}
+	header := cehttp.HeaderFrom(ctx).Clone()
+	header.Set("Authorization", "Bearer "+token)
+	return cehttp.WithCustomHeader(ctx, header), nil
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sinkauth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"knative.dev/pkg/ptr"
+)
+
+func TestJoinCACerts(t *testing.T) {
+	if got := JoinCACerts(nil, ptr.String(" ")); got != nil {
+		t.Errorf("JoinCACerts() = %q, want nil", *got)
+	}
+	got := JoinCACerts(ptr.String("cert-1\n"), nil, ptr.String("cert-2"))
+	if got == nil || *got != "cert-1\ncert-2\n" {
+		t.Errorf("JoinCACerts() = %v, want both certs", got)
+	}
+}
+
+func TestTokenSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	s := NewTokenSource(path)
+
+	if _, err := s.Token(); err == nil {
+		t.Error("Token() of missing file error = nil, want error")
+	}
+
+	if err := os.WriteFile(path, []byte("token-1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := s.Token(); err != nil || got != "token-1" {
+		t.Errorf("Token() = %q, %v, want token-1", got, err)
+	}
+
+	// refreshed tokens are read after the interval
+	if err := os.WriteFile(path, []byte("token-2"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := s.Token(); got != "token-1" {
+		t.Errorf("Token() = %q, want cached token-1", got)
+	}
+	s.Interval = time.Nanosecond
+	if got, _ := s.Token(); got != "token-2" {
+		t.Errorf("Token() = %q, want token-2", got)
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	var auth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	ce, err := cloudevents.NewClientHTTP(cloudevents.WithTarget(srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := NewClient(ce, nil); got != ce {
+		t.Error("NewClient() without tokens did not return the client")
+	}
+
+	path := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(path, []byte("secret-token"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	c := NewClient(ce, NewTokenSource(path))
+
+	event := cloudevents.NewEvent()
+	event.SetID("1")
+	event.SetType("com.example.test")
+	event.SetSource("test")
+	if result := c.Send(context.Background(), event); !cloudevents.IsACK(result) {
+		t.Fatalf("Send() = %v, want ACK", result)
+	}
+	if auth != "Bearer secret-token" {
+		t.Errorf("Authorization = %q, want bearer token", auth)
+	}
+
+	// events are not sent without token
+	auth = ""
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	c = NewClient(ce, NewTokenSource(path))
+	if result := c.Send(context.Background(), event); cloudevents.IsACK(result) || auth != "" {
+		t.Errorf("Send() without token = %v, Authorization = %q, want error", result, auth)
+	}
+}
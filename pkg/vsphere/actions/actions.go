@@ -0,0 +1,198 @@
+/*
+Copyright 2020 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vapi/rest"
+	"github.com/vmware/govmomi/vapi/tags"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/types"
+	"go.uber.org/zap"
+	"knative.dev/pkg/logging"
+
+	"github.com/vmware-tanzu/sources-for-knative/pkg/apis/sources/v1alpha1"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/vsphere/events"
+)
+
+// ResultType is the CloudEvent type of the replies sent by an Executor.
+const ResultType = "com.vmware.vsphere.action.result.v0"
+
+// Result is the outcome of a single action.
+type Result struct {
+	Action    string                          `json:"action"`
+	Operation v1alpha1.VSphereActionOperation `json:"operation"`
+	Error     string                          `json:"error,omitempty"`
+}
+
+// Results is the payload of the replies sent by an Executor.
+type Results struct {
+	// Event is the ID of the CloudEvent which triggered the actions.
+	Event string `json:"event"`
+	// VM is the managed object reference of the virtual machine.
+	VM      types.ManagedObjectReference `json:"vm"`
+	Results []Result                     `json:"results"`
+}
+
+// Executor performs vSphere actions for incoming CloudEvents.
+type Executor struct {
+	vim     *vim25.Client
+	tags    *tags.Manager
+	source  string
+	actions []v1alpha1.VSphereAction
+}
+
+// NewExecutor returns an Executor performing the given actions. The REST
+// client is only used for tag actions and may be nil if there are none.
+func NewExecutor(vim *vim25.Client, rc *rest.Client, source string, actions []v1alpha1.VSphereAction) *Executor {
+	e := &Executor{
+		vim:     vim,
+		source:  source,
+		actions: actions,
+	}
+	if rc != nil {
+		e.tags = tags.NewManager(rc)
+	}
+	return e
+}
+
+// NeedsREST returns whether any of the actions requires a vSphere REST client.
+func NeedsREST(actions []v1alpha1.VSphereAction) bool {
+	for _, a := range actions {
+		if a.Operation == v1alpha1.VSphereActionTagAttach || a.Operation == v1alpha1.VSphereActionTagDetach {
+			return true
+		}
+	}
+	return false
+}
+
+// Handle performs all actions matching the type of the given CloudEvent and
+// replies with their results. Events which do not match any action are
+// acknowledged without a reply. Only if all actions fail the reply is sent
+// with a 500 status code so that the delivery can be retried, since a retry
+// would repeat the actions which succeeded.
+func (e *Executor) Handle(ctx context.Context, event cloudevents.Event) (*cloudevents.Event, cloudevents.Result) {
+	logger := logging.FromContext(ctx).With(zap.String("eventID", event.ID()), zap.String("eventType", event.Type()))
+
+	var matched []v1alpha1.VSphereAction
+	for _, a := range e.actions {
+		if a.Type == "" || a.Type == event.Type() {
+			matched = append(matched, a)
+		}
+	}
+	if len(matched) == 0 {
+		logger.Debug("ignoring event: no matching action")
+		return nil, cloudevents.ResultACK
+	}
+
+	be, err := events.Decode(event)
+	if err != nil {
+		return nil, cloudevents.NewHTTPResult(http.StatusBadRequest, "decode event: %v", err)
+	}
+	vm := be.GetEvent().Vm
+	if vm == nil {
+		return nil, cloudevents.NewHTTPResult(http.StatusBadRequest, "event %q does not reference a virtual machine", event.ID())
+	}
+
+	results := Results{
+		Event:   event.ID(),
+		VM:      vm.Vm,
+		Results: make([]Result, 0, len(matched)),
+	}
+	var failed int
+	for _, a := range matched {
+		r := Result{Action: a.Name, Operation: a.Operation}
+		if err := e.perform(ctx, a, vm.Vm); err != nil {
+			logger.Errorw("action failed", zap.String("action", a.Name), zap.Error(err))
+			r.Error = err.Error()
+			failed++
+		} else {
+			logger.Infow("action succeeded", zap.String("action", a.Name), zap.String("vm", vm.Vm.Value))
+		}
+		results.Results = append(results.Results, r)
+	}
+
+	reply := cloudevents.NewEvent(cloudevents.VersionV1)
+	reply.SetID(fmt.Sprintf("%s-result", event.ID()))
+	reply.SetType(ResultType)
+	reply.SetSource(e.source)
+	reply.SetSubject(vm.Vm.Value)
+	if err := reply.SetData(cloudevents.ApplicationJSON, results); err != nil {
+		return nil, cloudevents.NewHTTPResult(http.StatusInternalServerError, "set data on reply: %v", err)
+	}
+
+	if failed == len(matched) {
+		return &reply, cloudevents.NewHTTPResult(http.StatusInternalServerError, "all %d actions failed", failed)
+	}
+	if failed > 0 {
+		logger.Warnw("acknowledging event with failed actions", zap.Int("failed", failed), zap.Int("actions", len(matched)))
+	}
+	return &reply, cloudevents.ResultACK
+}
+
+// perform executes a single action on the given virtual machine.
+func (e *Executor) perform(ctx context.Context, a v1alpha1.VSphereAction, ref types.ManagedObjectReference) error {
+	vm := object.NewVirtualMachine(e.vim, ref)
+
+	switch a.Operation {
+	case v1alpha1.VSphereActionTagAttach, v1alpha1.VSphereActionTagDetach:
+		if e.tags == nil {
+			return errors.New("no vSphere REST client configured")
+		}
+		if a.Operation == v1alpha1.VSphereActionTagAttach {
+			return e.tags.AttachTag(ctx, a.Tag, ref)
+		}
+		return e.tags.DetachTag(ctx, a.Tag, ref)
+
+	case v1alpha1.VSphereActionPowerOn:
+		return wait(ctx)(vm.PowerOn(ctx))
+	case v1alpha1.VSphereActionPowerOff:
+		return wait(ctx)(vm.PowerOff(ctx))
+	case v1alpha1.VSphereActionSuspend:
+		return wait(ctx)(vm.Suspend(ctx))
+	case v1alpha1.VSphereActionReset:
+		return wait(ctx)(vm.Reset(ctx))
+
+	case v1alpha1.VSphereActionSnapshotCreate:
+		if a.Snapshot == nil {
+			return errors.New("missing snapshot configuration")
+		}
+		s := a.Snapshot
+		return wait(ctx)(vm.CreateSnapshot(ctx, s.Name, s.Description, s.Memory, s.Quiesce))
+
+	case v1alpha1.VSphereActionCustomAttributeSet:
+		if a.CustomAttribute == nil {
+			return errors.New("missing custom attribute configuration")
+		}
+		m, err := object.GetCustomFieldsManager(e.vim)
+		if err != nil {
+			return err
+		}
+		key, err := m.FindKey(ctx, a.CustomAttribute.Name)
+		if err != nil {
+			return fmt.Errorf("find custom attribute %q: %w", a.CustomAttribute.Name, err)
+		}
+		return m.Set(ctx, ref, key, a.CustomAttribute.Value)
+	}
+
+	return fmt.Errorf("unsupported operation %q", a.Operation)
+}
+
+// wait returns a function waiting for the completion of a vSphere task.
+func wait(ctx context.Context) func(*object.Task, error) error {
+	return func(task *object.Task, err error) error {
+		if err != nil {
+			return err
+		}
+		return task.Wait(ctx)
+	}
+}
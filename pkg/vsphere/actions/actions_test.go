@@ -0,0 +1,205 @@
+/*
+Copyright 2020 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package actions
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/google/go-cmp/cmp"
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vapi/rest"
+	"github.com/vmware/govmomi/vapi/tags"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+
+	_ "github.com/vmware/govmomi/vapi/simulator"
+
+	"github.com/vmware-tanzu/sources-for-knative/pkg/apis/sources/v1alpha1"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/vsphere/events"
+)
+
+func newVMEvent(t *testing.T, eventType string, ref types.ManagedObjectReference) cloudevents.Event {
+	t.Helper()
+
+	be := &types.VmPoweredOffEvent{
+		VmEvent: types.VmEvent{
+			Event: types.Event{
+				Key: 42,
+				Vm: &types.VmEventArgument{
+					EntityEventArgument: types.EntityEventArgument{Name: ref.Value},
+					Vm:                  ref,
+				},
+			},
+		},
+	}
+
+	ev := cloudevents.NewEvent(cloudevents.VersionV1)
+	ev.SetID("42")
+	ev.SetSource("https://vcenter.local/sdk")
+	ev.SetType(events.Type(eventType))
+	ev.SetExtension(events.ExtensionEventClass, events.ClassEvent)
+	if err := ev.SetData(cloudevents.ApplicationXML, be); err != nil {
+		t.Fatalf("set data: %v", err)
+	}
+	return ev
+}
+
+func TestHandle(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		vm, err := find.NewFinder(c).VirtualMachine(ctx, "DC0_H0_VM0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := wait(ctx)(vm.PowerOff(ctx)); err != nil {
+			t.Fatal(err)
+		}
+
+		rc := rest.NewClient(c)
+		if err := rc.Login(ctx, simulator.DefaultLogin); err != nil {
+			t.Fatal(err)
+		}
+		tm := tags.NewManager(rc)
+		category, err := tm.CreateCategory(ctx, &tags.Category{Name: "actions", Cardinality: "MULTIPLE"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = tm.CreateTag(ctx, &tags.Tag{Name: "handled", CategoryID: category}); err != nil {
+			t.Fatal(err)
+		}
+
+		fields, err := object.GetCustomFieldsManager(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = fields.Add(ctx, "owner", "VirtualMachine", nil, nil); err != nil {
+			t.Fatal(err)
+		}
+
+		actions := []v1alpha1.VSphereAction{{
+			Name:      "power-on",
+			Type:      events.Type("VmPoweredOffEvent"),
+			Operation: v1alpha1.VSphereActionPowerOn,
+		}, {
+			Name:      "tag",
+			Operation: v1alpha1.VSphereActionTagAttach,
+			Tag:       "handled",
+		}, {
+			Name:      "snapshot",
+			Operation: v1alpha1.VSphereActionSnapshotCreate,
+			Snapshot:  &v1alpha1.VSphereSnapshotAction{Name: "before"},
+		}, {
+			Name:            "owner",
+			Operation:       v1alpha1.VSphereActionCustomAttributeSet,
+			CustomAttribute: &v1alpha1.VSphereCustomAttributeAction{Name: "owner", Value: "knative"},
+		}, {
+			Name:      "ignored",
+			Type:      events.Type("VmCreatedEvent"),
+			Operation: v1alpha1.VSphereActionPowerOff,
+		}}
+
+		e := NewExecutor(c, rc, "test", actions)
+		reply, result := e.Handle(ctx, newVMEvent(t, "VmPoweredOffEvent", vm.Reference()))
+		if !cloudevents.IsACK(result) {
+			t.Fatalf("Handle() = %v, wanted ACK", result)
+		}
+		if reply == nil {
+			t.Fatal("Handle() returned no reply")
+		}
+		if reply.Type() != ResultType || reply.Subject() != vm.Reference().Value {
+			t.Errorf("reply type, subject = %q, %q", reply.Type(), reply.Subject())
+		}
+
+		var got Results
+		if err := json.Unmarshal(reply.Data(), &got); err != nil {
+			t.Fatal(err)
+		}
+		want := Results{
+			Event: "42",
+			VM:    vm.Reference(),
+			Results: []Result{
+				{Action: "power-on", Operation: v1alpha1.VSphereActionPowerOn},
+				{Action: "tag", Operation: v1alpha1.VSphereActionTagAttach},
+				{Action: "snapshot", Operation: v1alpha1.VSphereActionSnapshotCreate},
+				{Action: "owner", Operation: v1alpha1.VSphereActionCustomAttributeSet},
+			},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("results (-want, +got) = %s", diff)
+		}
+
+		var props mo.VirtualMachine
+		if err := vm.Properties(ctx, vm.Reference(), []string{"runtime.powerState", "snapshot", "customValue"}, &props); err != nil {
+			t.Fatal(err)
+		}
+		if props.Runtime.PowerState != types.VirtualMachinePowerStatePoweredOn {
+			t.Errorf("power state = %s, wanted poweredOn", props.Runtime.PowerState)
+		}
+		if props.Snapshot == nil || props.Snapshot.RootSnapshotList[0].Name != "before" {
+			t.Errorf("snapshot = %v, wanted before", props.Snapshot)
+		}
+		if len(props.CustomValue) != 1 || props.CustomValue[0].(*types.CustomFieldStringValue).Value != "knative" {
+			t.Errorf("custom values = %v, wanted owner=knative", props.CustomValue)
+		}
+		attached, err := tm.GetAttachedTags(ctx, vm.Reference())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(attached) != 1 || attached[0].Name != "handled" {
+			t.Errorf("attached tags = %v, wanted handled", attached)
+		}
+
+		// Powering on again fails and is reported in the reply. The event is
+		// acknowledged so the other actions are not repeated.
+		reply, result = e.Handle(ctx, newVMEvent(t, "VmPoweredOffEvent", vm.Reference()))
+		if !cloudevents.IsACK(result) {
+			t.Errorf("Handle() = %v, wanted ACK", result)
+		}
+		if reply == nil {
+			t.Fatal("Handle() returned no reply")
+		}
+		if err := json.Unmarshal(reply.Data(), &got); err != nil {
+			t.Fatal(err)
+		}
+		if got.Results[0].Error == "" {
+			t.Errorf("results[0] = %v, wanted error", got.Results[0])
+		}
+		for _, r := range got.Results[1:] {
+			if r.Error != "" {
+				t.Errorf("result %q error = %s, wanted none", r.Action, r.Error)
+			}
+		}
+
+		// If all actions fail the delivery is retried.
+		e = NewExecutor(c, rc, "test", actions[:1])
+		reply, result = e.Handle(ctx, newVMEvent(t, "VmPoweredOffEvent", vm.Reference()))
+		if cloudevents.IsACK(result) {
+			t.Error("Handle() = ACK, wanted failure")
+		}
+		if reply == nil {
+			t.Fatal("Handle() returned no reply")
+		}
+	})
+}
+
+func TestHandleIgnored(t *testing.T) {
+	e := NewExecutor(nil, nil, "test", []v1alpha1.VSphereAction{{
+		Name:      "power-on",
+		Type:      events.Type("VmPoweredOffEvent"),
+		Operation: v1alpha1.VSphereActionPowerOn,
+	}})
+
+	ref := types.ManagedObjectReference{Type: "VirtualMachine", Value: "vm-1"}
+	reply, result := e.Handle(context.Background(), newVMEvent(t, "VmCreatedEvent", ref))
+	if !cloudevents.IsACK(result) || reply != nil {
+		t.Errorf("Handle() = %v, %v, wanted no reply and ACK", reply, result)
+	}
+}
@@ -0,0 +1,12 @@
+/*
+Copyright 2020 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package actions implements the runtime of a VSphereActionSink. An Executor
+// performs the configured vSphere actions on the virtual machine referenced
+// by an incoming vSphere CloudEvent and replies with their results:
+//
+//	e := actions.NewExecutor(vimClient, restClient, source, spec.Actions)
+//	err := ceclient.StartReceiver(ctx, e.Handle)
+package actions
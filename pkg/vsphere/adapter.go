@@ -8,6 +8,8 @@ package vsphere
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"sync"
 	"time"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
@@ -17,21 +19,37 @@ import (
 	"github.com/vmware/govmomi/vim25/methods"
 	"github.com/vmware/govmomi/vim25/types"
 	"go.uber.org/zap"
+	"k8s.io/client-go/dynamic"
 	"knative.dev/eventing/pkg/adapter/v2"
-	"knative.dev/pkg/kvstore"
+	"knative.dev/pkg/injection/clients/dynamicclient"
 	"knative.dev/pkg/logging"
 
-	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/cemapping"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/delivery"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/sinkauth"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/vsphere/events"
 )
 
 const (
-	// signal unstable event API for converting vSphere events to CE
-	eventTypeFormat = "com.vmware.vsphere.%s.v0"
 	// extended attribute to filter on vSphere API version/class
-	ceVSphereAPIKey     = "vsphereapiversion"
-	ceVSphereEventClass = "eventclass"
-	// read up to max events per iteration
-	maxEventsBatch = 100
+	ceVSphereAPIKey     = events.ExtensionAPIVersion
+	ceVSphereEventClass = events.ExtensionEventClass
+	// PollDefaultBatchSize is the default maximum number of events read per
+	// iteration
+	PollDefaultBatchSize = 100
+	// PollDefaultMinBackoff is the default initial delay between reads when
+	// no new events are received
+	PollDefaultMinBackoff = time.Second
+	// PollDefaultMaxBackoff is the default maximum delay between reads when
+	// no new events are received
+	PollDefaultMaxBackoff = 5 * time.Second
+	// ShutdownDefaultGracePeriod is the default time the adapter has to shut
+	// down
+	ShutdownDefaultGracePeriod = 30 * time.Second
+	// ShutdownTerminationMargin is added to the shutdown grace period for the
+	// termination grace period of the adapter pod, so that the adapter exits
+	// before it is killed
+	ShutdownTerminationMargin = 5 * time.Second
 )
 
 type envConfig struct {
@@ -45,6 +63,51 @@ type envConfig struct {
 
 	// PayloadEncoding configures the encoding format for the cloud event payload
 	PayloadEncoding string `envconfig:"VSPHERE_PAYLOAD_ENCODING" default:"application/xml"`
+
+	// CheckpointStore selects where checkpoints are stored: configmap, status
+	// or file
+	CheckpointStore string `envconfig:"VSPHERE_CHECKPOINT_STORE" default:"configmap"`
+
+	// CheckpointFile is the path of the checkpoint file of the file store
+	CheckpointFile string `envconfig:"VSPHERE_CHECKPOINT_FILE" default:"/var/run/vsphere/checkpoint/checkpoint.json"`
+
+	// SourceName is the name of the VSphereSource, used by the status store
+	// and to report the delivery status
+	SourceName string `envconfig:"VSPHERE_SOURCE_NAME"`
+
+	// ShutdownGracePeriod is the time the adapter has to finish in-flight
+	// sends, save the checkpoint and log out when it is stopped
+	ShutdownGracePeriod time.Duration `envconfig:"VSPHERE_SHUTDOWN_GRACE_PERIOD" default:"30s"`
+
+	// MinBackoff and MaxBackoff bound the delay between reads when no new
+	// events are received
+	MinBackoff time.Duration `envconfig:"VSPHERE_POLL_MIN_BACKOFF" default:"1s"`
+	MaxBackoff time.Duration `envconfig:"VSPHERE_POLL_MAX_BACKOFF" default:"5s"`
+
+	// BatchSize is the maximum number of events read per iteration
+	BatchSize int32 `envconfig:"VSPHERE_POLL_BATCH_SIZE" default:"100"`
+
+	// CloudEventMapping is the JSON-encoded cemapping.Config of the
+	// CloudEvent type, source and subject
+	CloudEventMapping string `envconfig:"VSPHERE_CE_MAPPING" default:""`
+
+	// ReconnectMinBackoff and ReconnectMaxBackoff bound the delay between
+	// attempts to restore the vCenter event stream after a lost session or
+	// connection
+	ReconnectMinBackoff time.Duration `envconfig:"VSPHERE_RECONNECT_MIN_BACKOFF" default:"1s"`
+	ReconnectMaxBackoff time.Duration `envconfig:"VSPHERE_RECONNECT_MAX_BACKOFF" default:"1m"`
+
+	// CheckpointMismatchPolicy selects how to start when the checkpoint does
+	// not match the vCenter: ReplayMaxAge, ResetToNow or Fail
+	CheckpointMismatchPolicy string `envconfig:"VSPHERE_CHECKPOINT_MISMATCH_POLICY" default:"ReplayMaxAge"`
+
+	// BackfillStart and BackfillEnd run the adapter in backfill mode: the
+	// events created in this time range are sent and the adapter exits
+	BackfillStart time.Time `envconfig:"VSPHERE_BACKFILL_START"`
+	BackfillEnd   time.Time `envconfig:"VSPHERE_BACKFILL_END"`
+
+	// Audience is the OIDC audience of the sink, if it requires tokens
+	Audience string `envconfig:"K_AUDIENCE" default:""`
 }
 
 func NewEnvConfig() adapter.EnvConfigAccessor {
@@ -59,15 +122,61 @@ type vAdapter struct {
 	VClient         *govmomi.Client
 	VAPIVersion     string
 	CEClient        cloudevents.Client
-	KVStore         kvstore.Interface
+	KVStore         CheckpointStore
 	CpConfig        CheckpointConfig
 	PayloadEncoding string
+	Reporter        *delivery.Reporter
+	// Mapper and CESource map vSphere events to CloudEvents, default to
+	// DefaultCloudEventMapping and Source
+	Mapper   *cemapping.Mapper
+	CESource string
+	// ShutdownGracePeriod bounds the shutdown of the adapter, defaults to
+	// ShutdownDefaultGracePeriod
+	ShutdownGracePeriod time.Duration
+	// MinBackoff and MaxBackoff bound the delay between reads when no new
+	// events are received, default to PollDefaultMinBackoff and
+	// PollDefaultMaxBackoff
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// BatchSize is the maximum number of events read per iteration, defaults
+	// to PollDefaultBatchSize
+	BatchSize int32
+	// Credentials returns the credentials to log in again when the vCenter
+	// session is lost
+	Credentials func() (*url.Userinfo, error)
+	// ReconnectMinBackoff and ReconnectMaxBackoff bound the delay between
+	// attempts to restore the vCenter event stream, default to
+	// ReconnectDefaultMinBackoff and ReconnectDefaultMaxBackoff
+	ReconnectMinBackoff time.Duration
+	ReconnectMaxBackoff time.Duration
+	// MismatchPolicy selects how to start when the checkpoint does not match
+	// the vCenter, defaults to CheckpointMismatchReplayMaxAge
+	MismatchPolicy string
+	// StatusClient and SourceName are used to report a checkpoint mismatch
+	// in the status of the source
+	StatusClient dynamic.ResourceInterface
+	SourceName   string
+	// Backfill runs the adapter in backfill mode, BackfillReporter reports
+	// its progress
+	Backfill         *backfillRange
+	BackfillReporter *backfillReporter
+
+	// time the shutdown has to be complete by, see shutdownDeadline
+	shutdownMu sync.Mutex
+	shutdownAt time.Time
 }
 
 func NewAdapter(ctx context.Context, processed adapter.EnvConfigAccessor, ceClient cloudevents.Client) adapter.Adapter {
 	env := processed.(*envConfig)
 	logger := logging.FromContext(ctx)
 
+	if err := registerMetrics(); err != nil {
+		logger.Fatalf("unable to register metrics: %v", err)
+	}
+
+	// send with a token if the sink requires OIDC authentication
+	ceClient = sinkauth.NewClient(ceClient, sinkauth.ForAudience(env.Audience, sinkauth.SinkTokenName))
+
 	vClient, err := NewSOAPClient(ctx)
 	if err != nil {
 		logger.Fatalf("unable to create vSphere client: %v", err)
@@ -78,10 +187,21 @@ func NewAdapter(ctx context.Context, processed adapter.EnvConfigAccessor, ceClie
 		logger.Fatal("unable to determine vSphere client source: empty host")
 	}
 
+	mapper, ceSource, err := newMapper(env.CloudEventMapping, source, vClient.ServiceContent.About)
+	if err != nil {
+		logger.Fatalf("could not configure CloudEvent mapping: %v", err)
+	}
+
+	if !env.BackfillStart.IsZero() {
+		a := newBackfillAdapter(ctx, env, ceClient, vClient, source)
+		a.Mapper, a.CESource = mapper, ceSource
+		return a
+	}
+
 	// setup checkpointing
-	store := kvstore.NewConfigMapKVStore(ctx, env.KVConfigMap, env.Namespace, kubeclient.Get(ctx).CoreV1())
-	if err = store.Init(ctx); err != nil {
-		logger.Fatalf("could not initialize kv store: %v", err)
+	store, err := newCheckpointStore(ctx, env)
+	if err != nil {
+		logger.Fatalf("could not initialize checkpoint store: %v", err)
 	}
 
 	cpconf, err := newCheckpointConfig(env.CheckpointConfig)
@@ -90,12 +210,21 @@ func NewAdapter(ctx context.Context, processed adapter.EnvConfigAccessor, ceClie
 	}
 
 	logger.Infow("configuring checkpointing", zap.String("ReplayWindow", cpconf.MaxAge.String()),
-		zap.String("Period", cpconf.Period.String()))
+		zap.String("Period", cpconf.Period.String()), zap.String("Store", env.CheckpointStore))
 
 	if cpconf.MaxAge == time.Duration(0) {
 		logger.Warn("disabling event replay: maxAge set to 0s")
 	}
 
+	var (
+		reporter     *delivery.Reporter
+		statusClient dynamic.ResourceInterface
+	)
+	if env.SourceName != "" {
+		statusClient = dynamicclient.Get(ctx).Resource(vsphereSourcesResource).Namespace(env.Namespace)
+		reporter = delivery.NewReporter(statusClient, env.SourceName, "lastEventKey")
+	}
+
 	return &vAdapter{
 		Logger:          logger,
 		Namespace:       env.Namespace,
@@ -106,14 +235,98 @@ func NewAdapter(ctx context.Context, processed adapter.EnvConfigAccessor, ceClie
 		KVStore:         store,
 		CpConfig:        *cpconf,
 		PayloadEncoding: env.PayloadEncoding,
+		Reporter:        reporter,
+		Mapper:          mapper,
+		CESource:        ceSource,
+		MismatchPolicy:  env.CheckpointMismatchPolicy,
+		StatusClient:    statusClient,
+		SourceName:      env.SourceName,
+		Credentials:     ReadCredentials,
+
+		ShutdownGracePeriod: env.ShutdownGracePeriod,
+		MinBackoff:          env.MinBackoff,
+		MaxBackoff:          env.MaxBackoff,
+		BatchSize:           env.BatchSize,
+		ReconnectMinBackoff: env.ReconnectMinBackoff,
+		ReconnectMaxBackoff: env.ReconnectMaxBackoff,
+	}
+}
+
+// newBackfillAdapter returns an adapter in backfill mode, which does not use
+// the checkpoint store and does not report the delivery status of the source
+func newBackfillAdapter(ctx context.Context, env *envConfig, ceClient cloudevents.Client, vClient *govmomi.Client, source string) *vAdapter {
+	logger := logging.FromContext(ctx)
+
+	cpconf, err := newCheckpointConfig(env.CheckpointConfig)
+	if err != nil {
+		logger.Fatalf("could not not read checkpoint config: %v", err)
+	}
+
+	rng := backfillRange{Begin: env.BackfillStart, End: env.BackfillEnd}
+	var reporter *backfillReporter
+	if env.SourceName != "" {
+		client := dynamicclient.Get(ctx).Resource(vsphereSourcesResource).Namespace(env.Namespace)
+		reporter = newBackfillReporter(client, env.SourceName, rng)
+	}
+
+	return &vAdapter{
+		Logger:          logger,
+		Namespace:       env.Namespace,
+		Source:          source,
+		VClient:         vClient,
+		VAPIVersion:     vClient.ServiceContent.About.ApiVersion,
+		CEClient:        ceClient,
+		CpConfig:        *cpconf,
+		PayloadEncoding: env.PayloadEncoding,
+
+		ShutdownGracePeriod: env.ShutdownGracePeriod,
+		BatchSize:           env.BatchSize,
+		Backfill:            &rng,
+		BackfillReporter:    reporter,
 	}
 }
 
 // Start implements adapter.Adapter
 func (a *vAdapter) Start(ctx context.Context) error {
+	// the shutdown starts as soon as ctx is canceled
+	stopped := make(chan struct{})
+	defer close(stopped)
+	go func() {
+		select {
+		case <-ctx.Done():
+			a.shutdownDeadline()
+		case <-stopped:
+		}
+	}()
+
 	defer func() {
 		// using fresh ctx to avoid canceled error during logout
-		_ = a.VClient.Logout(context.Background()) // best effort, ignoring error
+		logoutCtx, cancel := a.shutdownContext(ctx)
+		defer cancel()
+		_ = a.VClient.Logout(logoutCtx) // best effort, ignoring error
+	}()
+
+	if a.Backfill != nil {
+		return a.backfill(ctx)
+	}
+
+	reportCtx, stopReports := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		a.Reporter.Report(reportCtx, a.CpConfig.Period)
+	}()
+	defer func() {
+		stopReports()
+		wg.Wait()
+
+		// report the last deliveries before the shutdown deadline
+		flushCtx, cancel := a.shutdownContext(ctx)
+		defer cancel()
+		if err := a.Reporter.Flush(flushCtx); err != nil {
+			logging.FromContext(ctx).Warnw("could not report delivery status", zap.Error(err))
+		}
 	}()
 
 	return a.run(ctx)
@@ -136,79 +349,158 @@ func (a *vAdapter) run(ctx context.Context) error {
 		return fmt.Errorf("get current time from vCenter: %w", err)
 	}
 
-	begin := getBeginFromCheckpoint(ctx, *vcTime, cp, a.CpConfig.MaxAge)
+	mismatch, err := a.checkpointMismatch(ctx, cp)
+	if err != nil {
+		return fmt.Errorf("verify checkpoint: %w", err)
+	}
+	a.reportCheckpointMismatch(ctx, mismatch)
+
+	var begin time.Time
+	switch {
+	case mismatch == "":
+		a.updateCheckpointAPIVersion(ctx, &cp)
+		begin = getBeginFromCheckpoint(ctx, *vcTime, cp, a.CpConfig.MaxAge)
+	case a.MismatchPolicy == CheckpointMismatchFail:
+		return fmt.Errorf("checkpoint does not match vCenter: %s", mismatch)
+	case a.MismatchPolicy == CheckpointMismatchResetToNow:
+		logging.FromContext(ctx).Warnw("ignoring checkpoint: starting at current vCenter time", zap.String("mismatch", mismatch))
+		begin = *vcTime
+		cp = checkpoint{}
+	default:
+		logging.FromContext(ctx).Warnw("ignoring checkpoint: replaying events since maximum age", zap.String("mismatch", mismatch),
+			zap.String("maxHistory", a.CpConfig.MaxAge.String()))
+		begin = vcTime.Add(-a.CpConfig.MaxAge)
+		cp = checkpoint{}
+	}
+
 	coll, err := newHistoryCollector(ctx, a.VClient.Client, begin)
 	if err != nil {
 		return fmt.Errorf("create event collector: %w", err)
 	}
 
-	return a.readEvents(ctx, coll)
+	return a.readEvents(ctx, coll, begin, newDeduplicator(cp, maxRecentEventKeys))
 }
 
 // readEvents polls vCenter for new events starting at the configured begin time
 // in the provided event history collector. A checkpoint will be periodically
 // created and stored in Kubernetes to track successfully processed events
-// (ACK-ed by sink).
-func (a *vAdapter) readEvents(ctx context.Context, c *event.HistoryCollector) error {
+// (ACK-ed by sink). Events which have already been sent before the stream was
+// replayed from a checkpoint are skipped. When the vCenter session or
+// connection is lost, the adapter logs in again and continues reading after the
+// last event read. When ctx is canceled, reading stops, in-flight sends get
+// until sendDeadline to complete and the pending checkpoint is saved before
+// the shutdown deadline.
+func (a *vAdapter) readEvents(ctx context.Context, c *event.HistoryCollector, begin time.Time, dedup *deduplicator) error {
 	logger := logging.FromContext(ctx)
 
+	sendCtx, cancelSends := withShutdownDeadline(ctx, a.sendDeadline)
+	defer cancelSends()
+
+	// events created before are replayed, e.g. from a checkpoint, and do not
+	// count towards the event lag
+	readStart := time.Now()
+
 	var (
 		lastEvent              types.BaseEvent
 		lastCheckpointEventKey int32
+		// last event read from the collector, the position to continue
+		// from when the collector is recreated
+		lastRead types.BaseEvent
 	)
 
-	bOff := backoff.Backoff{
-		Factor: 2,
-		Jitter: false,
-		Min:    time.Second,
-		Max:    5 * time.Second,
-	}
+	bOff := a.backoff()
+	batchSize := a.batchSize()
 
 	cpTicker := time.NewTicker(a.CpConfig.Period)
 	defer cpTicker.Stop()
 
 	for {
+		// checked first, the select below picks randomly between ready cases
+		if ctx.Err() != nil {
+			// save pending checkpoint using fresh ctx to avoid canceled error
+			saveCtx, cancel := a.shutdownContext(ctx)
+			defer cancel()
+
+			logger.Info("saving checkpoint before shutdown")
+			if err := a.saveCheckpoint(saveCtx, lastEvent, &lastCheckpointEventKey); err != nil {
+				return err
+			}
+			return ctx.Err()
+		}
+
 		select {
 		case <-ctx.Done():
-			return ctx.Err()
+			// handled at the top of the loop
+			continue
 
 		// checkpoints
 		case <-cpTicker.C:
-			// avoid unnecessary K8s API calls
-			skip := lastEvent == nil || lastCheckpointEventKey == lastEvent.GetEvent().Key
-			if !skip {
-				var current checkpoint
-				if err := a.KVStore.Get(ctx, checkpointKey, &current); err != nil {
-					return fmt.Errorf("retrieve current checkpoint: %w", err)
-				}
-
-				logger.Debugw("creating checkpoint", zap.Any("checkpoint", current))
-				if err := a.KVStore.Save(ctx); err != nil {
-					return fmt.Errorf("save checkpoint: %w", err)
-				}
-				lastCheckpointEventKey = lastEvent.GetEvent().Key
-			} else {
-				logger.Debug("skipping checkpoint: no new events since last checkpoint")
+			if err := a.saveCheckpoint(ctx, lastEvent, &lastCheckpointEventKey); err != nil {
+				return err
 			}
 
 		// poll vCenter events
 		default:
-			events, err := c.ReadNextEvents(ctx, maxEventsBatch)
+			events, err := c.ReadNextEvents(ctx, batchSize)
 			if err != nil {
-				return fmt.Errorf("read events from vcenter: %w", err)
+				if ctx.Err() != nil {
+					// shutting down
+					continue
+				}
+
+				kind := classifyReadError(err)
+				if kind == readErrorFatal {
+					return fmt.Errorf("read events from vcenter: %w", err)
+				}
+				logger.Warnw("lost vCenter event stream", zap.Error(err), zap.String("reason", kind.String()))
+
+				// events sent before are skipped by dedup
+				if lastRead != nil {
+					begin = lastRead.GetEvent().CreatedTime
+				}
+				coll, err := a.reconnect(ctx, kind, begin)
+				if err != nil {
+					// shutting down
+					continue
+				}
+				if kind != readErrorCollector {
+					_ = c.Destroy(ctx) // best effort, the collector ends with its session
+				}
+				c = coll
+				continue
 			}
+			a.Reporter.Polled()
 
 			if len(events) == 0 {
 				delay := bOff.Duration()
 				logger.Debugw("backing off retrieving events: no new events received", zap.Duration("backoffSeconds", delay))
-				time.Sleep(delay)
+				select {
+				case <-ctx.Done():
+				case <-time.After(delay):
+				}
 				continue
 			}
 
 			logger.Debugf("got %d events", len(events))
+			lastRead = events[len(events)-1]
+
+			if events = dedup.filter(ctx, events); len(events) == 0 {
+				logger.Debug("skipping batch: all events have already been sent")
+				bOff.Reset()
+				continue
+			}
 
-			n, err := a.sendEvents(ctx, events)
+			n, err := a.sendEvents(sendCtx, events)
+			if n > 0 {
+				last := events[n-1].GetEvent()
+				if last.CreatedTime.Before(readStart) {
+					a.Reporter.Replayed(n, last.Key, last.CreatedTime)
+				} else {
+					a.Reporter.Sent(n, last.Key, last.CreatedTime)
+				}
+			}
 			if err != nil {
+				a.Reporter.Failed(err)
 				// TODO: return and fail instead?
 				logger.Errorf("send events: success %d (total %d): %v", n, len(events), err)
 
@@ -224,12 +516,16 @@ func (a *vAdapter) readEvents(ctx context.Context, c *event.HistoryCollector) er
 
 			// last successfully sent event from batch
 			lastEvent = events[n-1]
+			dedup.sent(events[:n])
 			cp := checkpoint{
 				VCenter:               a.Source,
+				InstanceUUID:          a.VClient.ServiceContent.About.InstanceUuid,
+				APIVersion:            a.VClient.ServiceContent.About.ApiVersion,
 				LastEventKey:          lastEvent.GetEvent().Key,
 				LastEventType:         getEventDetails(lastEvent).Type,
 				LastEventKeyTimestamp: lastEvent.GetEvent().CreatedTime,
 				CreatedTimestamp:      time.Now().UTC(),
+				RecentEventKeys:       dedup.keys(),
 			}
 			if err = a.KVStore.Set(ctx, checkpointKey, cp); err != nil {
 				return fmt.Errorf("set checkpoint: %w", err)
@@ -240,27 +536,102 @@ func (a *vAdapter) readEvents(ctx context.Context, c *event.HistoryCollector) er
 	}
 }
 
+// backoff returns the backoff between reads when no new events are received
+func (a *vAdapter) backoff() backoff.Backoff {
+	b := backoff.Backoff{
+		Factor: 2,
+		Jitter: false,
+		Min:    a.MinBackoff,
+		Max:    a.MaxBackoff,
+	}
+	if b.Min <= 0 {
+		b.Min = PollDefaultMinBackoff
+	}
+	if b.Max <= 0 {
+		b.Max = PollDefaultMaxBackoff
+	}
+	return b
+}
+
+// batchSize returns the maximum number of events read per iteration
+func (a *vAdapter) batchSize() int32 {
+	if a.BatchSize <= 0 {
+		return PollDefaultBatchSize
+	}
+	return a.BatchSize
+}
+
+// saveCheckpoint saves the checkpoint if lastEvent has not been checkpointed
+// yet and records its key in lastCheckpointEventKey.
+func (a *vAdapter) saveCheckpoint(ctx context.Context, lastEvent types.BaseEvent, lastCheckpointEventKey *int32) error {
+	logger := logging.FromContext(ctx)
+
+	// avoid unnecessary K8s API calls
+	if lastEvent == nil || *lastCheckpointEventKey == lastEvent.GetEvent().Key {
+		logger.Debug("skipping checkpoint: no new events since last checkpoint")
+		return nil
+	}
+
+	var current checkpoint
+	if err := a.KVStore.Get(ctx, checkpointKey, &current); err != nil {
+		return fmt.Errorf("retrieve current checkpoint: %w", err)
+	}
+
+	logger.Debugw("creating checkpoint", zap.Any("checkpoint", current))
+	if err := a.KVStore.Save(ctx); err != nil {
+		return fmt.Errorf("save checkpoint: %w", err)
+	}
+	*lastCheckpointEventKey = lastEvent.GetEvent().Key
+	return nil
+}
+
 // sendEvents converts all events to cloud events and sends them to the
 // configured sink. It returns the number of successfully processed events,
-// which might 0, partial or all events. sendEvents returns when all events are
-// processed or on the first error.
+// which might 0, partial or all events. Events which cannot be mapped to a
+// CloudEvent are skipped and count as processed. sendEvents returns when all
+// events are processed or on the first error.
 func (a *vAdapter) sendEvents(ctx context.Context, baseEvents []types.BaseEvent) (int, error) {
 	var success int
 
+	mapper, source := a.Mapper, a.CESource
+	if mapper == nil {
+		mapper = defaultMapper
+	}
+	if source == "" {
+		source = a.Source
+	}
+
 	for _, be := range baseEvents {
 		ev := cloudevents.NewEvent(cloudevents.VersionV1)
-		ev.SetSource(a.Source)
+		ev.SetSource(source)
 
 		details := getEventDetails(be)
+		data := cemapping.EventData{
+			Type:  details.Type,
+			Class: details.Class,
+		}
+		data.Entity, data.EntityType = getEventEntity(be)
+
+		ceType, subject, err := mapEvent(mapper, data)
+		if err != nil {
+			// retrying would not fix the mapping
+			logging.FromContext(ctx).Errorw("skipping event because it could not be mapped to a cloudevent",
+				zap.Int32("key", be.GetEvent().Key), zap.String("type", details.Type), zap.Error(err))
+			success++
+			continue
+		}
 
 		// CE envelop
 		ev.SetID(fmt.Sprintf("%d", be.GetEvent().Key))
-		ev.SetType(fmt.Sprintf(eventTypeFormat, details.Type))
+		ev.SetType(ceType)
+		if subject != "" {
+			ev.SetSubject(subject)
+		}
 		ev.SetTime(be.GetEvent().CreatedTime)
 		ev.SetExtension(ceVSphereEventClass, details.Class)
 		ev.SetExtension(ceVSphereAPIKey, a.VAPIVersion)
 
-		if err := ev.SetData(a.PayloadEncoding, be); err != nil {
+		if err := events.SetData(&ev, a.PayloadEncoding, be); err != nil {
 			return success, fmt.Errorf("set data on event: %w", err)
 		}
 
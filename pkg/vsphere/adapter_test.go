@@ -33,6 +33,8 @@ import (
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest"
 	"knative.dev/pkg/kvstore"
+
+	vsevents "github.com/vmware-tanzu/sources-for-knative/pkg/vsphere/events"
 )
 
 const (
@@ -177,6 +179,107 @@ func TestSendEvents(t *testing.T) {
 	}
 }
 
+func TestSendEvents_cloudEventMapping(t *testing.T) {
+	ctx := cecontext.WithTarget(context.Background(), "fake.example.com")
+
+	roundTripper := &roundTripperTest{statusCodes: createStatusCodes(1, failNever)}
+	p, err := cehttp.New(cehttp.WithRoundTripper(roundTripper))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := client.New(p, client.WithTimeNow(), client.WithUUIDs())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mapper, ceSource, err := newMapper(
+		`{"type":"com.example.{{ lower .Type }}","source":"urn:vcenter:{{ .InstanceUUID }}","subject":"{{ .EntityType }}/{{ .Entity }}"}`,
+		source, types.AboutInfo{InstanceUuid: "dbed6e0c-bd88-4ef6-b594-21283e1c677f"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	adapter := vAdapter{
+		Logger:          zaptest.NewLogger(t).Sugar(),
+		CEClient:        c,
+		Source:          source,
+		PayloadEncoding: cloudevents.ApplicationXML,
+		Mapper:          mapper,
+		CESource:        ceSource,
+	}
+
+	be := &types.VmPoweredOnEvent{VmEvent: types.VmEvent{Event: types.Event{
+		Key: 1000,
+		Vm:  &types.VmEventArgument{Vm: types.ManagedObjectReference{Type: "VirtualMachine", Value: "vm-42"}},
+	}}}
+	if _, err := adapter.sendEvents(ctx, []types.BaseEvent{be}); err != nil {
+		t.Fatalf("sendEvents() error = %v", err)
+	}
+
+	if len(roundTripper.events) != 1 {
+		t.Fatalf("sent events = %d, want 1", len(roundTripper.events))
+	}
+	got := roundTripper.events[0]
+	if want := "com.example.vmpoweredonevent"; got.Type() != want {
+		t.Errorf("type = %q, want %q", got.Type(), want)
+	}
+	if want := "urn:vcenter:dbed6e0c-bd88-4ef6-b594-21283e1c677f"; got.Source() != want {
+		t.Errorf("source = %q, want %q", got.Source(), want)
+	}
+	if want := "VirtualMachine/vm-42"; got.Subject() != want {
+		t.Errorf("subject = %q, want %q", got.Subject(), want)
+	}
+}
+
+func TestSendEvents_unmappableEvent(t *testing.T) {
+	ctx := cecontext.WithTarget(context.Background(), "fake.example.com")
+
+	roundTripper := &roundTripperTest{statusCodes: createStatusCodes(1, failNever)}
+	p, err := cehttp.New(cehttp.WithRoundTripper(roundTripper))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := client.New(p, client.WithTimeNow(), client.WithUUIDs())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// events without an entity render an empty type
+	mapper, ceSource, err := newMapper(`{"type":"{{ if .Entity }}com.example.{{ .Type }}{{ end }}"}`,
+		source, types.AboutInfo{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	adapter := vAdapter{
+		Logger:          zaptest.NewLogger(t).Sugar(),
+		CEClient:        c,
+		Source:          source,
+		PayloadEncoding: cloudevents.ApplicationXML,
+		Mapper:          mapper,
+		CESource:        ceSource,
+	}
+
+	events := []types.BaseEvent{
+		&types.UserLoginSessionEvent{SessionEvent: types.SessionEvent{Event: types.Event{Key: 1000}}},
+		&types.VmPoweredOnEvent{VmEvent: types.VmEvent{Event: types.Event{
+			Key: 1001,
+			Vm:  &types.VmEventArgument{Vm: types.ManagedObjectReference{Type: "VirtualMachine", Value: "vm-42"}},
+		}}},
+	}
+	n, err := adapter.sendEvents(ctx, events)
+	if err != nil {
+		t.Fatalf("sendEvents() error = %v", err)
+	}
+	// the skipped event is checkpointed with the rest of the batch
+	if n != 2 {
+		t.Errorf("sendEvents() = %d, want 2", n)
+	}
+	if len(roundTripper.events) != 1 || roundTripper.events[0].ID() != "1001" {
+		t.Errorf("sent events = %v, want event 1001", roundTripper.events)
+	}
+}
+
 type testEvents struct {
 	vEvents  []types.BaseEvent
 	ceEvents []*event.Event
@@ -216,7 +319,7 @@ func createCloudEvent(eventSource string, eventID string, baseEvent types.BaseEv
 
 	ev := cloudevents.NewEvent(cloudevents.VersionV1)
 
-	ev.SetType(fmt.Sprintf(eventTypeFormat, details.Type))
+	ev.SetType(vsevents.Type(details.Type))
 	ev.SetTime(eventTime)
 	ev.SetID(eventID)
 	ev.SetSource(eventSource)
@@ -516,6 +619,9 @@ func (f *fakeKVStore) Save(ctx context.Context) error {
 }
 
 func (f *fakeKVStore) Get(ctx context.Context, key string, value interface{}) error {
+	f.Lock()
+	defer f.Unlock()
+
 	v, ok := f.data[key]
 	if !ok {
 		return fmt.Errorf("key %s does not exist", key)
@@ -542,3 +648,173 @@ func (f *fakeKVStore) Set(ctx context.Context, key string, value interface{}) er
 	f.data[key] = string(bytes)
 	return nil
 }
+
+func Test_vAdapter_runDeduplicates(t *testing.T) {
+	simulator.Run(func(ctx context.Context, vim *vim25.Client) error {
+		ctx = cecontext.WithTarget(ctx, "fake.example.com")
+
+		// look up the timestamp of a vcsim event to checkpoint
+		coll, err := newHistoryCollector(ctx, vim, time.Now().UTC().Add(-time.Hour))
+		if err != nil {
+			t.Fatal(err)
+		}
+		vcEvents, err := coll.ReadNextEvents(ctx, PollDefaultBatchSize)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var last *types.Event
+		for _, be := range vcEvents {
+			if be.GetEvent().Key == 20 {
+				last = be.GetEvent()
+			}
+		}
+		if last == nil {
+			t.Fatal("vcsim event with key 20 not found")
+		}
+
+		// event 23 was sent before the restart as well
+		b, err := json.Marshal(checkpoint{
+			LastEventKey:          last.Key,
+			LastEventKeyTimestamp: last.CreatedTime,
+			RecentEventKeys:       []int32{23},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		kv := &fakeKVStore{
+			data:     map[string]string{checkpointKey: string(b)},
+			dataChan: make(chan string, 1),
+		}
+
+		wantSent := []string{"21", "22", "24", "25", "26"}
+		roundTripper := &roundTripperTest{statusCodes: createStatusCodes(len(wantSent), failNever)}
+		p, err := cehttp.New(cehttp.WithRoundTripper(roundTripper))
+		if err != nil {
+			t.Fatal(err)
+		}
+		c, err := client.New(p, client.WithTimeNow(), client.WithUUIDs())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		a := &vAdapter{
+			Logger:   zaptest.NewLogger(t).Sugar(),
+			Source:   source,
+			VClient:  &govmomi.Client{Client: vim, SessionManager: session.NewManager(vim)},
+			CEClient: c,
+			KVStore:  kv,
+			CpConfig: CheckpointConfig{MaxAge: time.Hour, Period: time.Millisecond},
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		var cp checkpoint
+		go func() {
+			if err := json.Unmarshal([]byte(<-kv.dataChan), &cp); err != nil {
+				t.Errorf("unmarshal data from KV store: %v", err)
+			}
+			cancel()
+		}()
+		_ = a.run(ctx)
+
+		var gotSent []string
+		for _, e := range roundTripper.events {
+			gotSent = append(gotSent, e.ID())
+		}
+		if diff := cmp.Diff(wantSent, gotSent); diff != "" {
+			t.Errorf("sent events (-want, +got) = %s", diff)
+		}
+		if cp.LastEventKey != 26 {
+			t.Errorf("run() checkpointKey = %v, wantEventKey 26", cp.LastEventKey)
+		}
+		if diff := cmp.Diff([]int32{23, 21, 22, 24, 25, 26}, cp.RecentEventKeys); diff != "" {
+			t.Errorf("recent event keys (-want, +got) = %s", diff)
+		}
+		return nil
+	})
+}
+
+func Test_vAdapter_runSavesCheckpointOnShutdown(t *testing.T) {
+	simulator.Run(func(ctx context.Context, vim *vim25.Client) error {
+		ctx = cecontext.WithTarget(ctx, "fake.example.com")
+
+		kv := &fakeKVStore{
+			data: map[string]string{
+				checkpointKey: createCheckpoint(t, time.Now().UTC().Add(-time.Hour)),
+			},
+			dataChan: make(chan string, 1),
+		}
+
+		roundTripper := &roundTripperTest{statusCodes: createStatusCodes(26, failNever)}
+		p, err := cehttp.New(cehttp.WithRoundTripper(roundTripper))
+		if err != nil {
+			t.Fatal(err)
+		}
+		c, err := client.New(p, client.WithTimeNow(), client.WithUUIDs())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		a := &vAdapter{
+			Logger:   zaptest.NewLogger(t).Sugar(),
+			Source:   source,
+			VClient:  &govmomi.Client{Client: vim, SessionManager: session.NewManager(vim)},
+			CEClient: c,
+			KVStore:  kv,
+			// never checkpoint periodically
+			CpConfig: CheckpointConfig{MaxAge: time.Hour, Period: time.Hour},
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		// stop once all events have been sent and checkpointed in memory
+		go func() {
+			for {
+				var cp checkpoint
+				if err := kv.Get(ctx, checkpointKey, &cp); err == nil && cp.LastEventKey == 26 {
+					cancel()
+					return
+				}
+				time.Sleep(time.Millisecond)
+			}
+		}()
+
+		if err := a.run(ctx); !errors.Is(err, context.Canceled) {
+			t.Errorf("run() error = %v, want %v", err, context.Canceled)
+		}
+
+		select {
+		case data := <-kv.dataChan:
+			var cp checkpoint
+			if err := json.Unmarshal([]byte(data), &cp); err != nil {
+				t.Fatalf("unmarshal data from KV store: %v", err)
+			}
+			if cp.LastEventKey != 26 {
+				t.Errorf("run() checkpointKey = %v, wantEventKey 26", cp.LastEventKey)
+			}
+		default:
+			t.Error("run() did not save checkpoint on shutdown")
+		}
+		return nil
+	})
+}
+
+func Test_vAdapter_polling(t *testing.T) {
+	a := &vAdapter{}
+	if b := a.backoff(); b.Min != PollDefaultMinBackoff || b.Max != PollDefaultMaxBackoff {
+		t.Errorf("backoff() = [%v, %v], want [%v, %v]", b.Min, b.Max, PollDefaultMinBackoff, PollDefaultMaxBackoff)
+	}
+	if got := a.batchSize(); got != PollDefaultBatchSize {
+		t.Errorf("batchSize() = %d, want %d", got, PollDefaultBatchSize)
+	}
+
+	a = &vAdapter{MinBackoff: 2 * time.Second, MaxBackoff: time.Minute, BatchSize: 500}
+	if b := a.backoff(); b.Min != 2*time.Second || b.Max != time.Minute {
+		t.Errorf("backoff() = [%v, %v], want [2s, 1m0s]", b.Min, b.Max)
+	}
+	if got := a.batchSize(); got != 500 {
+		t.Errorf("batchSize() = %d, want 500", got)
+	}
+}
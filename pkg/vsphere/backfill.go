@@ -0,0 +1,221 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vsphere
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/vmware/govmomi/vim25/types"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"knative.dev/pkg/logging"
+)
+
+// backfillRange is the time range of the events delivered by an adapter in
+// backfill mode
+type backfillRange struct {
+	Begin time.Time
+	End   time.Time
+}
+
+// backfill reads the events created in the backfill range and sends them to
+// the configured sink. It returns once all events are delivered or on the
+// first failed delivery, so that the backfill Job is retried. A retried Job
+// resumes after the last event reported in status.backfill. The checkpoint of
+// the source is neither read nor modified.
+func (a *vAdapter) backfill(ctx context.Context) error {
+	logger := logging.FromContext(ctx)
+
+	resume, err := a.BackfillReporter.Resume(ctx)
+	if err != nil {
+		return err
+	}
+	begin := a.Backfill.Begin
+	if resume.LastEventKeyTimestamp.After(begin) {
+		begin = resume.LastEventKeyTimestamp.Truncate(time.Second)
+	}
+	logger.Infow("backfilling events", zap.Time("beginTimestamp", begin),
+		zap.Time("endTimestamp", a.Backfill.End), zap.Int32("lastEventKey", resume.LastEventKey))
+
+	coll, err := newRangeHistoryCollector(ctx, a.VClient.Client, begin, a.Backfill.End)
+	if err != nil {
+		return fmt.Errorf("create event collector: %w", err)
+	}
+	// events sent by a previous attempt are skipped
+	dedup := newDeduplicator(resume, maxRecentEventKeys)
+
+	var (
+		sent       int
+		lastReport = time.Now()
+	)
+	for {
+		events, err := coll.ReadNextEvents(ctx, a.batchSize())
+		if err != nil {
+			return fmt.Errorf("read events from vcenter: %w", err)
+		}
+		if len(events) == 0 {
+			break
+		}
+
+		events = dedup.filter(ctx, events)
+		n, err := a.sendEvents(ctx, events)
+		if n > 0 {
+			sent += n
+			dedup.sent(events[:n])
+			a.BackfillReporter.Sent(n, events[n-1].GetEvent())
+		}
+		if err != nil {
+			a.flushBackfill(ctx)
+			return fmt.Errorf("send events: success %d (total %d): %w", n, len(events), err)
+		}
+
+		if time.Since(lastReport) >= a.CpConfig.Period {
+			a.flushBackfill(ctx)
+			lastReport = time.Now()
+		}
+	}
+
+	a.BackfillReporter.Complete()
+	a.flushBackfill(ctx)
+	logger.Infow("backfill complete", zap.Int("events", sent))
+	return nil
+}
+
+// flushBackfill reports the backfill progress, a failed report does not fail
+// the backfill
+func (a *vAdapter) flushBackfill(ctx context.Context) {
+	if err := a.BackfillReporter.Flush(ctx); err != nil {
+		logging.FromContext(ctx).Warnw("could not report backfill status", zap.Error(err))
+	}
+}
+
+// backfillReporter reports the progress of a backfill in status.backfill of
+// the source. Reports are only applied while status.backfill holds the same
+// time range, so an adapter of a replaced backfill cannot overwrite the
+// progress of the new one. A nil backfillReporter discards all reports.
+type backfillReporter struct {
+	client dynamic.ResourceInterface
+	name   string
+	rng    backfillRange
+
+	sent          int64
+	lastEventTime time.Time
+	lastEventKey  int32
+	completed     time.Time
+}
+
+func newBackfillReporter(client dynamic.ResourceInterface, name string, rng backfillRange) *backfillReporter {
+	return &backfillReporter{
+		client: client,
+		name:   name,
+		rng:    rng,
+	}
+}
+
+// Resume reads the progress of a previous attempt of the backfill from
+// status.backfill and continues reporting from there. It returns the last
+// event delivered by that attempt as checkpoint, which is empty if
+// status.backfill holds another time range.
+func (r *backfillReporter) Resume(ctx context.Context) (checkpoint, error) {
+	if r == nil {
+		return checkpoint{}, nil
+	}
+
+	src, err := r.client.Get(ctx, r.name, metav1.GetOptions{})
+	if err != nil {
+		return checkpoint{}, fmt.Errorf("get backfill status of source %q: %w", r.name, err)
+	}
+	status, found, err := unstructured.NestedMap(src.Object, "status", "backfill")
+	if err != nil || !found {
+		return checkpoint{}, err
+	}
+
+	var progress struct {
+		StartTime     metav1.Time  `json:"startTime"`
+		EndTime       metav1.Time  `json:"endTime"`
+		EventsSent    int64        `json:"eventsSent"`
+		LastEventTime *metav1.Time `json:"lastEventTime"`
+		LastEventKey  int32        `json:"lastEventKey"`
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(status, &progress); err != nil {
+		return checkpoint{}, fmt.Errorf("read backfill status of source %q: %w", r.name, err)
+	}
+	// the times in status.backfill have second granularity
+	if !progress.StartTime.Equal(&metav1.Time{Time: r.rng.Begin.Truncate(time.Second)}) ||
+		!progress.EndTime.Equal(&metav1.Time{Time: r.rng.End.Truncate(time.Second)}) ||
+		progress.LastEventTime == nil {
+		return checkpoint{}, nil
+	}
+
+	r.sent = progress.EventsSent
+	r.lastEventTime = progress.LastEventTime.Time
+	r.lastEventKey = progress.LastEventKey
+	return checkpoint{
+		LastEventKey:          progress.LastEventKey,
+		LastEventKeyTimestamp: progress.LastEventTime.Time,
+	}, nil
+}
+
+// Sent records the delivery of n events, the last one being last.
+func (r *backfillReporter) Sent(n int, last *types.Event) {
+	if r == nil {
+		return
+	}
+	r.sent += int64(n)
+	r.lastEventTime = last.CreatedTime
+	r.lastEventKey = last.Key
+}
+
+// Complete records that all events of the backfill range were delivered.
+func (r *backfillReporter) Complete() {
+	if r == nil {
+		return
+	}
+	r.completed = time.Now()
+}
+
+// Flush patches status.backfill of the source with the recorded progress.
+func (r *backfillReporter) Flush(ctx context.Context) error {
+	if r == nil {
+		return nil
+	}
+
+	ops := []map[string]interface{}{
+		{"op": "test", "path": "/status/backfill/startTime", "value": metav1.NewTime(r.rng.Begin)},
+		{"op": "test", "path": "/status/backfill/endTime", "value": metav1.NewTime(r.rng.End)},
+		{"op": "add", "path": "/status/backfill/eventsSent", "value": r.sent},
+	}
+	if !r.lastEventTime.IsZero() {
+		ops = append(ops, map[string]interface{}{
+			"op": "add", "path": "/status/backfill/lastEventTime", "value": metav1.NewTime(r.lastEventTime),
+		}, map[string]interface{}{
+			"op": "add", "path": "/status/backfill/lastEventKey", "value": r.lastEventKey,
+		})
+	}
+	if !r.completed.IsZero() {
+		ops = append(ops, map[string]interface{}{
+			"op": "add", "path": "/status/backfill/completionTime", "value": metav1.NewTime(r.completed),
+		})
+	}
+
+	patch, err := json.Marshal(ops)
+	if err != nil {
+		return fmt.Errorf("marshal backfill status patch: %w", err)
+	}
+
+	_, err = r.client.Patch(ctx, r.name, k8stypes.JSONPatchType, patch, metav1.PatchOptions{}, "status")
+	if err != nil {
+		return fmt.Errorf("patch backfill status of source %q: %w", r.name, err)
+	}
+	return nil
+}
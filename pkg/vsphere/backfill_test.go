@@ -0,0 +1,233 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vsphere
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cloudevents/sdk-go/v2/client"
+	cecontext "github.com/cloudevents/sdk-go/v2/context"
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/session"
+	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/types"
+	"go.uber.org/zap/zaptest"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+// newBackfillTestClient returns a client for a source whose status.backfill
+// holds rng
+func newBackfillTestClient(t *testing.T, rng backfillRange) dynamic.ResourceInterface {
+	t.Helper()
+
+	src := &unstructured.Unstructured{}
+	src.SetAPIVersion("sources.tanzu.vmware.com/v1alpha1")
+	src.SetKind("VSphereSource")
+	src.SetNamespace("ns")
+	src.SetName("source")
+	status := map[string]interface{}{
+		"startTime": metav1.NewTime(rng.Begin).UTC().Format(time.RFC3339),
+		"endTime":   metav1.NewTime(rng.End).UTC().Format(time.RFC3339),
+	}
+	if err := unstructured.SetNestedMap(src.Object, status, "status", "backfill"); err != nil {
+		t.Fatal(err)
+	}
+
+	dc := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{vsphereSourcesResource: "VSphereSourceList"}, src)
+	return dc.Resource(vsphereSourcesResource).Namespace("ns")
+}
+
+func Test_vAdapter_backfill(t *testing.T) {
+	const vcsimEvents = 26
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	tests := []struct {
+		name       string
+		rng        backfillRange
+		failAt     int
+		wantSent   int
+		wantErr    bool
+		wantStatus bool
+	}{{
+		name:     "all events in range",
+		rng:      backfillRange{Begin: now.Add(-time.Hour), End: now.Add(time.Hour)},
+		failAt:   failNever,
+		wantSent: vcsimEvents,
+	}, {
+		name:     "no events in range",
+		rng:      backfillRange{Begin: now.Add(-2 * time.Hour), End: now.Add(-time.Hour)},
+		failAt:   failNever,
+		wantSent: 0,
+	}, {
+		name:     "delivery fails",
+		rng:      backfillRange{Begin: now.Add(-time.Hour), End: now.Add(time.Hour)},
+		failAt:   2,
+		wantSent: 2,
+		wantErr:  true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			simulator.Run(func(ctx context.Context, vim *vim25.Client) error {
+				ctx = cecontext.WithTarget(ctx, "fake.example.com")
+
+				roundTripper := &roundTripperTest{statusCodes: createStatusCodes(vcsimEvents, tt.failAt)}
+				p, err := cehttp.New(cehttp.WithRoundTripper(roundTripper))
+				if err != nil {
+					t.Fatal(err)
+				}
+				c, err := client.New(p, client.WithTimeNow(), client.WithUUIDs())
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				dc := newBackfillTestClient(t, tt.rng)
+				a := &vAdapter{
+					Logger:           zaptest.NewLogger(t).Sugar(),
+					Source:           source,
+					VClient:          &govmomi.Client{Client: vim, SessionManager: session.NewManager(vim)},
+					CEClient:         c,
+					CpConfig:         CheckpointConfig{Period: time.Hour},
+					Backfill:         &tt.rng,
+					BackfillReporter: newBackfillReporter(dc, "source", tt.rng),
+				}
+
+				err = a.backfill(ctx)
+				if (err != nil) != tt.wantErr {
+					t.Errorf("backfill() error = %v, wantErr %v", err, tt.wantErr)
+				}
+
+				src, err := dc.Get(ctx, "source", metav1.GetOptions{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				sent, _, _ := unstructured.NestedInt64(src.Object, "status", "backfill", "eventsSent")
+				if int(sent) != tt.wantSent {
+					t.Errorf("status.backfill.eventsSent = %d, want %d", sent, tt.wantSent)
+				}
+				_, completed, _ := unstructured.NestedString(src.Object, "status", "backfill", "completionTime")
+				if completed == tt.wantErr {
+					t.Errorf("status.backfill.completionTime set = %v, want %v", completed, !tt.wantErr)
+				}
+				return nil
+			})
+		})
+	}
+}
+
+func Test_vAdapter_backfillRetried(t *testing.T) {
+	const (
+		vcsimEvents = 26
+		failAt      = 2
+	)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	rng := backfillRange{Begin: now.Add(-time.Hour), End: now.Add(time.Hour)}
+
+	simulator.Run(func(ctx context.Context, vim *vim25.Client) error {
+		ctx = cecontext.WithTarget(ctx, "fake.example.com")
+		dc := newBackfillTestClient(t, rng)
+
+		// attempt runs the backfill as a new pod of the Job would
+		attempt := func(failAt int) (*roundTripperTest, error) {
+			roundTripper := &roundTripperTest{statusCodes: createStatusCodes(vcsimEvents, failAt)}
+			p, err := cehttp.New(cehttp.WithRoundTripper(roundTripper))
+			if err != nil {
+				t.Fatal(err)
+			}
+			c, err := client.New(p, client.WithTimeNow(), client.WithUUIDs())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			a := &vAdapter{
+				Logger:           zaptest.NewLogger(t).Sugar(),
+				Source:           source,
+				VClient:          &govmomi.Client{Client: vim, SessionManager: session.NewManager(vim)},
+				CEClient:         c,
+				CpConfig:         CheckpointConfig{Period: time.Hour},
+				Backfill:         &rng,
+				BackfillReporter: newBackfillReporter(dc, "source", rng),
+			}
+			return roundTripper, a.backfill(ctx)
+		}
+
+		first, err := attempt(failAt)
+		if err == nil {
+			t.Fatal("backfill() with failed delivery succeeded")
+		}
+		second, err := attempt(failNever)
+		if err != nil {
+			t.Fatalf("backfill() of retried job error = %v", err)
+		}
+
+		// the retried attempt continues after the events delivered before
+		if second.requestCount != vcsimEvents-failAt {
+			t.Errorf("retried backfill sent %d events, want %d", second.requestCount, vcsimEvents-failAt)
+		}
+		delivered := map[string]bool{}
+		for _, e := range first.events[:failAt] {
+			delivered[e.ID()] = true
+		}
+		for _, e := range second.events {
+			if delivered[e.ID()] {
+				t.Errorf("retried backfill sent event %s again", e.ID())
+			}
+		}
+
+		src, err := dc.Get(ctx, "source", metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sent, _, _ := unstructured.NestedInt64(src.Object, "status", "backfill", "eventsSent"); sent != vcsimEvents {
+			t.Errorf("status.backfill.eventsSent = %d, want %d", sent, vcsimEvents)
+		}
+		return nil
+	})
+}
+
+func Test_backfillReporter_replacedRange(t *testing.T) {
+	ctx := context.TODO()
+	begin := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	dc := newBackfillTestClient(t, backfillRange{Begin: begin, End: begin.Add(time.Hour)})
+
+	// the reporter of a backfill whose range was replaced
+	r := newBackfillReporter(dc, "source", backfillRange{Begin: begin, End: begin.Add(2 * time.Hour)})
+	r.Sent(5, &types.Event{Key: 5, CreatedTime: begin.Add(time.Minute)})
+	if err := r.Flush(ctx); err == nil {
+		t.Error("Flush() for replaced backfill range succeeded")
+	}
+
+	src, err := dc.Get(ctx, "source", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, found, _ := unstructured.NestedInt64(src.Object, "status", "backfill", "eventsSent"); found {
+		t.Error("Flush() for replaced backfill range reported progress")
+	}
+
+	var nilReporter *backfillReporter
+	if cp, err := nilReporter.Resume(ctx); err != nil || cp.LastEventKey != 0 {
+		t.Errorf("Resume() of nil reporter = %v, %v", cp, err)
+	}
+	nilReporter.Sent(1, &types.Event{Key: 1, CreatedTime: begin})
+	nilReporter.Complete()
+	if err := nilReporter.Flush(ctx); err != nil {
+		t.Errorf("Flush() of nil reporter = %v", err)
+	}
+}
@@ -8,7 +8,11 @@ package vsphere
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"strings"
 	"time"
+
+	"github.com/vmware/govmomi/vim25/soap"
 )
 
 const (
@@ -27,6 +31,10 @@ var (
 // checkpoint represents a vCenter checkpoint object
 type checkpoint struct {
 	VCenter string `json:"vCenter"`
+	// instance UUID and API version of the vCenter, used to detect when the
+	// checkpoint does not belong to the connected vCenter anymore
+	InstanceUUID string `json:"instanceUuid,omitempty"`
+	APIVersion   string `json:"apiVersion,omitempty"`
 	// last vCenter event key successfully processed
 	LastEventKey int32 `json:"lastEventKey"`
 	// last event type, e.g. VmPoweredOffEvent useful for debugging
@@ -36,6 +44,39 @@ type checkpoint struct {
 	LastEventKeyTimestamp time.Time `json:"lastEventKeyTimestamp"`
 	// timestamp (UTC) when this checkpoint was created
 	CreatedTimestamp time.Time `json:"createdTimestamp"`
+	// bounded list of recently sent vCenter event keys, oldest first - used to
+	// skip already sent events when replaying the event stream
+	RecentEventKeys []int32 `json:"recentEventKeys,omitempty"`
+}
+
+// ConfigMapCheckpoint returns the vCenter and the creation time of the last
+// sent event of the checkpoint stored in the data of a checkpoint ConfigMap.
+func ConfigMapCheckpoint(data map[string]string) (string, time.Time, error) {
+	raw, ok := data[checkpointKey]
+	if !ok {
+		return "", time.Time{}, errors.New("no checkpoint found")
+	}
+
+	var cp checkpoint
+	if err := json.Unmarshal([]byte(raw), &cp); err != nil {
+		return "", time.Time{}, fmt.Errorf("unmarshal checkpoint: %w", err)
+	}
+	return cp.VCenter, cp.LastEventKeyTimestamp, nil
+}
+
+// SameVCenter returns whether both addresses refer to the same vCenter. The
+// addresses are compared by host and port, so the address of a source matches
+// the vCenter recorded in its checkpoints.
+func SameVCenter(a, b string) bool {
+	ua, err := soap.ParseURL(a)
+	if err != nil || ua == nil {
+		return false
+	}
+	ub, err := soap.ParseURL(b)
+	if err != nil || ub == nil {
+		return false
+	}
+	return strings.EqualFold(ua.Host, ub.Host)
 }
 
 // CheckpointConfig influences the checkpoint behavior. It configures the
@@ -0,0 +1,273 @@
+/*
+Copyright 2020 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vsphere
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"knative.dev/pkg/injection/clients/dynamicclient"
+	"knative.dev/pkg/kvstore"
+	"knative.dev/pkg/logging"
+
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+)
+
+const (
+	// CheckpointStoreConfigMap stores checkpoints in a ConfigMap owned by the
+	// source.
+	CheckpointStoreConfigMap = "configmap"
+	// CheckpointStoreStatus stores checkpoints in status.checkpoint of the
+	// source.
+	CheckpointStoreStatus = "status"
+	// CheckpointStoreFile stores checkpoints in a local file, e.g. on a
+	// PersistentVolume.
+	CheckpointStoreFile = "file"
+)
+
+// CheckpointStore persists the checkpoint of the adapter. Set only stages a
+// value, Save persists all staged values.
+type CheckpointStore interface {
+	// Init loads the store, creating it if necessary.
+	Init(ctx context.Context) error
+	// Get retrieves and unmarshals the value for the key.
+	Get(ctx context.Context, key string, value interface{}) error
+	// Set marshals and stages the value for the key.
+	Set(ctx context.Context, key string, value interface{}) error
+	// Save persists all staged values.
+	Save(ctx context.Context) error
+}
+
+var vsphereSourcesResource = schema.GroupVersionResource{
+	Group:    "sources.tanzu.vmware.com",
+	Version:  "v1alpha1",
+	Resource: "vspheresources",
+}
+
+// newCheckpointStore returns the initialized checkpoint store configured in
+// env. Other stores than the ConfigMap store are seeded with the checkpoint
+// from an existing ConfigMap when they don't hold a checkpoint yet, so that
+// sources can switch stores without replaying or losing events.
+func newCheckpointStore(ctx context.Context, env *envConfig) (CheckpointStore, error) {
+	cm := kvstore.NewConfigMapKVStore(ctx, env.KVConfigMap, env.Namespace, kubeclient.Get(ctx).CoreV1())
+
+	var store CheckpointStore
+	switch env.CheckpointStore {
+	case "", CheckpointStoreConfigMap:
+		return cm, cm.Init(ctx)
+	case CheckpointStoreStatus:
+		if env.SourceName == "" {
+			return nil, errors.New("status checkpoint store requires the source name")
+		}
+		client := dynamicclient.Get(ctx).Resource(vsphereSourcesResource).Namespace(env.Namespace)
+		store = newStatusStore(client, env.SourceName)
+	case CheckpointStoreFile:
+		store = newFileStore(env.CheckpointFile)
+	default:
+		return nil, fmt.Errorf("unknown checkpoint store %q", env.CheckpointStore)
+	}
+
+	if err := store.Init(ctx); err != nil {
+		return nil, err
+	}
+	if err := migrateCheckpoint(ctx, cm, store); err != nil {
+		return nil, fmt.Errorf("migrate checkpoint: %w", err)
+	}
+	return store, nil
+}
+
+// migrateCheckpoint copies the checkpoint from the ConfigMap store to the
+// given store unless it already holds a checkpoint.
+func migrateCheckpoint(ctx context.Context, from kvstore.Interface, to CheckpointStore) error {
+	var cp checkpoint
+	if err := to.Get(ctx, checkpointKey, &cp); err == nil {
+		return nil
+	}
+
+	// Load does not create a missing ConfigMap, unlike Init.
+	if err := from.Load(ctx); err != nil {
+		logging.FromContext(ctx).Debugw("not migrating checkpoint: could not load ConfigMap", zap.Error(err))
+		return nil
+	}
+	if err := from.Get(ctx, checkpointKey, &cp); err != nil {
+		return nil
+	}
+
+	logging.FromContext(ctx).Infow("migrating checkpoint from ConfigMap", zap.Any("checkpoint", cp))
+	if err := to.Set(ctx, checkpointKey, cp); err != nil {
+		return err
+	}
+	return to.Save(ctx)
+}
+
+// mapStore holds JSON encoded values in memory.
+type mapStore map[string]string
+
+// Get implements CheckpointStore
+func (m mapStore) Get(ctx context.Context, key string, value interface{}) error {
+	v, ok := m[key]
+	if !ok {
+		return fmt.Errorf("key %s does not exist", key)
+	}
+	if err := json.Unmarshal([]byte(v), value); err != nil {
+		return fmt.Errorf("failed to Unmarshal %q: %w", v, err)
+	}
+	return nil
+}
+
+// Set implements CheckpointStore
+func (m mapStore) Set(ctx context.Context, key string, value interface{}) error {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to Marshal: %w", err)
+	}
+	m[key] = string(b)
+	return nil
+}
+
+// fileStore stores values as a JSON object in a local file.
+type fileStore struct {
+	mapStore
+	path string
+}
+
+func newFileStore(path string) *fileStore {
+	return &fileStore{mapStore: mapStore{}, path: path}
+}
+
+// Init implements CheckpointStore
+func (f *fileStore) Init(ctx context.Context) error {
+	b, err := os.ReadFile(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		logging.FromContext(ctx).Infow("no checkpoint file found", zap.String("path", f.path))
+		return os.MkdirAll(filepath.Dir(f.path), 0o755)
+	}
+	if err != nil {
+		return fmt.Errorf("read checkpoint file: %w", err)
+	}
+	if err := json.Unmarshal(b, &f.mapStore); err != nil {
+		return fmt.Errorf("unmarshal checkpoint file %q: %w", f.path, err)
+	}
+	return nil
+}
+
+// Save implements CheckpointStore. The file is replaced atomically so that a
+// crash never leaves a partially written checkpoint behind.
+func (f *fileStore) Save(ctx context.Context) error {
+	b, err := json.Marshal(f.mapStore)
+	if err != nil {
+		return err
+	}
+	tmp := f.path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, f.path)
+}
+
+// statusStore stores the checkpoint in status.checkpoint of a VSphereSource.
+// Only the checkpoint key is supported.
+type statusStore struct {
+	client dynamic.ResourceInterface
+	name   string
+	data   json.RawMessage
+	// stored is true once status.checkpoint exists
+	stored bool
+}
+
+func newStatusStore(client dynamic.ResourceInterface, name string) *statusStore {
+	return &statusStore{client: client, name: name}
+}
+
+// Init implements CheckpointStore
+func (s *statusStore) Init(ctx context.Context) error {
+	u, err := s.client.Get(ctx, s.name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("get source %q: %w", s.name, err)
+	}
+	cp, found, err := unstructured.NestedFieldNoCopy(u.Object, "status", "checkpoint")
+	if err != nil || !found {
+		return err
+	}
+	s.stored = true
+	s.data, err = json.Marshal(cp)
+	return err
+}
+
+// Get implements CheckpointStore
+func (s *statusStore) Get(ctx context.Context, key string, value interface{}) error {
+	if key != checkpointKey {
+		return fmt.Errorf("unsupported key %s", key)
+	}
+	if s.data == nil {
+		return fmt.Errorf("key %s does not exist", key)
+	}
+	return json.Unmarshal(s.data, value)
+}
+
+// Set implements CheckpointStore
+func (s *statusStore) Set(ctx context.Context, key string, value interface{}) error {
+	if key != checkpointKey {
+		return fmt.Errorf("unsupported key %s", key)
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to Marshal: %w", err)
+	}
+	s.data = b
+	return nil
+}
+
+// Save implements CheckpointStore. An existing checkpoint is replaced as a
+// whole, so that fields omitted by the new checkpoint do not survive from the
+// old one as they would in a merge patch.
+func (s *statusStore) Save(ctx context.Context) error {
+	if s.data == nil {
+		return nil
+	}
+
+	var (
+		patch     []byte
+		patchType k8stypes.PatchType
+		err       error
+	)
+	if s.stored {
+		// add replaces the value of an existing member
+		patchType = k8stypes.JSONPatchType
+		patch, err = json.Marshal([]map[string]interface{}{{
+			"op":    "add",
+			"path":  "/status/checkpoint",
+			"value": s.data,
+		}})
+	} else {
+		// creates the status if the source has none yet
+		patchType = k8stypes.MergePatchType
+		patch, err = json.Marshal(map[string]interface{}{
+			"status": map[string]json.RawMessage{
+				"checkpoint": s.data,
+			},
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, err = s.client.Patch(ctx, s.name, patchType, patch, metav1.PatchOptions{}, "status"); err != nil {
+		return err
+	}
+	s.stored = true
+	return nil
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2020 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vsphere
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"knative.dev/pkg/kvstore"
+)
+
+func testCheckpoint() checkpoint {
+	ts := time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)
+	return checkpoint{
+		VCenter:               source,
+		LastEventKey:          42,
+		LastEventType:         "VmPoweredOnEvent",
+		LastEventKeyTimestamp: ts,
+		CreatedTimestamp:      ts,
+		RecentEventKeys:       []int32{41, 42},
+	}
+}
+
+func Test_fileStore(t *testing.T) {
+	ctx := context.TODO()
+	path := filepath.Join(t.TempDir(), "checkpoint", "checkpoint.json")
+
+	s := newFileStore(path)
+	if err := s.Init(ctx); err != nil {
+		t.Fatalf("Init() = %v", err)
+	}
+	var cp checkpoint
+	if err := s.Get(ctx, checkpointKey, &cp); err == nil {
+		t.Error("Get() on empty store succeeded")
+	}
+
+	want := testCheckpoint()
+	if err := s.Set(ctx, checkpointKey, want); err != nil {
+		t.Fatalf("Set() = %v", err)
+	}
+	if err := s.Save(ctx); err != nil {
+		t.Fatalf("Save() = %v", err)
+	}
+
+	// a new store reads the saved checkpoint
+	s = newFileStore(path)
+	if err := s.Init(ctx); err != nil {
+		t.Fatalf("Init() = %v", err)
+	}
+	if err := s.Get(ctx, checkpointKey, &cp); err != nil {
+		t.Fatalf("Get() = %v", err)
+	}
+	if diff := cmp.Diff(want, cp); diff != "" {
+		t.Errorf("Get() (-want, +got) = %s", diff)
+	}
+}
+
+func Test_statusStore(t *testing.T) {
+	ctx := context.TODO()
+
+	src := &unstructured.Unstructured{}
+	src.SetAPIVersion("sources.tanzu.vmware.com/v1alpha1")
+	src.SetKind("VSphereSource")
+	src.SetNamespace("ns")
+	src.SetName("source")
+	dc := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{vsphereSourcesResource: "VSphereSourceList"}, src)
+	client := dc.Resource(vsphereSourcesResource).Namespace("ns")
+
+	s := newStatusStore(client, "source")
+	if err := s.Init(ctx); err != nil {
+		t.Fatalf("Init() = %v", err)
+	}
+	var cp checkpoint
+	if err := s.Get(ctx, checkpointKey, &cp); err == nil {
+		t.Error("Get() on empty status succeeded")
+	}
+
+	want := testCheckpoint()
+	if err := s.Set(ctx, checkpointKey, want); err != nil {
+		t.Fatalf("Set() = %v", err)
+	}
+	if err := s.Save(ctx); err != nil {
+		t.Fatalf("Save() = %v", err)
+	}
+
+	// a new store reads the checkpoint from the status
+	s = newStatusStore(client, "source")
+	if err := s.Init(ctx); err != nil {
+		t.Fatalf("Init() = %v", err)
+	}
+	if err := s.Get(ctx, checkpointKey, &cp); err != nil {
+		t.Fatalf("Get() = %v", err)
+	}
+	if diff := cmp.Diff(want, cp); diff != "" {
+		t.Errorf("Get() (-want, +got) = %s", diff)
+	}
+
+	// fields omitted by a new checkpoint are removed from the status
+	want = checkpoint{LastEventKey: 43}
+	if err := s.Set(ctx, checkpointKey, want); err != nil {
+		t.Fatalf("Set() = %v", err)
+	}
+	if err := s.Save(ctx); err != nil {
+		t.Fatalf("Save() = %v", err)
+	}
+	s = newStatusStore(client, "source")
+	if err := s.Init(ctx); err != nil {
+		t.Fatalf("Init() = %v", err)
+	}
+	cp = checkpoint{}
+	if err := s.Get(ctx, checkpointKey, &cp); err != nil {
+		t.Fatalf("Get() = %v", err)
+	}
+	if diff := cmp.Diff(want, cp); diff != "" {
+		t.Errorf("Get() after reset (-want, +got) = %s", diff)
+	}
+
+	if err := s.Set(ctx, "other", want); err == nil {
+		t.Error("Set() with unsupported key succeeded")
+	}
+}
+
+func Test_migrateCheckpoint(t *testing.T) {
+	ctx := context.TODO()
+	want := testCheckpoint()
+
+	kc := kubefake.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "source-configmap"},
+	})
+	cm := kvstore.NewConfigMapKVStore(ctx, "source-configmap", "ns", kc.CoreV1())
+	if err := cm.Init(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := cm.Set(ctx, checkpointKey, want); err != nil {
+		t.Fatal(err)
+	}
+	if err := cm.Save(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	to := newFileStore(filepath.Join(t.TempDir(), "checkpoint.json"))
+	if err := migrateCheckpoint(ctx, cm, to); err != nil {
+		t.Fatalf("migrateCheckpoint() = %v", err)
+	}
+	var cp checkpoint
+	if err := to.Get(ctx, checkpointKey, &cp); err != nil {
+		t.Fatalf("Get() = %v", err)
+	}
+	if diff := cmp.Diff(want, cp); diff != "" {
+		t.Errorf("migrated checkpoint (-want, +got) = %s", diff)
+	}
+
+	// an existing checkpoint is not overwritten
+	newer := want
+	newer.LastEventKey = 100
+	if err := to.Set(ctx, checkpointKey, newer); err != nil {
+		t.Fatal(err)
+	}
+	if err := migrateCheckpoint(ctx, cm, to); err != nil {
+		t.Fatalf("migrateCheckpoint() = %v", err)
+	}
+	if err := to.Get(ctx, checkpointKey, &cp); err != nil || cp.LastEventKey != 100 {
+		t.Errorf("Get() = %v, %v, wanted LastEventKey 100", cp.LastEventKey, err)
+	}
+
+	// a missing ConfigMap is not an error
+	missing := kvstore.NewConfigMapKVStore(ctx, "missing", "ns", kc.CoreV1())
+	if err := migrateCheckpoint(ctx, missing, newFileStore(filepath.Join(t.TempDir(), "checkpoint.json"))); err != nil {
+		t.Errorf("migrateCheckpoint() with missing ConfigMap = %v", err)
+	}
+}
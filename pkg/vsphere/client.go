@@ -58,6 +58,20 @@ func ReadKey(key string) (string, error) {
 	return string(data), nil
 }
 
+// ReadCredentials reads the username and password from the secret. The secret
+// is read on each call, so that a new login uses rotated credentials.
+func ReadCredentials() (*url.Userinfo, error) {
+	username, err := ReadKey(corev1.BasicAuthUsernameKey)
+	if err != nil {
+		return nil, err
+	}
+	password, err := ReadKey(corev1.BasicAuthPasswordKey)
+	if err != nil {
+		return nil, err
+	}
+	return url.UserPassword(username, password), nil
+}
+
 // NewSOAPClient returns a vCenter SOAP API client with active keep-alive. Use
 // Logout() to release resources and perform a clean logout from vCenter.
 func NewSOAPClient(ctx context.Context) (*govmomi.Client, error) {
@@ -71,16 +85,10 @@ func NewSOAPClient(ctx context.Context) (*govmomi.Client, error) {
 		return nil, err
 	}
 
-	// Read the username and password from the filesystem.
-	username, err := ReadKey(corev1.BasicAuthUsernameKey)
-	if err != nil {
-		return nil, err
-	}
-	password, err := ReadKey(corev1.BasicAuthPasswordKey)
+	parsedURL.User, err = ReadCredentials()
 	if err != nil {
 		return nil, err
 	}
-	parsedURL.User = url.UserPassword(username, password)
 
 	return soapWithKeepalive(ctx, parsedURL, env.Insecure)
 }
@@ -136,16 +144,10 @@ func NewRESTClient(ctx context.Context) (*rest.Client, error) {
 		return nil, err
 	}
 
-	// Read the username and password from the filesystem.
-	username, err := ReadKey(corev1.BasicAuthUsernameKey)
-	if err != nil {
-		return nil, err
-	}
-	password, err := ReadKey(corev1.BasicAuthPasswordKey)
+	parsedURL.User, err = ReadCredentials()
 	if err != nil {
 		return nil, err
 	}
-	parsedURL.User = url.UserPassword(username, password)
 
 	soapclient, err := soapWithKeepalive(ctx, parsedURL, env.Insecure)
 	if err != nil {
@@ -0,0 +1,99 @@
+/*
+Copyright 2020 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vsphere
+
+import (
+	"context"
+	"time"
+
+	"github.com/vmware/govmomi/vim25/types"
+	"go.uber.org/zap"
+	"knative.dev/pkg/logging"
+)
+
+const (
+	// number of recently sent event keys kept in the checkpoint to detect
+	// duplicates across restarts
+	maxRecentEventKeys = 500
+)
+
+// deduplicator drops events which have already been sent before the event
+// stream was replayed from a checkpoint. Since the replay begins at the
+// checkpointed event timestamp with second granularity, events sharing that
+// second with a key not larger than the checkpointed key are dropped. In
+// addition, a bounded set of recently sent keys is kept and stored in the
+// checkpoint to catch duplicates outside of that second.
+type deduplicator struct {
+	lastKey  int32
+	lastTime time.Time
+
+	// recent holds the keys in the order they were sent, oldest first
+	recent []int32
+	index  map[int32]struct{}
+	size   int
+}
+
+// newDeduplicator returns a deduplicator for an event stream replayed from
+// the given checkpoint, keeping at most size recently sent keys.
+func newDeduplicator(cp checkpoint, size int) *deduplicator {
+	d := &deduplicator{
+		lastKey:  cp.LastEventKey,
+		lastTime: cp.LastEventKeyTimestamp.Truncate(time.Second),
+		index:    make(map[int32]struct{}, size),
+		size:     size,
+	}
+	d.add(cp.RecentEventKeys...)
+	return d
+}
+
+// isDuplicate returns whether the event has already been sent.
+func (d *deduplicator) isDuplicate(be types.BaseEvent) bool {
+	e := be.GetEvent()
+	if _, ok := d.index[e.Key]; ok {
+		return true
+	}
+	return !d.lastTime.IsZero() && e.Key <= d.lastKey && e.CreatedTime.Truncate(time.Second).Equal(d.lastTime)
+}
+
+// filter returns the events which have not been sent yet, preserving their
+// order.
+func (d *deduplicator) filter(ctx context.Context, baseEvents []types.BaseEvent) []types.BaseEvent {
+	filtered := baseEvents[:0:0]
+	for _, be := range baseEvents {
+		if d.isDuplicate(be) {
+			logging.FromContext(ctx).Debugw("skipping duplicate event", zap.Int32("eventKey", be.GetEvent().Key))
+			continue
+		}
+		filtered = append(filtered, be)
+	}
+	return filtered
+}
+
+// sent records the given events as sent.
+func (d *deduplicator) sent(baseEvents []types.BaseEvent) {
+	for _, be := range baseEvents {
+		d.add(be.GetEvent().Key)
+	}
+}
+
+func (d *deduplicator) add(keys ...int32) {
+	for _, k := range keys {
+		if _, ok := d.index[k]; ok {
+			continue
+		}
+		if len(d.recent) == d.size {
+			delete(d.index, d.recent[0])
+			d.recent = d.recent[1:]
+		}
+		d.recent = append(d.recent, k)
+		d.index[k] = struct{}{}
+	}
+}
+
+// keys returns the recently sent keys, oldest first.
+func (d *deduplicator) keys() []int32 {
+	return append([]int32(nil), d.recent...)
+}
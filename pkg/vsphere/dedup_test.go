@@ -0,0 +1,64 @@
+/*
+Copyright 2020 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vsphere
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func Test_deduplicator(t *testing.T) {
+	now := time.Date(2021, 1, 1, 12, 0, 0, 500, time.UTC)
+	cp := checkpoint{
+		LastEventKey:          10,
+		LastEventKeyTimestamp: now,
+		RecentEventKeys:       []int32{11},
+	}
+
+	keys := func(baseEvents []types.BaseEvent) []int32 {
+		var k []int32
+		for _, be := range baseEvents {
+			k = append(k, be.GetEvent().Key)
+		}
+		return k
+	}
+
+	d := newDeduplicator(cp, 3)
+	got := d.filter(context.TODO(), []types.BaseEvent{
+		createBaseEvent(9, now.Truncate(time.Second)), // same second as checkpoint
+		createBaseEvent(10, now),                      // checkpointed event
+		createBaseEvent(11, now.Add(time.Second)),     // recently sent
+		createBaseEvent(12, now),                      // newer key in same second
+		createBaseEvent(13, now.Add(time.Second)),
+	})
+	if diff := cmp.Diff([]int32{12, 13}, keys(got)); diff != "" {
+		t.Errorf("filter() (-want, +got) = %s", diff)
+	}
+
+	// recorded keys are bounded, evicting the oldest
+	d.sent(got)
+	d.sent([]types.BaseEvent{createBaseEvent(14, now.Add(time.Second))})
+	if diff := cmp.Diff([]int32{12, 13, 14}, d.keys()); diff != "" {
+		t.Errorf("keys() (-want, +got) = %s", diff)
+	}
+	got = d.filter(context.TODO(), []types.BaseEvent{
+		createBaseEvent(11, now.Add(time.Second)),
+		createBaseEvent(14, now.Add(time.Second)),
+	})
+	if diff := cmp.Diff([]int32{11}, keys(got)); diff != "" {
+		t.Errorf("filter() after eviction (-want, +got) = %s", diff)
+	}
+
+	// an empty checkpoint skips nothing
+	d = newDeduplicator(checkpoint{}, 3)
+	if got := d.filter(context.TODO(), []types.BaseEvent{createBaseEvent(0, time.Time{})}); len(got) != 1 {
+		t.Errorf("filter() with empty checkpoint = %v, wanted 1 event", keys(got))
+	}
+}
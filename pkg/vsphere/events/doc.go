@@ -0,0 +1,20 @@
+/*
+Copyright 2020 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package events holds helpers for functions consuming CloudEvents produced
+// by a VSphereSource. Decode returns the concrete vSphere event carried in a
+// CloudEvent, regardless of the payload encoding configured on the source:
+//
+//	be, err := events.Decode(event)
+//
+// A Handler dispatches CloudEvents to typed callbacks and can be passed to a
+// CloudEvents receiver directly:
+//
+//	h := events.NewHandler()
+//	h.OnVmCreated(func(ctx context.Context, e *types.VmCreatedEvent) error {
+//		return tagVM(ctx, e.Vm.Vm)
+//	})
+//	err := ceclient.StartReceiver(ctx, h.Handle)
+package events
@@ -0,0 +1,141 @@
+/*
+Copyright 2020 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package events
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/vmware/govmomi/vim25/types"
+	"github.com/vmware/govmomi/vim25/xml"
+)
+
+const (
+	// TypePrefix and TypeSuffix wrap the vSphere event type in the CloudEvent
+	// type, e.g. com.vmware.vsphere.VmPoweredOnEvent.v0
+	TypePrefix = "com.vmware.vsphere."
+	TypeSuffix = ".v0"
+
+	// ExtensionEventClass is the CloudEvent extension holding the vSphere
+	// event class.
+	ExtensionEventClass = "eventclass"
+	// ExtensionAPIVersion is the CloudEvent extension holding the vSphere API
+	// version of the vCenter which emitted the event.
+	ExtensionAPIVersion = "vsphereapiversion"
+
+	// ClassEvent is the event class of all events derived from types.Event
+	// other than types.EventEx and types.ExtendedEvent.
+	ClassEvent = "event"
+	// ClassEventEx is the event class of types.EventEx.
+	ClassEventEx = "eventex"
+	// ClassExtendedEvent is the event class of types.ExtendedEvent.
+	ClassExtendedEvent = "extendedevent"
+)
+
+// Type returns the CloudEvent type for the given vSphere event type, e.g.
+// VmPoweredOnEvent or an EventEx/ExtendedEvent type ID.
+func Type(eventType string) string {
+	return TypePrefix + eventType + TypeSuffix
+}
+
+// Details returns the vSphere event type and class of the given CloudEvent as
+// set by a VSphereSource.
+func Details(event cloudevents.Event) (eventType, class string, err error) {
+	t := event.Type()
+	if !strings.HasPrefix(t, TypePrefix) || !strings.HasSuffix(t, TypeSuffix) ||
+		len(t) <= len(TypePrefix)+len(TypeSuffix) {
+		return "", "", fmt.Errorf("unsupported event type %q", t)
+	}
+	eventType = strings.TrimSuffix(strings.TrimPrefix(t, TypePrefix), TypeSuffix)
+
+	ext, ok := event.Extensions()[ExtensionEventClass]
+	if !ok {
+		return "", "", fmt.Errorf("missing extension %q", ExtensionEventClass)
+	}
+	class, ok = ext.(string)
+	if !ok {
+		return "", "", fmt.Errorf("invalid extension %q: %v", ExtensionEventClass, ext)
+	}
+	return eventType, class, nil
+}
+
+// newEvent returns a pointer to a new zero value of the concrete vSphere event
+// for the given type and class.
+func newEvent(eventType, class string) (types.BaseEvent, error) {
+	switch class {
+	case ClassEventEx:
+		return &types.EventEx{}, nil
+	case ClassExtendedEvent:
+		return &types.ExtendedEvent{}, nil
+	case ClassEvent:
+		typ, ok := types.TypeFunc()(eventType)
+		if !ok {
+			return nil, fmt.Errorf("unknown vSphere event type %q", eventType)
+		}
+		be, ok := reflect.New(typ).Interface().(types.BaseEvent)
+		if !ok {
+			return nil, fmt.Errorf("vSphere type %q is not an event", eventType)
+		}
+		return be, nil
+	default:
+		return nil, fmt.Errorf("unsupported event class %q", class)
+	}
+}
+
+// SetData sets the given vSphere event as the data of the CloudEvent in the
+// given encoding. JSON encoded events carry the vSphere type names of values
+// in interface fields, see MarshalJSON.
+func SetData(event *cloudevents.Event, encoding string, be types.BaseEvent) error {
+	switch encoding {
+	case cloudevents.ApplicationJSON, "text/json":
+		data, err := MarshalJSON(be)
+		if err != nil {
+			return fmt.Errorf("encode JSON event data: %w", err)
+		}
+		return event.SetData(encoding, data)
+	default:
+		return event.SetData(encoding, be)
+	}
+}
+
+// Decode decodes the data of a CloudEvent produced by a VSphereSource into
+// the concrete vSphere event, e.g. *types.VmCreatedEvent, using the type and
+// event class of the CloudEvent. Both XML and JSON payload encodings are
+// supported.
+func Decode(event cloudevents.Event) (types.BaseEvent, error) {
+	eventType, class, err := Details(event)
+	if err != nil {
+		return nil, err
+	}
+
+	be, err := newEvent(eventType, class)
+	if err != nil {
+		return nil, err
+	}
+
+	data := event.Data()
+	switch ct := event.DataMediaType(); ct {
+	case cloudevents.ApplicationXML, "text/xml":
+		// the govmomi decoder resolves interface fields, e.g.
+		// types.BaseMethodFault, from their xsi:type
+		dec := xml.NewDecoder(bytes.NewReader(data))
+		dec.TypeFunc = types.TypeFunc()
+		if err = dec.Decode(be); err != nil {
+			return nil, fmt.Errorf("decode XML event data: %w", err)
+		}
+	case cloudevents.ApplicationJSON, "text/json":
+		if err = UnmarshalJSON(data, be); err != nil {
+			return nil, fmt.Errorf("decode JSON event data: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported data content type %q", ct)
+	}
+
+	return be, nil
+}
@@ -0,0 +1,298 @@
+/*
+Copyright 2020 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package events
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/google/go-cmp/cmp"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func newTestEvent(t *testing.T, be types.BaseEvent, eventType, class, encoding string) cloudevents.Event {
+	t.Helper()
+
+	ev := cloudevents.NewEvent(cloudevents.VersionV1)
+	ev.SetID("1")
+	ev.SetSource("https://vcenter.local/sdk")
+	ev.SetType(Type(eventType))
+	ev.SetExtension(ExtensionEventClass, class)
+	ev.SetExtension(ExtensionAPIVersion, "6.7.0")
+	if err := ev.SetData(encoding, be); err != nil {
+		t.Fatalf("set data: %v", err)
+	}
+	return ev
+}
+
+func TestDecode(t *testing.T) {
+	created := time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)
+	vmCreated := &types.VmCreatedEvent{
+		VmEvent: types.VmEvent{
+			Event: types.Event{
+				Key:         42,
+				CreatedTime: created,
+				UserName:    "administrator@vsphere.local",
+				Vm: &types.VmEventArgument{
+					EntityEventArgument: types.EntityEventArgument{Name: "vm-1"},
+					Vm: types.ManagedObjectReference{
+						Type:  "VirtualMachine",
+						Value: "vm-1",
+					},
+				},
+			},
+		},
+	}
+	eventEx := &types.EventEx{
+		Event: types.Event{
+			Key:         43,
+			CreatedTime: created,
+		},
+		EventTypeId: "com.vmware.vc.HA.ClusterFailoverActionCompletedEvent",
+		Severity:    "info",
+	}
+	extended := &types.ExtendedEvent{
+		GeneralEvent: types.GeneralEvent{
+			Event: types.Event{
+				Key:         44,
+				CreatedTime: created,
+			},
+		},
+		EventTypeId: "com.vmware.applmgmt.backup.job.failed.event",
+	}
+
+	tests := []struct {
+		name      string
+		event     types.BaseEvent
+		eventType string
+		class     string
+	}{{
+		name:      "event",
+		event:     vmCreated,
+		eventType: "VmCreatedEvent",
+		class:     ClassEvent,
+	}, {
+		name:      "eventex",
+		event:     eventEx,
+		eventType: eventEx.EventTypeId,
+		class:     ClassEventEx,
+	}, {
+		name:      "extendedevent",
+		event:     extended,
+		eventType: extended.EventTypeId,
+		class:     ClassExtendedEvent,
+	}}
+
+	for _, encoding := range []string{cloudevents.ApplicationXML, cloudevents.ApplicationJSON} {
+		for _, tt := range tests {
+			t.Run(encoding+"/"+tt.name, func(t *testing.T) {
+				ev := newTestEvent(t, tt.event, tt.eventType, tt.class, encoding)
+
+				got, err := Decode(ev)
+				if err != nil {
+					t.Fatalf("Decode() error = %v", err)
+				}
+				if diff := cmp.Diff(tt.event, got); diff != "" {
+					t.Errorf("Decode() (-want, +got) = %s", diff)
+				}
+			})
+		}
+	}
+}
+
+func TestDecodeJSON(t *testing.T) {
+	created := time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)
+	failed := func(fault types.BaseMethodFault, args ...types.KeyAnyValue) *types.EventEx {
+		return &types.EventEx{
+			Event: types.Event{
+				Key:                  45,
+				ChainId:              45,
+				CreatedTime:          created,
+				UserName:             "administrator@vsphere.local",
+				FullFormattedMessage: "Reconfigure of vm-1 failed",
+			},
+			EventTypeId: "com.vmware.vc.vm.VmReconfigureFailedEvent",
+			Severity:    "error",
+			Arguments:   args,
+			Fault: &types.LocalizedMethodFault{
+				Fault:            fault,
+				LocalizedMessage: "A specified parameter was not correct: spec.numCPUs",
+			},
+		}
+	}
+
+	typed := failed(&types.InvalidArgument{InvalidProperty: "spec.numCPUs"},
+		types.KeyAnyValue{Key: "vm.name", Value: "vm-1"},
+		types.KeyAnyValue{Key: "vm", Value: types.ManagedObjectReference{Type: "VirtualMachine", Value: "vm-1"}},
+	)
+	typed.ObjectId = "vm-1"
+	typed.ObjectType = "VirtualMachine"
+	typed.ObjectName = "vm-1"
+
+	tests := []struct {
+		name    string
+		fixture string
+		want    *types.EventEx
+	}{{
+		name:    "typed fault and arguments",
+		fixture: "testdata/eventex-fault.json",
+		want:    typed,
+	}, {
+		// e.g. sent by adapters encoding with encoding/json
+		name:    "untyped fault and arguments",
+		fixture: "testdata/eventex-fault-untyped.json",
+		want: failed(&types.MethodFault{
+			FaultMessage: []types.LocalizableMessage{{Key: "msg.cpus", Message: "too many CPUs"}},
+		},
+			types.KeyAnyValue{Key: "vm.name", Value: "vm-1"},
+			types.KeyAnyValue{Key: "vm.cpus", Value: float64(2)},
+		),
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := os.ReadFile(tt.fixture)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ev := newTestEvent(t, &types.EventEx{}, tt.want.EventTypeId, ClassEventEx, cloudevents.ApplicationJSON)
+			if err := ev.SetData(cloudevents.ApplicationJSON, data); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := Decode(ev)
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Decode() (-want, +got) = %s", diff)
+			}
+		})
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		ev := cloudevents.NewEvent(cloudevents.VersionV1)
+		ev.SetType(Type(typed.EventTypeId))
+		ev.SetExtension(ExtensionEventClass, ClassEventEx)
+		if err := SetData(&ev, cloudevents.ApplicationJSON, typed); err != nil {
+			t.Fatalf("SetData() error = %v", err)
+		}
+
+		got, err := Decode(ev)
+		if err != nil {
+			t.Fatalf("Decode() error = %v", err)
+		}
+		if diff := cmp.Diff(typed, got); diff != "" {
+			t.Errorf("Decode() (-want, +got) = %s", diff)
+		}
+	})
+}
+
+func TestDecodeErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		event func() cloudevents.Event
+	}{{
+		name: "foreign event type",
+		event: func() cloudevents.Event {
+			ev := newTestEvent(t, &types.VmCreatedEvent{}, "VmCreatedEvent", ClassEvent, cloudevents.ApplicationJSON)
+			ev.SetType("com.example.someevent")
+			return ev
+		},
+	}, {
+		name: "missing event class",
+		event: func() cloudevents.Event {
+			ev := newTestEvent(t, &types.VmCreatedEvent{}, "VmCreatedEvent", ClassEvent, cloudevents.ApplicationJSON)
+			ev.SetExtension(ExtensionEventClass, nil)
+			return ev
+		},
+	}, {
+		name: "unknown vSphere type",
+		event: func() cloudevents.Event {
+			return newTestEvent(t, &types.VmCreatedEvent{}, "NoSuchEvent", ClassEvent, cloudevents.ApplicationJSON)
+		},
+	}, {
+		name: "vSphere type is not an event",
+		event: func() cloudevents.Event {
+			return newTestEvent(t, &types.VmCreatedEvent{}, "VirtualMachineConfigSpec", ClassEvent, cloudevents.ApplicationJSON)
+		},
+	}, {
+		name: "unsupported content type",
+		event: func() cloudevents.Event {
+			ev := newTestEvent(t, &types.VmCreatedEvent{}, "VmCreatedEvent", ClassEvent, cloudevents.ApplicationJSON)
+			ev.SetDataContentType(cloudevents.TextPlain)
+			return ev
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Decode(tt.event()); err == nil {
+				t.Error("Decode() expected error")
+			}
+		})
+	}
+}
+
+func TestHandler(t *testing.T) {
+	var got []string
+	errFailed := errors.New("failed")
+
+	h := NewHandler()
+	h.OnVmCreated(func(ctx context.Context, e *types.VmCreatedEvent) error {
+		got = append(got, "created "+e.Vm.Name)
+		return nil
+	})
+	On(h, func(ctx context.Context, e *types.VmPoweredOnEvent) error {
+		return errFailed
+	})
+	h.OnEventEx("com.vmware.cl.CreateLibraryEvent", func(ctx context.Context, e *types.EventEx) error {
+		got = append(got, "eventex "+e.EventTypeId)
+		return nil
+	})
+
+	ctx := context.Background()
+	created := &types.VmCreatedEvent{VmEvent: types.VmEvent{Event: types.Event{
+		Vm: &types.VmEventArgument{EntityEventArgument: types.EntityEventArgument{Name: "vm-1"}},
+	}}}
+	if err := h.Handle(ctx, newTestEvent(t, created, "VmCreatedEvent", ClassEvent, cloudevents.ApplicationXML)); err != nil {
+		t.Errorf("Handle(VmCreatedEvent) error = %v", err)
+	}
+
+	poweredOn := newTestEvent(t, &types.VmPoweredOnEvent{}, "VmPoweredOnEvent", ClassEvent, cloudevents.ApplicationJSON)
+	if err := h.Handle(ctx, poweredOn); !errors.Is(err, errFailed) {
+		t.Errorf("Handle(VmPoweredOnEvent) error = %v, want %v", err, errFailed)
+	}
+
+	eventEx := &types.EventEx{EventTypeId: "com.vmware.cl.CreateLibraryEvent"}
+	if err := h.Handle(ctx, newTestEvent(t, eventEx, eventEx.EventTypeId, ClassEventEx, cloudevents.ApplicationJSON)); err != nil {
+		t.Errorf("Handle(EventEx) error = %v", err)
+	}
+
+	// no callback and no default callback
+	removed := newTestEvent(t, &types.VmRemovedEvent{}, "VmRemovedEvent", ClassEvent, cloudevents.ApplicationJSON)
+	if err := h.Handle(ctx, removed); err != nil {
+		t.Errorf("Handle(VmRemovedEvent) error = %v", err)
+	}
+
+	h.OnDefault(func(ctx context.Context, be types.BaseEvent) error {
+		got = append(got, "default")
+		return nil
+	})
+	if err := h.Handle(ctx, removed); err != nil {
+		t.Errorf("Handle(VmRemovedEvent) error = %v", err)
+	}
+
+	want := []string{"created vm-1", "eventex com.vmware.cl.CreateLibraryEvent", "default"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Handle() callbacks (-want, +got) = %s", diff)
+	}
+}
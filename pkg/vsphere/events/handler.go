@@ -0,0 +1,160 @@
+/*
+Copyright 2020 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package events
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// HandlerFunc handles a decoded vSphere event.
+type HandlerFunc func(ctx context.Context, event types.BaseEvent) error
+
+// Handler dispatches CloudEvents produced by a VSphereSource to the callbacks
+// registered for their vSphere event type. Events without a registered
+// callback are passed to the default callback, if any, or ignored.
+// Registration is not safe for concurrent use with Handle.
+type Handler struct {
+	handlers map[handlerKey]HandlerFunc
+	fallback HandlerFunc
+}
+
+type handlerKey struct {
+	class     string
+	eventType string
+}
+
+// NewHandler returns an empty Handler.
+func NewHandler() *Handler {
+	return &Handler{handlers: make(map[handlerKey]HandlerFunc)}
+}
+
+// Handle decodes the given CloudEvent and invokes the matching callback. It
+// has the signature expected by cloudevents.Client.StartReceiver.
+func (h *Handler) Handle(ctx context.Context, event cloudevents.Event) error {
+	eventType, class, err := Details(event)
+	if err != nil {
+		return err
+	}
+
+	fn, ok := h.handlers[handlerKey{class: class, eventType: eventType}]
+	if !ok {
+		fn = h.fallback
+	}
+	if fn == nil {
+		return nil
+	}
+
+	be, err := Decode(event)
+	if err != nil {
+		return err
+	}
+	return fn(ctx, be)
+}
+
+// OnDefault registers the callback for events without a more specific callback.
+func (h *Handler) OnDefault(fn HandlerFunc) {
+	h.fallback = fn
+}
+
+// OnEvent registers the callback for the given vSphere event type, e.g.
+// VmPoweredOnEvent.
+func (h *Handler) OnEvent(eventType string, fn HandlerFunc) {
+	h.handlers[handlerKey{class: ClassEvent, eventType: eventType}] = fn
+}
+
+// OnEventEx registers the callback for EventEx events with the given event
+// type ID.
+func (h *Handler) OnEventEx(typeID string, fn func(context.Context, *types.EventEx) error) {
+	h.handlers[handlerKey{class: ClassEventEx, eventType: typeID}] = func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.EventEx))
+	}
+}
+
+// OnExtendedEvent registers the callback for ExtendedEvent events with the
+// given event type ID.
+func (h *Handler) OnExtendedEvent(typeID string, fn func(context.Context, *types.ExtendedEvent) error) {
+	h.handlers[handlerKey{class: ClassExtendedEvent, eventType: typeID}] = func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.ExtendedEvent))
+	}
+}
+
+// On registers a typed callback for the vSphere event type E, e.g.
+//
+//	events.On(h, func(ctx context.Context, e *types.VmPoweredOnEvent) error { ... })
+func On[E types.BaseEvent](h *Handler, fn func(context.Context, E) error) {
+	eventType := reflect.TypeOf((*E)(nil)).Elem()
+	if eventType.Kind() == reflect.Ptr {
+		eventType = eventType.Elem()
+	}
+
+	switch eventType.Name() {
+	case "EventEx", "ExtendedEvent":
+		panic(fmt.Sprintf("use OnEventEx or OnExtendedEvent to register %s callbacks", eventType.Name()))
+	}
+
+	h.OnEvent(eventType.Name(), func(ctx context.Context, be types.BaseEvent) error {
+		e, ok := be.(E)
+		if !ok {
+			return fmt.Errorf("unexpected event %T for callback of %s", be, eventType.Name())
+		}
+		return fn(ctx, e)
+	})
+}
+
+// OnVmCreated registers the callback for VmCreatedEvent events.
+func (h *Handler) OnVmCreated(fn func(context.Context, *types.VmCreatedEvent) error) {
+	On(h, fn)
+}
+
+// OnVmRemoved registers the callback for VmRemovedEvent events.
+func (h *Handler) OnVmRemoved(fn func(context.Context, *types.VmRemovedEvent) error) {
+	On(h, fn)
+}
+
+// OnVmPoweredOn registers the callback for VmPoweredOnEvent events.
+func (h *Handler) OnVmPoweredOn(fn func(context.Context, *types.VmPoweredOnEvent) error) {
+	On(h, fn)
+}
+
+// OnVmPoweredOff registers the callback for VmPoweredOffEvent events.
+func (h *Handler) OnVmPoweredOff(fn func(context.Context, *types.VmPoweredOffEvent) error) {
+	On(h, fn)
+}
+
+// OnVmReconfigured registers the callback for VmReconfiguredEvent events.
+func (h *Handler) OnVmReconfigured(fn func(context.Context, *types.VmReconfiguredEvent) error) {
+	On(h, fn)
+}
+
+// OnVmRenamed registers the callback for VmRenamedEvent events.
+func (h *Handler) OnVmRenamed(fn func(context.Context, *types.VmRenamedEvent) error) {
+	On(h, fn)
+}
+
+// OnVmMigrated registers the callback for VmMigratedEvent events.
+func (h *Handler) OnVmMigrated(fn func(context.Context, *types.VmMigratedEvent) error) {
+	On(h, fn)
+}
+
+// OnHostConnected registers the callback for HostConnectedEvent events.
+func (h *Handler) OnHostConnected(fn func(context.Context, *types.HostConnectedEvent) error) {
+	On(h, fn)
+}
+
+// OnHostDisconnected registers the callback for HostDisconnectedEvent events.
+func (h *Handler) OnHostDisconnected(fn func(context.Context, *types.HostDisconnectedEvent) error) {
+	On(h, fn)
+}
+
+// OnAlarmStatusChanged registers the callback for AlarmStatusChangedEvent events.
+func (h *Handler) OnAlarmStatusChanged(fn func(context.Context, *types.AlarmStatusChangedEvent) error) {
+	On(h, fn)
+}
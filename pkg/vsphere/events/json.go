@@ -0,0 +1,280 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// JSONTypeKey is the key holding the vSphere type name of objects stored in
+// interface fields of JSON encoded vSphere events, e.g. the concrete fault of
+// a types.LocalizedMethodFault. It is the discriminator used for the xsi:type
+// attribute in the XML encoding.
+const JSONTypeKey = "_typeName"
+
+var (
+	jsonMarshaler   = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	jsonUnmarshaler = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+)
+
+// MarshalJSON returns the JSON encoding of the given vSphere value. It matches
+// encoding/json except that objects stored in interface fields carry their
+// vSphere type name in JSONTypeKey, so that UnmarshalJSON can restore them.
+func MarshalJSON(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := encodeJSON(&buf, reflect.ValueOf(v), ""); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON decodes the JSON encoding of a vSphere value into v, which
+// must be a pointer. Objects in interface fields are decoded into the type
+// named by JSONTypeKey, or into the base type of the interface, e.g.
+// types.MethodFault for types.BaseMethodFault, when it is missing.
+func UnmarshalJSON(data []byte, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("decode JSON into non-pointer %T", v)
+	}
+	return decodeJSON(data, rv.Elem())
+}
+
+// jsonField is a field of a struct as encoded by encoding/json, with the
+// fields of embedded structs promoted.
+type jsonField struct {
+	name  string
+	index []int
+}
+
+// jsonFields returns the encoded fields of the given struct type. Like
+// encoding/json, shallower fields hide deeper fields of the same name.
+func jsonFields(t reflect.Type) []jsonField {
+	var fields []jsonField
+	seen := make(map[string]bool)
+
+	current := []jsonField{{index: nil}}
+	for len(current) > 0 {
+		var next []jsonField
+		var level []jsonField
+		for _, parent := range current {
+			pt := t
+			if len(parent.index) > 0 {
+				pt = t.FieldByIndex(parent.index).Type
+			}
+			for i := 0; i < pt.NumField(); i++ {
+				sf := pt.Field(i)
+				index := append(append([]int(nil), parent.index...), i)
+				if sf.Anonymous && sf.Type.Kind() == reflect.Struct {
+					next = append(next, jsonField{index: index})
+					continue
+				}
+				if sf.PkgPath != "" {
+					continue // unexported
+				}
+				level = append(level, jsonField{name: sf.Name, index: index})
+			}
+		}
+		for _, f := range level {
+			if !seen[f.name] {
+				seen[f.name] = true
+				fields = append(fields, f)
+			}
+		}
+		current = next
+	}
+	return fields
+}
+
+func encodeJSON(buf *bytes.Buffer, v reflect.Value, typeName string) error {
+	if !v.IsValid() {
+		buf.WriteString("null")
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			buf.WriteString("null")
+			return nil
+		}
+		elem := v.Elem()
+		t := elem.Type()
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		return encodeJSON(buf, elem, t.Name())
+
+	case reflect.Ptr:
+		if v.IsNil() {
+			buf.WriteString("null")
+			return nil
+		}
+		return encodeJSON(buf, v.Elem(), typeName)
+
+	case reflect.Struct:
+		if v.Type().Implements(jsonMarshaler) || reflect.PtrTo(v.Type()).Implements(jsonMarshaler) {
+			return marshalJSON(buf, v)
+		}
+
+		buf.WriteByte('{')
+		sep := false
+		if typeName != "" {
+			fmt.Fprintf(buf, "%q:%q", JSONTypeKey, typeName)
+			sep = true
+		}
+		for _, f := range jsonFields(v.Type()) {
+			if sep {
+				buf.WriteByte(',')
+			}
+			sep = true
+			fmt.Fprintf(buf, "%q:", f.name)
+			if err := encodeJSON(buf, v.FieldByIndex(f.index), ""); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+		return nil
+
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && (v.IsNil() || v.Type().Elem().Kind() == reflect.Uint8) {
+			return marshalJSON(buf, v)
+		}
+
+		buf.WriteByte('[')
+		for i := 0; i < v.Len(); i++ {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := encodeJSON(buf, v.Index(i), ""); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+		return nil
+
+	default:
+		return marshalJSON(buf, v)
+	}
+}
+
+func marshalJSON(buf *bytes.Buffer, v reflect.Value) error {
+	b, err := json.Marshal(v.Interface())
+	if err != nil {
+		return err
+	}
+	buf.Write(b)
+	return nil
+}
+
+func decodeJSON(data []byte, v reflect.Value) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Interface:
+		return decodeJSONInterface(data, v)
+
+	case reflect.Ptr:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return decodeJSON(data, v.Elem())
+
+	case reflect.Struct:
+		if reflect.PtrTo(v.Type()).Implements(jsonUnmarshaler) {
+			return json.Unmarshal(data, v.Addr().Interface())
+		}
+
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(data, &obj); err != nil {
+			return fmt.Errorf("decode %s: %w", v.Type(), err)
+		}
+		for _, f := range jsonFields(v.Type()) {
+			raw, ok := obj[f.name]
+			if !ok {
+				continue
+			}
+			if err := decodeJSON(raw, v.FieldByIndex(f.index)); err != nil {
+				return fmt.Errorf("decode %s.%s: %w", v.Type().Name(), f.name, err)
+			}
+		}
+		return nil
+
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return json.Unmarshal(data, v.Addr().Interface())
+		}
+
+		var items []json.RawMessage
+		if err := json.Unmarshal(data, &items); err != nil {
+			return fmt.Errorf("decode %s: %w", v.Type(), err)
+		}
+		s := reflect.MakeSlice(v.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := decodeJSON(item, s.Index(i)); err != nil {
+				return err
+			}
+		}
+		v.Set(s)
+		return nil
+
+	default:
+		return json.Unmarshal(data, v.Addr().Interface())
+	}
+}
+
+// decodeJSONInterface decodes into an interface field, e.g. the
+// types.BaseMethodFault of a types.LocalizedMethodFault or the types.AnyType
+// value of a types.KeyAnyValue.
+func decodeJSONInterface(data []byte, v reflect.Value) error {
+	var obj struct {
+		TypeName string `json:"_typeName"`
+	}
+	isObject := bytes.HasPrefix(bytes.TrimSpace(data), []byte("{"))
+	if isObject {
+		if err := json.Unmarshal(data, &obj); err != nil {
+			return err
+		}
+	}
+
+	typeName := obj.TypeName
+	if typeName == "" {
+		if v.NumMethod() == 0 {
+			// plain JSON value in a types.AnyType
+			return json.Unmarshal(data, v.Addr().Interface())
+		}
+		// e.g. types.MethodFault for types.BaseMethodFault
+		typeName = strings.TrimPrefix(v.Type().Name(), "Base")
+	}
+
+	typ, ok := types.TypeFunc()(typeName)
+	if !ok {
+		return fmt.Errorf("unknown vSphere type %q", typeName)
+	}
+	ptr := reflect.New(typ)
+	if err := decodeJSON(data, ptr.Elem()); err != nil {
+		return err
+	}
+
+	switch {
+	case typ.AssignableTo(v.Type()):
+		v.Set(ptr.Elem())
+	case ptr.Type().AssignableTo(v.Type()):
+		v.Set(ptr)
+	default:
+		return fmt.Errorf("vSphere type %q is not a %s", typeName, v.Type())
+	}
+	return nil
+}
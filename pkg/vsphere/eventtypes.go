@@ -0,0 +1,125 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/session"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/soap"
+	"github.com/vmware/govmomi/vim25/types"
+	"go.uber.org/zap"
+	"knative.dev/pkg/logging"
+
+	"github.com/vmware-tanzu/sources-for-knative/pkg/cemapping"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/vsphere/events"
+)
+
+// EventTypeInfo is an event type of the vCenter event catalog
+type EventTypeInfo struct {
+	// Class is the event class, i.e. event, eventex or extendedevent
+	Class string
+	// Type is the event type, e.g. VmPoweredOnEvent or the type ID of an
+	// EventEx, e.g. com.vmware.vc.HA.ClusterFailoverActionCompletedEvent
+	Type string
+	// Category is the vCenter event category, i.e. info, warning, error or
+	// user
+	Category string
+	// Description is a short description of the event type
+	Description string
+}
+
+// EventData returns the data of the type and subject templates of a
+// cemapping.Mapper for the event type. The entity of the event is unknown.
+func (i EventTypeInfo) EventData() cemapping.EventData {
+	return cemapping.EventData{Type: i.Type, Class: i.Class}
+}
+
+// ReadEventCatalog logs in to the vCenter at address and returns its about
+// info and the event types of its EventManager description.
+func ReadEventCatalog(ctx context.Context, address string, insecure bool, user *url.Userinfo) (types.AboutInfo, []EventTypeInfo, error) {
+	u, err := soap.ParseURL(address)
+	if err != nil {
+		return types.AboutInfo{}, nil, err
+	}
+
+	c, err := vim25.NewClient(ctx, soap.NewClient(u, insecure))
+	if err != nil {
+		return types.AboutInfo{}, nil, fmt.Errorf("create vCenter client: %w", err)
+	}
+
+	m := session.NewManager(c)
+	if err := m.Login(ctx, user); err != nil {
+		return types.AboutInfo{}, nil, fmt.Errorf("login: %w", err)
+	}
+	defer func() {
+		if err := m.Logout(ctx); err != nil {
+			logging.FromContext(ctx).Warnw("could not logout from vCenter", zap.Error(err))
+		}
+	}()
+
+	catalog, err := eventCatalog(ctx, c)
+	if err != nil {
+		return types.AboutInfo{}, nil, err
+	}
+	return c.ServiceContent.About, catalog, nil
+}
+
+// eventCatalog returns the event types of the EventManager description
+func eventCatalog(ctx context.Context, c *vim25.Client) ([]EventTypeInfo, error) {
+	var em mo.EventManager
+	err := property.DefaultCollector(c).RetrieveOne(ctx, *c.ServiceContent.EventManager, []string{"description.eventInfo"}, &em)
+	if err != nil {
+		return nil, fmt.Errorf("retrieve event catalog: %w", err)
+	}
+
+	seen := make(map[string]bool, len(em.Description.EventInfo))
+	catalog := make([]EventTypeInfo, 0, len(em.Description.EventInfo))
+	for _, detail := range em.Description.EventInfo {
+		info := eventTypeInfo(detail)
+		if info.Type == "" || seen[info.Type] {
+			continue
+		}
+		seen[info.Type] = true
+		catalog = append(catalog, info)
+	}
+	return catalog, nil
+}
+
+// eventTypeInfo returns the event type of an EventManager description entry.
+// The entries of EventEx and ExtendedEvent types use the class as key and
+// prefix the full format with the type ID, e.g.
+// com.vmware.vc.HA.ClusterFailoverActionCompletedEvent|HA initiated all...
+func eventTypeInfo(detail types.EventDescriptionEventDetail) EventTypeInfo {
+	info := EventTypeInfo{
+		Class:       events.ClassEvent,
+		Type:        detail.Key,
+		Category:    detail.Category,
+		Description: detail.Description,
+	}
+
+	switch detail.Key {
+	case "EventEx":
+		info.Class = events.ClassEventEx
+	case "ExtendedEvent":
+		info.Class = events.ClassExtendedEvent
+	default:
+		return info
+	}
+
+	typeID, format, _ := strings.Cut(detail.FullFormat, "|")
+	info.Type = strings.TrimSpace(typeID)
+	if info.Description == "" || info.Description == detail.Key {
+		info.Description = strings.TrimSpace(format)
+	}
+	return info
+}
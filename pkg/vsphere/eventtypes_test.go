@@ -0,0 +1,97 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vsphere
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func Test_eventTypeInfo(t *testing.T) {
+	tests := []struct {
+		name   string
+		detail types.EventDescriptionEventDetail
+		want   EventTypeInfo
+	}{{
+		name: "event",
+		detail: types.EventDescriptionEventDetail{
+			Key:         "VmPoweredOnEvent",
+			Description: "VM powered on",
+			Category:    "info",
+			FullFormat:  "{vm.name} on {host.name} in {datacenter.name} is powered on",
+		},
+		want: EventTypeInfo{
+			Class:       "event",
+			Type:        "VmPoweredOnEvent",
+			Category:    "info",
+			Description: "VM powered on",
+		},
+	}, {
+		name: "EventEx",
+		detail: types.EventDescriptionEventDetail{
+			Key:        "EventEx",
+			Category:   "error",
+			FullFormat: "com.vmware.vc.HA.HostFailedEvent|vSphere HA detected a possible host failure of host {host.name}",
+		},
+		want: EventTypeInfo{
+			Class:       "eventex",
+			Type:        "com.vmware.vc.HA.HostFailedEvent",
+			Category:    "error",
+			Description: "vSphere HA detected a possible host failure of host {host.name}",
+		},
+	}, {
+		name: "ExtendedEvent",
+		detail: types.EventDescriptionEventDetail{
+			Key:         "ExtendedEvent",
+			Description: "Backup job failed",
+			Category:    "warning",
+			FullFormat:  "com.vmware.applmgmt.backup.job.failed.event|Backup job failed",
+		},
+		want: EventTypeInfo{
+			Class:       "extendedevent",
+			Type:        "com.vmware.applmgmt.backup.job.failed.event",
+			Category:    "warning",
+			Description: "Backup job failed",
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, eventTypeInfo(tt.detail)); diff != "" {
+				t.Errorf("eventTypeInfo() (-want, +got) = %s", diff)
+			}
+		})
+	}
+}
+
+func TestReadEventCatalog(t *testing.T) {
+	simulator.Run(func(ctx context.Context, vim *vim25.Client) error {
+		about, catalog, err := ReadEventCatalog(ctx, vim.URL().String(), true, simulator.DefaultLogin)
+		if err != nil {
+			t.Fatalf("ReadEventCatalog() error = %v", err)
+		}
+
+		if about.InstanceUuid != vim.ServiceContent.About.InstanceUuid {
+			t.Errorf("ReadEventCatalog() instanceUuid = %q, want %q", about.InstanceUuid, vim.ServiceContent.About.InstanceUuid)
+		}
+
+		found := false
+		for _, info := range catalog {
+			if info.Type == "VmPoweredOnEvent" {
+				found = info.Class == "event" && info.Category == "info"
+			}
+		}
+		if !found {
+			t.Errorf("ReadEventCatalog() did not return VmPoweredOnEvent info event type: %+v", catalog)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vsphere
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/vmware/govmomi/vim25"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/logging"
+)
+
+const (
+	// CheckpointMismatchReplayMaxAge replays the events of the replay window
+	// when the checkpoint does not match the vCenter.
+	CheckpointMismatchReplayMaxAge = "ReplayMaxAge"
+	// CheckpointMismatchResetToNow starts at the current vCenter time when the
+	// checkpoint does not match the vCenter.
+	CheckpointMismatchResetToNow = "ResetToNow"
+	// CheckpointMismatchFail stops the adapter when the checkpoint does not
+	// match the vCenter.
+	CheckpointMismatchFail = "Fail"
+)
+
+// checkpointMismatch returns why the checkpoint cannot be used to resume the
+// event stream of the connected vCenter, or an empty string if it can. The
+// vCenter instance UUID recorded in the checkpoint must match and the event
+// keys must not have been reset, e.g. by restoring a vCenter backup.
+// Checkpoints created before the identity was recorded are only checked for an
+// event key reset. An upgrade of the vCenter changes its API version, but keeps
+// its event stream, see updateCheckpointAPIVersion.
+func (a *vAdapter) checkpointMismatch(ctx context.Context, cp checkpoint) (string, error) {
+	if cp.LastEventKeyTimestamp.IsZero() {
+		// no checkpoint
+		return "", nil
+	}
+
+	about := a.VClient.ServiceContent.About
+	if cp.InstanceUUID != "" && cp.InstanceUUID != about.InstanceUuid {
+		return fmt.Sprintf("vCenter instance UUID changed from %q to %q", cp.InstanceUUID, about.InstanceUuid), nil
+	}
+
+	latest, err := latestEventKey(ctx, a.VClient.Client)
+	if err != nil {
+		return "", fmt.Errorf("get latest event key: %w", err)
+	}
+	if latest < cp.LastEventKey {
+		return fmt.Sprintf("vCenter event keys were reset: latest event key %d is lower than checkpoint event key %d",
+			latest, cp.LastEventKey), nil
+	}
+	return "", nil
+}
+
+// updateCheckpointAPIVersion records the API version of the connected vCenter
+// in the checkpoint if it changed, e.g. by an upgrade of the vCenter.
+func (a *vAdapter) updateCheckpointAPIVersion(ctx context.Context, cp *checkpoint) {
+	version := a.VClient.ServiceContent.About.ApiVersion
+	if cp.LastEventKeyTimestamp.IsZero() || cp.APIVersion == "" || cp.APIVersion == version {
+		return
+	}
+
+	logging.FromContext(ctx).Infow("vCenter API version changed, resuming from checkpoint",
+		zap.String("checkpointAPIVersion", cp.APIVersion), zap.String("apiVersion", version))
+	cp.APIVersion = version
+	if err := a.KVStore.Set(ctx, checkpointKey, *cp); err != nil {
+		logging.FromContext(ctx).Warnw("could not update API version in checkpoint", zap.Error(err))
+	}
+}
+
+// latestEventKey returns the key of the latest vCenter event, 0 if there are
+// no events.
+func latestEventKey(ctx context.Context, client *vim25.Client) (int32, error) {
+	coll, err := newHistoryCollector(ctx, client, time.Time{})
+	if err != nil {
+		return 0, fmt.Errorf("create event collector: %w", err)
+	}
+	defer func() {
+		_ = coll.Destroy(ctx) // best effort, the collector ends with the session
+	}()
+
+	events, err := coll.LatestPage(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("read latest events: %w", err)
+	}
+
+	var latest int32
+	for _, be := range events {
+		if key := be.GetEvent().Key; key > latest {
+			latest = key
+		}
+	}
+	return latest, nil
+}
+
+// reportCheckpointMismatch reports the checkpoint mismatch in
+// status.checkpointMismatch of the source. An empty mismatch clears a
+// previously reported one.
+func (a *vAdapter) reportCheckpointMismatch(ctx context.Context, mismatch string) {
+	if a.StatusClient == nil {
+		return
+	}
+
+	var value interface{}
+	if mismatch != "" {
+		value = mismatch
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"status": map[string]interface{}{
+			// null removes a previously reported mismatch
+			"checkpointMismatch": value,
+		},
+	})
+	if err != nil {
+		logging.FromContext(ctx).Warnw("could not marshal checkpoint mismatch", zap.Error(err))
+		return
+	}
+
+	_, err = a.StatusClient.Patch(ctx, a.SourceName, types.MergePatchType, patch, metav1.PatchOptions{}, "status")
+	if err != nil {
+		logging.FromContext(ctx).Warnw("could not report checkpoint mismatch", zap.Error(err))
+	}
+}
@@ -0,0 +1,259 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vsphere
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudevents/sdk-go/v2/client"
+	cecontext "github.com/cloudevents/sdk-go/v2/context"
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/session"
+	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vim25"
+	"go.uber.org/zap/zaptest"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+// newStatusTestClient returns a client for a source named "source"
+func newStatusTestClient() dynamic.ResourceInterface {
+	src := &unstructured.Unstructured{}
+	src.SetAPIVersion("sources.tanzu.vmware.com/v1alpha1")
+	src.SetKind("VSphereSource")
+	src.SetNamespace("ns")
+	src.SetName("source")
+
+	dc := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{vsphereSourcesResource: "VSphereSourceList"}, src)
+	return dc.Resource(vsphereSourcesResource).Namespace("ns")
+}
+
+func Test_vAdapter_checkpointMismatch(t *testing.T) {
+	ts := time.Now().UTC().Add(-time.Hour)
+
+	tests := []struct {
+		name         string
+		cp           func(about checkpoint) checkpoint
+		wantMismatch string
+	}{{
+		name: "no checkpoint",
+		cp: func(checkpoint) checkpoint {
+			return checkpoint{LastEventKey: 1000}
+		},
+	}, {
+		name: "matching checkpoint",
+		cp: func(about checkpoint) checkpoint {
+			about.LastEventKey = 26
+			return about
+		},
+	}, {
+		name: "checkpoint without vCenter identity",
+		cp: func(checkpoint) checkpoint {
+			return checkpoint{LastEventKey: 20, LastEventKeyTimestamp: ts}
+		},
+	}, {
+		name: "instance UUID changed",
+		cp: func(about checkpoint) checkpoint {
+			about.InstanceUUID = "a7e1d3f0-5a5b-4c2e-9a8e-3c1e7a6c0f10"
+			return about
+		},
+		wantMismatch: "instance UUID changed",
+	}, {
+		// an upgraded vCenter keeps its event stream
+		name: "API version changed",
+		cp: func(about checkpoint) checkpoint {
+			about.APIVersion = "6.0"
+			about.LastEventKey = 26
+			return about
+		},
+	}, {
+		name: "event keys reset",
+		cp: func(about checkpoint) checkpoint {
+			about.LastEventKey = 1000
+			return about
+		},
+		wantMismatch: "event keys were reset",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			simulator.Run(func(ctx context.Context, vim *vim25.Client) error {
+				a := &vAdapter{
+					Logger:  zaptest.NewLogger(t).Sugar(),
+					Source:  source,
+					VClient: &govmomi.Client{Client: vim, SessionManager: session.NewManager(vim)},
+				}
+
+				cp := tt.cp(checkpoint{
+					InstanceUUID:          vim.ServiceContent.About.InstanceUuid,
+					APIVersion:            vim.ServiceContent.About.ApiVersion,
+					LastEventKeyTimestamp: ts,
+				})
+				mismatch, err := a.checkpointMismatch(ctx, cp)
+				if err != nil {
+					t.Fatalf("checkpointMismatch() error = %v", err)
+				}
+				if tt.wantMismatch == "" && mismatch != "" {
+					t.Errorf("checkpointMismatch() = %q, want none", mismatch)
+				}
+				if !strings.Contains(mismatch, tt.wantMismatch) {
+					t.Errorf("checkpointMismatch() = %q, want %q", mismatch, tt.wantMismatch)
+				}
+				return nil
+			})
+		})
+	}
+}
+
+func Test_vAdapter_updateCheckpointAPIVersion(t *testing.T) {
+	simulator.Run(func(ctx context.Context, vim *vim25.Client) error {
+		kv := &fakeKVStore{data: map[string]string{}}
+		a := &vAdapter{
+			Logger:  zaptest.NewLogger(t).Sugar(),
+			VClient: &govmomi.Client{Client: vim, SessionManager: session.NewManager(vim)},
+			KVStore: kv,
+		}
+
+		cp := checkpoint{
+			InstanceUUID:          vim.ServiceContent.About.InstanceUuid,
+			APIVersion:            "6.0",
+			LastEventKey:          26,
+			LastEventKeyTimestamp: time.Now().UTC(),
+		}
+		a.updateCheckpointAPIVersion(ctx, &cp)
+
+		var saved checkpoint
+		if err := kv.Get(ctx, checkpointKey, &saved); err != nil {
+			t.Fatalf("checkpoint not updated: %v", err)
+		}
+		if want := vim.ServiceContent.About.ApiVersion; cp.APIVersion != want || saved.APIVersion != want {
+			t.Errorf("checkpoint API version = %q, saved %q, want %q", cp.APIVersion, saved.APIVersion, want)
+		}
+		if saved.LastEventKey != 26 {
+			t.Errorf("saved checkpoint event key = %d, want 26", saved.LastEventKey)
+		}
+		return nil
+	})
+}
+
+func Test_vAdapter_runCheckpointMismatch(t *testing.T) {
+	const vcsimEvents = 26
+
+	tests := []struct {
+		policy       string
+		wantSent     int
+		wantCpKey    int32
+		wantStartErr bool
+	}{{
+		policy:    CheckpointMismatchReplayMaxAge,
+		wantSent:  vcsimEvents,
+		wantCpKey: vcsimEvents,
+	}, {
+		// the vcsim events are created before the adapter starts
+		policy:   CheckpointMismatchResetToNow,
+		wantSent: 0,
+	}, {
+		policy:       CheckpointMismatchFail,
+		wantSent:     0,
+		wantStartErr: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			simulator.Run(func(ctx context.Context, vim *vim25.Client) error {
+				ctx = cecontext.WithTarget(ctx, "fake.example.com")
+
+				// checkpoint of a vCenter restored from a backup
+				b, err := json.Marshal(checkpoint{
+					InstanceUUID:          vim.ServiceContent.About.InstanceUuid,
+					APIVersion:            vim.ServiceContent.About.ApiVersion,
+					LastEventKey:          1000,
+					LastEventKeyTimestamp: time.Now().UTC().Add(-time.Minute),
+					RecentEventKeys:       []int32{1, 2, 3},
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+				kv := &fakeKVStore{
+					data:     map[string]string{checkpointKey: string(b)},
+					dataChan: make(chan string, 1),
+				}
+
+				roundTripper := &roundTripperTest{statusCodes: createStatusCodes(vcsimEvents, failNever)}
+				p, err := cehttp.New(cehttp.WithRoundTripper(roundTripper))
+				if err != nil {
+					t.Fatal(err)
+				}
+				c, err := client.New(p, client.WithTimeNow(), client.WithUUIDs())
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				statusClient := newStatusTestClient()
+				a := &vAdapter{
+					Logger:         zaptest.NewLogger(t).Sugar(),
+					Source:         source,
+					VClient:        &govmomi.Client{Client: vim, SessionManager: session.NewManager(vim)},
+					CEClient:       c,
+					KVStore:        kv,
+					CpConfig:       CheckpointConfig{MaxAge: time.Hour, Period: time.Millisecond},
+					MismatchPolicy: tt.policy,
+					StatusClient:   statusClient,
+					SourceName:     "source",
+				}
+
+				ctx, cancel := context.WithCancel(ctx)
+				defer cancel()
+
+				var cp checkpoint
+				go func() {
+					select {
+					case data := <-kv.dataChan:
+						if err := json.Unmarshal([]byte(data), &cp); err != nil {
+							t.Errorf("unmarshal data from KV store: %v", err)
+						}
+					case <-time.After(500 * time.Millisecond):
+					}
+					cancel()
+				}()
+
+				err = a.run(ctx)
+				if gotStartErr := err != nil && strings.Contains(err.Error(), "checkpoint does not match"); gotStartErr != tt.wantStartErr {
+					t.Errorf("run() error = %v, want mismatch error %v", err, tt.wantStartErr)
+				}
+
+				if got := len(roundTripper.events); got != tt.wantSent {
+					t.Errorf("sent events = %d, want %d", got, tt.wantSent)
+				}
+				if cp.LastEventKey != tt.wantCpKey {
+					t.Errorf("run() checkpointKey = %v, wantEventKey %v", cp.LastEventKey, tt.wantCpKey)
+				}
+				if tt.wantCpKey != 0 && cp.InstanceUUID != vim.ServiceContent.About.InstanceUuid {
+					t.Errorf("checkpoint instanceUuid = %q, want %q", cp.InstanceUUID, vim.ServiceContent.About.InstanceUuid)
+				}
+
+				src, err := statusClient.Get(context.TODO(), "source", metav1.GetOptions{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if mismatch, _, _ := unstructured.NestedString(src.Object, "status", "checkpointMismatch"); mismatch == "" {
+					t.Error("status.checkpointMismatch not reported")
+				}
+				return nil
+			})
+		})
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vsphere
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/vmware/govmomi/vim25/types"
+
+	"github.com/vmware-tanzu/sources-for-knative/pkg/cemapping"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/vsphere/events"
+)
+
+// DefaultCloudEventMapping maps vSphere events to the CloudEvent type
+// expected by the events package, the vCenter address as source and the
+// primary managed entity as subject.
+var DefaultCloudEventMapping = cemapping.Config{
+	Type:    events.TypePrefix + "{{ .Type }}" + events.TypeSuffix,
+	Source:  "{{ .Address }}",
+	Subject: "{{ .Entity }}",
+}
+
+// defaultMapper is used by adapters without a configured mapper
+var defaultMapper = cemapping.MustNew(cemapping.Config{}, DefaultCloudEventMapping)
+
+// newMapper returns the mapper for the JSON-encoded cemapping.Config and the
+// CloudEvent source of the vCenter at address
+func newMapper(config, address string, about types.AboutInfo) (*cemapping.Mapper, string, error) {
+	var c cemapping.Config
+	if config != "" {
+		if err := json.Unmarshal([]byte(config), &c); err != nil {
+			return nil, "", fmt.Errorf("unmarshal CloudEvent mapping: %w", err)
+		}
+	}
+
+	m, err := cemapping.New(c, DefaultCloudEventMapping)
+	if err != nil {
+		return nil, "", err
+	}
+
+	source, err := m.Source(cemapping.SourceData{
+		Address:      address,
+		InstanceUUID: about.InstanceUuid,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return m, source, nil
+}
+
+// mapEvent returns the CloudEvent type and subject of an event, the subject
+// may be empty
+func mapEvent(m *cemapping.Mapper, d cemapping.EventData) (string, string, error) {
+	ceType, err := m.Type(d)
+	if err != nil {
+		return "", "", err
+	}
+	subject, err := m.Subject(d)
+	if err != nil {
+		return "", "", err
+	}
+	return ceType, subject, nil
+}
+
+// getEventEntity returns the managed object ID and type of the primary entity
+// of the event: the object of an EventEx or ExtendedEvent, otherwise the most
+// specific entity argument, e.g. the virtual machine before its host. Both are
+// empty if the event has no entity.
+func getEventEntity(be types.BaseEvent) (string, string) {
+	switch e := be.(type) {
+	case *types.EventEx:
+		if e.ObjectId != "" {
+			return e.ObjectId, e.ObjectType
+		}
+	case *types.ExtendedEvent:
+		if e.ManagedObject.Value != "" {
+			return e.ManagedObject.Value, e.ManagedObject.Type
+		}
+	}
+
+	var ref *types.ManagedObjectReference
+	switch e := be.GetEvent(); {
+	case e.Vm != nil:
+		ref = &e.Vm.Vm
+	case e.Host != nil:
+		ref = &e.Host.Host
+	case e.Ds != nil:
+		ref = &e.Ds.Datastore
+	case e.Net != nil:
+		ref = &e.Net.Network
+	case e.Dvs != nil:
+		ref = &e.Dvs.Dvs
+	case e.ComputeResource != nil:
+		ref = &e.ComputeResource.ComputeResource
+	case e.Datacenter != nil:
+		ref = &e.Datacenter.Datacenter
+	default:
+		return "", ""
+	}
+	return ref.Value, ref.Type
+}
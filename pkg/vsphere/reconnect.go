@@ -0,0 +1,223 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vsphere
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/jpillora/backoff"
+	"github.com/vmware/govmomi/event"
+	"github.com/vmware/govmomi/vim25/soap"
+	"github.com/vmware/govmomi/vim25/types"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.uber.org/zap"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/metrics"
+)
+
+const (
+	// ReconnectDefaultMinBackoff is the default initial delay between
+	// attempts to restore the vCenter event stream
+	ReconnectDefaultMinBackoff = time.Second
+	// ReconnectDefaultMaxBackoff is the default maximum delay between
+	// attempts to restore the vCenter event stream
+	ReconnectDefaultMaxBackoff = time.Minute
+)
+
+// readError classifies the errors returned when reading vCenter events
+type readError int
+
+const (
+	// readErrorFatal errors cannot be recovered from in-process
+	readErrorFatal readError = iota
+	// readErrorSession is returned when the session expired or was terminated
+	readErrorSession
+	// readErrorTransport is returned when vCenter is unreachable, e.g. while
+	// it restarts
+	readErrorTransport
+	// readErrorCollector is returned when the event collector was destroyed
+	readErrorCollector
+)
+
+func (e readError) String() string {
+	switch e {
+	case readErrorSession:
+		return "session"
+	case readErrorTransport:
+		return "transport"
+	case readErrorCollector:
+		return "collector"
+	default:
+		return "fatal"
+	}
+}
+
+var (
+	reconnectCountM = stats.Int64(
+		"vsphere_reconnect_count",
+		"Number of attempts to restore the vCenter event stream",
+		stats.UnitDimensionless,
+	)
+
+	reasonKey = tag.MustNewKey("reason")
+	resultKey = tag.MustNewKey("result")
+
+	registerMetricsOnce sync.Once
+	registerMetricsErr  error
+)
+
+// registerMetrics registers the views of the adapter metrics. Only the adapter
+// registers them, not every binary linking this package.
+func registerMetrics() error {
+	registerMetricsOnce.Do(func() {
+		registerMetricsErr = view.Register(&view.View{
+			Description: reconnectCountM.Description(),
+			Measure:     reconnectCountM,
+			Aggregation: view.Count(),
+			TagKeys:     []tag.Key{reasonKey, resultKey},
+		})
+	})
+	return registerMetricsErr
+}
+
+// classifyReadError returns whether err returned by the event collector can be
+// recovered from by logging in again or recreating the collector.
+func classifyReadError(err error) readError {
+	var fault interface{}
+	switch {
+	case soap.IsSoapFault(err):
+		fault = soap.ToSoapFault(err).VimFault()
+	case soap.IsVimFault(err):
+		fault = soap.ToVimFault(err)
+	case soap.IsRegularError(err):
+		err = soap.ToRegularError(err)
+	}
+
+	switch fault.(type) {
+	case types.NotAuthenticated, *types.NotAuthenticated:
+		return readErrorSession
+	case types.ManagedObjectNotFound, *types.ManagedObjectNotFound:
+		return readErrorCollector
+	case nil:
+	default:
+		return readErrorFatal
+	}
+
+	var (
+		urlErr *url.Error
+		netErr net.Error
+	)
+	if errors.As(err, &urlErr) || errors.As(err, &netErr) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return readErrorTransport
+	}
+	return readErrorFatal
+}
+
+// reconnect restores the vCenter event stream after a read error of the given
+// kind. A lost session is replaced by a new login, then a new collector reading
+// the events created since begin is returned. Attempts are retried with
+// exponential backoff until ctx is canceled.
+func (a *vAdapter) reconnect(ctx context.Context, kind readError, begin time.Time) (*event.HistoryCollector, error) {
+	logger := logging.FromContext(ctx).With(zap.String("reason", kind.String()))
+
+	bOff := a.reconnectBackoff()
+	for {
+		coll, err := a.restoreCollector(ctx, kind, begin)
+		if err == nil {
+			recordReconnect(ctx, kind, "success")
+			logger.Infow("restored vCenter event stream", zap.Time("beginTimestamp", begin))
+			return coll, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		recordReconnect(ctx, kind, "failure")
+		delay := bOff.Duration()
+		logger.Warnw("could not restore vCenter event stream", zap.Error(err), zap.Duration("backoff", delay))
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// restoreCollector logs in again if the session was lost and creates a new
+// collector for the events created since begin
+func (a *vAdapter) restoreCollector(ctx context.Context, kind readError, begin time.Time) (*event.HistoryCollector, error) {
+	if kind != readErrorCollector {
+		// a transport error does not necessarily end the session
+		session, err := a.VClient.SessionManager.UserSession(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("get session: %w", err)
+		}
+		if session == nil {
+			if err := a.login(ctx); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	coll, err := newHistoryCollector(ctx, a.VClient.Client, begin)
+	if err != nil {
+		return nil, fmt.Errorf("create event collector: %w", err)
+	}
+	return coll, nil
+}
+
+// login creates a new vCenter session
+func (a *vAdapter) login(ctx context.Context) error {
+	if a.Credentials == nil {
+		return errors.New("login: no credentials configured")
+	}
+	user, err := a.Credentials()
+	if err != nil {
+		return fmt.Errorf("read credentials: %w", err)
+	}
+	if err := a.VClient.SessionManager.Login(ctx, user); err != nil {
+		return fmt.Errorf("login: %w", err)
+	}
+	return nil
+}
+
+// reconnectBackoff returns the backoff between attempts to restore the vCenter
+// event stream
+func (a *vAdapter) reconnectBackoff() backoff.Backoff {
+	b := backoff.Backoff{
+		Factor: 2,
+		Jitter: true,
+		Min:    a.ReconnectMinBackoff,
+		Max:    a.ReconnectMaxBackoff,
+	}
+	if b.Min <= 0 {
+		b.Min = ReconnectDefaultMinBackoff
+	}
+	if b.Max <= 0 {
+		b.Max = ReconnectDefaultMaxBackoff
+	}
+	return b
+}
+
+func recordReconnect(ctx context.Context, kind readError, result string) {
+	ctx, err := tag.New(ctx, tag.Insert(reasonKey, kind.String()), tag.Insert(resultKey, result))
+	if err != nil {
+		logging.FromContext(ctx).Warnw("could not tag reconnect metric", zap.Error(err))
+		return
+	}
+	metrics.Record(ctx, reconnectCountM.M(1))
+}
@@ -0,0 +1,234 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vsphere
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/cloudevents/sdk-go/v2/client"
+	cecontext "github.com/cloudevents/sdk-go/v2/context"
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+	"github.com/google/go-cmp/cmp"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/session"
+	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/soap"
+	"github.com/vmware/govmomi/vim25/types"
+	"go.uber.org/zap/zaptest"
+)
+
+func Test_classifyReadError(t *testing.T) {
+	fault := func(f types.AnyType) error {
+		sf := &soap.Fault{}
+		sf.Detail.Fault = f
+		return soap.WrapSoapFault(sf)
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want readError
+	}{{
+		name: "not authenticated",
+		err:  fault(types.NotAuthenticated{}),
+		want: readErrorSession,
+	}, {
+		name: "not authenticated vim fault",
+		err:  soap.WrapVimFault(&types.NotAuthenticated{}),
+		want: readErrorSession,
+	}, {
+		name: "collector destroyed",
+		err:  fault(types.ManagedObjectNotFound{}),
+		want: readErrorCollector,
+	}, {
+		name: "other fault",
+		err:  fault(types.InvalidArgument{}),
+		want: readErrorFatal,
+	}, {
+		name: "connection refused",
+		err:  &url.Error{Op: "Post", URL: "https://vcenter.local/sdk", Err: errors.New("connection refused")},
+		want: readErrorTransport,
+	}, {
+		name: "wrapped transport error",
+		err:  soap.WrapRegularError(io.ErrUnexpectedEOF),
+		want: readErrorTransport,
+	}, {
+		name: "other error",
+		err:  errors.New("unexpected"),
+		want: readErrorFatal,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifyReadError(tt.err); got != tt.want {
+				t.Errorf("classifyReadError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// newReconnectTestAdapter returns an adapter which sends up to 40 events and
+// logs in again with the vcsim credentials
+func newReconnectTestAdapter(t *testing.T, vim *vim25.Client, kv *fakeKVStore) (*vAdapter, *roundTripperTest) {
+	t.Helper()
+
+	roundTripper := &roundTripperTest{statusCodes: createStatusCodes(40, failNever)}
+	p, err := cehttp.New(cehttp.WithRoundTripper(roundTripper))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := client.New(p, client.WithTimeNow(), client.WithUUIDs())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &vAdapter{
+		Logger:   zaptest.NewLogger(t).Sugar(),
+		Source:   source,
+		VClient:  &govmomi.Client{Client: vim, SessionManager: session.NewManager(vim)},
+		CEClient: c,
+		KVStore:  kv,
+		// never checkpoint periodically
+		CpConfig: CheckpointConfig{MaxAge: time.Hour, Period: time.Hour},
+		Credentials: func() (*url.Userinfo, error) {
+			return simulator.DefaultLogin, nil
+		},
+		ReconnectMinBackoff: time.Millisecond,
+		ReconnectMaxBackoff: time.Millisecond,
+	}, roundTripper
+}
+
+// waitForEventKey blocks until the checkpoint in kv holds key
+func waitForEventKey(ctx context.Context, kv *fakeKVStore, key int32) bool {
+	for ctx.Err() == nil {
+		var cp checkpoint
+		if err := kv.Get(ctx, checkpointKey, &cp); err == nil && cp.LastEventKey == key {
+			return true
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return false
+}
+
+func Test_vAdapter_readEventsReconnectsOnSessionLoss(t *testing.T) {
+	simulator.Run(func(ctx context.Context, vim *vim25.Client) error {
+		ctx = cecontext.WithTarget(ctx, "fake.example.com")
+
+		kv := &fakeKVStore{dataChan: make(chan string, 1)}
+		a, roundTripper := newReconnectTestAdapter(t, vim, kv)
+
+		begin := time.Now().UTC().Add(-time.Hour)
+		coll, err := newHistoryCollector(ctx, vim, begin)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+
+		go func() {
+			if !waitForEventKey(ctx, kv, 26) {
+				return
+			}
+			// terminate the session of the adapter, which creates event 27
+			if err := session.NewManager(vim).Logout(ctx); err != nil {
+				t.Errorf("logout: %v", err)
+			}
+			// event 28 is created by the new login of the adapter
+			if waitForEventKey(ctx, kv, 28) {
+				cancel()
+			}
+		}()
+
+		err = a.readEvents(ctx, coll, begin, newDeduplicator(checkpoint{}, maxRecentEventKeys))
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("readEvents() error = %v, want %v", err, context.Canceled)
+		}
+
+		var cp checkpoint
+		if err := json.Unmarshal([]byte(<-kv.dataChan), &cp); err != nil {
+			t.Fatalf("unmarshal data from KV store: %v", err)
+		}
+		if cp.LastEventKey != 28 {
+			t.Errorf("readEvents() checkpointKey = %v, wantEventKey 28", cp.LastEventKey)
+		}
+
+		// events read before the session was lost are not sent again
+		seen := map[string]bool{}
+		for _, e := range roundTripper.events {
+			if seen[e.ID()] {
+				t.Errorf("event %s sent more than once", e.ID())
+			}
+			seen[e.ID()] = true
+		}
+		if len(seen) != 28 {
+			t.Errorf("sent events = %d, want 28", len(seen))
+		}
+		return nil
+	})
+}
+
+func Test_vAdapter_readEventsRecreatesCollector(t *testing.T) {
+	simulator.Run(func(ctx context.Context, vim *vim25.Client) error {
+		ctx = cecontext.WithTarget(ctx, "fake.example.com")
+
+		kv := &fakeKVStore{dataChan: make(chan string, 1)}
+		a, roundTripper := newReconnectTestAdapter(t, vim, kv)
+
+		begin := time.Now().UTC().Add(-time.Hour)
+		coll, err := newHistoryCollector(ctx, vim, begin)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := coll.Destroy(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+
+		go func() {
+			if waitForEventKey(ctx, kv, 26) {
+				cancel()
+			}
+		}()
+
+		err = a.readEvents(ctx, coll, begin, newDeduplicator(checkpoint{}, maxRecentEventKeys))
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("readEvents() error = %v, want %v", err, context.Canceled)
+		}
+
+		var gotSent []string
+		for _, e := range roundTripper.events {
+			gotSent = append(gotSent, e.ID())
+		}
+		var wantSent []string
+		for i := 1; i <= 26; i++ {
+			wantSent = append(wantSent, fmt.Sprint(i))
+		}
+		if diff := cmp.Diff(wantSent, gotSent); diff != "" {
+			t.Errorf("sent events (-want, +got) = %s", diff)
+		}
+		return nil
+	})
+}
+
+func Test_registerMetrics(t *testing.T) {
+	// NewAdapter may run more than once in a process, e.g. in tests
+	for i := 0; i < 2; i++ {
+		if err := registerMetrics(); err != nil {
+			t.Fatalf("registerMetrics() = %v", err)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vsphere
+
+import (
+	"context"
+	"time"
+
+	"knative.dev/pkg/logging"
+)
+
+// detachedContext carries the values of its parent but not its deadline and
+// cancelation.
+type detachedContext struct {
+	context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}       { return nil }
+func (detachedContext) Err() error                  { return nil }
+
+// gracePeriod returns the configured shutdown grace period or
+// ShutdownDefaultGracePeriod.
+func (a *vAdapter) gracePeriod() time.Duration {
+	if a.ShutdownGracePeriod <= 0 {
+		return ShutdownDefaultGracePeriod
+	}
+	return a.ShutdownGracePeriod
+}
+
+// shutdownDeadline returns the time the shutdown of the adapter has to be
+// complete by, the grace period after the shutdown started. The deadlines of
+// all shutdown phases are derived from it, so that the adapter exits before
+// the kubelet kills it, see ShutdownTerminationMargin. The first call starts
+// the shutdown.
+func (a *vAdapter) shutdownDeadline() time.Time {
+	a.shutdownMu.Lock()
+	defer a.shutdownMu.Unlock()
+
+	if a.shutdownAt.IsZero() {
+		a.shutdownAt = time.Now().Add(a.gracePeriod())
+	}
+	return a.shutdownAt
+}
+
+// sendDeadline returns the time in-flight sends are abandoned during shutdown.
+// The last quarter of the grace period is left to save the checkpoint, report
+// the delivery status and log out.
+func (a *vAdapter) sendDeadline() time.Time {
+	return a.shutdownDeadline().Add(-a.gracePeriod() / 4)
+}
+
+// shutdownContext returns a context, carrying the values but not the
+// cancelation of ctx, to save the checkpoint, report the delivery status and
+// log out until the shutdown deadline.
+func (a *vAdapter) shutdownContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithDeadline(detachedContext{ctx}, a.shutdownDeadline())
+}
+
+// withShutdownDeadline returns a context which is canceled at the time
+// returned by deadline once parent was canceled, or when the returned
+// CancelFunc is called.
+func withShutdownDeadline(parent context.Context, deadline func() time.Time) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(detachedContext{parent})
+
+	go func() {
+		select {
+		case <-parent.Done():
+		case <-ctx.Done():
+			return
+		}
+
+		t := time.NewTimer(time.Until(deadline()))
+		defer t.Stop()
+		select {
+		case <-t.C:
+			logging.FromContext(ctx).Warn("abandoning in-flight sends: shutdown deadline exceeded")
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	return ctx, cancel
+}
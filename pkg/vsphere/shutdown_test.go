@@ -0,0 +1,69 @@
+/*
+Copyright 2022 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vsphere
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type ctxKey struct{}
+
+func Test_withShutdownDeadline(t *testing.T) {
+	parent, cancelParent := context.WithCancel(context.WithValue(context.Background(), ctxKey{}, "value"))
+
+	var deadline time.Time
+	ctx, cancel := withShutdownDeadline(parent, func() time.Time {
+		if deadline.IsZero() {
+			deadline = time.Now().Add(50 * time.Millisecond)
+		}
+		return deadline
+	})
+	defer cancel()
+
+	if got := ctx.Value(ctxKey{}); got != "value" {
+		t.Errorf("Value() = %v, want %q", got, "value")
+	}
+
+	cancelParent()
+	select {
+	case <-ctx.Done():
+		t.Fatal("context canceled before deadline")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("context not canceled after deadline")
+	}
+}
+
+func Test_vAdapter_shutdownContext(t *testing.T) {
+	a := &vAdapter{ShutdownGracePeriod: 40 * time.Second}
+
+	deadline := a.shutdownDeadline()
+	if until := time.Until(deadline); until > 40*time.Second || until < 39*time.Second {
+		t.Errorf("shutdownDeadline() in %v, want 40s", until)
+	}
+	if got := a.sendDeadline(); !got.Equal(deadline.Add(-10 * time.Second)) {
+		t.Errorf("sendDeadline() = %v, want 10s before %v", got, deadline)
+	}
+
+	parent, cancelParent := context.WithCancel(context.Background())
+	cancelParent()
+
+	ctx, cancel := a.shutdownContext(parent)
+	defer cancel()
+	if ctx.Err() != nil {
+		t.Errorf("shutdownContext() error = %v, want nil", ctx.Err())
+	}
+	// all shutdown phases share the deadline of the first one
+	if got, ok := ctx.Deadline(); !ok || !got.Equal(deadline) {
+		t.Errorf("shutdownContext() deadline = %v, want %v", got, deadline)
+	}
+}
@@ -13,9 +13,17 @@ import (
 	"github.com/vmware/govmomi/event"
 	"github.com/vmware/govmomi/vim25"
 	"github.com/vmware/govmomi/vim25/types"
+
+	"github.com/vmware-tanzu/sources-for-knative/pkg/vsphere/events"
 )
 
 func newHistoryCollector(ctx context.Context, client *vim25.Client, begin time.Time) (*event.HistoryCollector, error) {
+	return newRangeHistoryCollector(ctx, client, begin, time.Time{})
+}
+
+// newRangeHistoryCollector returns a collector for the events created between
+// begin and end, a zero begin or end does not bound the collected events.
+func newRangeHistoryCollector(ctx context.Context, client *vim25.Client, begin, end time.Time) (*event.HistoryCollector, error) {
 	mgr := event.NewManager(client)
 	root := client.ServiceContent.RootFolder
 
@@ -25,9 +33,16 @@ func newHistoryCollector(ctx context.Context, client *vim25.Client, begin time.T
 			Entity:    root,
 			Recursion: types.EventFilterSpecRecursionOptionAll,
 		},
-		Time: &types.EventFilterSpecByTime{
-			BeginTime: types.NewTime(begin),
-		},
+	}
+
+	if !begin.IsZero() || !end.IsZero() {
+		filter.Time = &types.EventFilterSpecByTime{}
+	}
+	if !begin.IsZero() {
+		filter.Time.BeginTime = types.NewTime(begin)
+	}
+	if !end.IsZero() {
+		filter.Time.EndTime = types.NewTime(end)
 	}
 
 	return mgr.CreateCollectorForEvents(ctx, filter)
@@ -52,14 +67,14 @@ func getEventDetails(event types.BaseEvent) eventDetails {
 
 	switch e := event.(type) {
 	case *types.EventEx:
-		details.Class = "eventex"
+		details.Class = events.ClassEventEx
 		details.Type = e.EventTypeId
 	case *types.ExtendedEvent:
-		details.Class = "extendedevent"
+		details.Class = events.ClassExtendedEvent
 		details.Type = e.EventTypeId
 	default:
 		t := reflect.TypeOf(event).Elem().Name()
-		details.Class = "event"
+		details.Class = events.ClassEvent
 		details.Type = t
 	}
 
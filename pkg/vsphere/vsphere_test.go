@@ -58,3 +58,85 @@ func Test_getEventDetails(t *testing.T) {
 		})
 	}
 }
+
+func Test_getEventEntity(t *testing.T) {
+	vm := types.ManagedObjectReference{Type: "VirtualMachine", Value: "vm-42"}
+	host := types.ManagedObjectReference{Type: "HostSystem", Value: "host-7"}
+
+	tests := []struct {
+		name     string
+		event    types.BaseEvent
+		wantID   string
+		wantType string
+	}{
+		{
+			name: "VmPoweredOnEvent",
+			event: &types.VmPoweredOnEvent{VmEvent: types.VmEvent{Event: types.Event{
+				Vm:   &types.VmEventArgument{Vm: vm},
+				Host: &types.HostEventArgument{Host: host},
+			}}},
+			wantID:   "vm-42",
+			wantType: "VirtualMachine",
+		},
+		{
+			name: "HostConnectedEvent",
+			event: &types.HostConnectedEvent{HostEvent: types.HostEvent{Event: types.Event{
+				Host: &types.HostEventArgument{Host: host},
+			}}},
+			wantID:   "host-7",
+			wantType: "HostSystem",
+		},
+		{
+			name: "EventEx",
+			event: &types.EventEx{
+				ObjectId:   "datastore-3",
+				ObjectType: "Datastore",
+				Event:      types.Event{Vm: &types.VmEventArgument{Vm: vm}},
+			},
+			wantID:   "datastore-3",
+			wantType: "Datastore",
+		},
+		{
+			name:     "ExtendedEvent",
+			event:    &types.ExtendedEvent{ManagedObject: host},
+			wantID:   "host-7",
+			wantType: "HostSystem",
+		},
+		{
+			name:  "UserLoginSessionEvent",
+			event: &types.UserLoginSessionEvent{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotID, gotType := getEventEntity(tt.event)
+			if gotID != tt.wantID || gotType != tt.wantType {
+				t.Errorf("getEventEntity() = %q, %q, want %q, %q", gotID, gotType, tt.wantID, tt.wantType)
+			}
+		})
+	}
+}
+
+func Test_newMapper(t *testing.T) {
+	about := types.AboutInfo{InstanceUuid: "dbed6e0c-bd88-4ef6-b594-21283e1c677f"}
+
+	_, gotSource, err := newMapper("", "https://vcenter.local/sdk", about)
+	if err != nil {
+		t.Fatalf("newMapper() error = %v", err)
+	}
+	if want := "https://vcenter.local/sdk"; gotSource != want {
+		t.Errorf("newMapper() source = %q, want %q", gotSource, want)
+	}
+
+	_, gotSource, err = newMapper(`{"source":"urn:vcenter:{{ .InstanceUUID }}"}`, "https://vcenter.local/sdk", about)
+	if err != nil {
+		t.Fatalf("newMapper() error = %v", err)
+	}
+	if want := "urn:vcenter:dbed6e0c-bd88-4ef6-b594-21283e1c677f"; gotSource != want {
+		t.Errorf("newMapper() source = %q, want %q", gotSource, want)
+	}
+
+	if _, _, err := newMapper(`{"type":"{{ .Type"}`, "https://vcenter.local/sdk", about); err == nil {
+		t.Error("newMapper() error = nil, want error for invalid template")
+	}
+}
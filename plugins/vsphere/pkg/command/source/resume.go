@@ -0,0 +1,48 @@
+/*
+Copyright 2020 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package source
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/sources-for-knative/plugins/vsphere/pkg"
+)
+
+func NewSourceResumeCommand(clients *pkg.Clients, opts *Options) *cobra.Command {
+	result := cobra.Command{
+		Use:   "resume",
+		Short: "Resume a suspended vSphere source",
+		Long:  "Resume a suspended vSphere source, replaying the events since its checkpoint according to its checkpoint configuration.",
+		Example: `# Resume the source in the default namespace
+kn vsphere source resume --name vc-01-source
+
+# Resume the source in the specified namespace
+kn vsphere source resume --namespace ns --name vc-01-source
+`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if opts.Name == "" {
+				return fmt.Errorf("'name' requires a nonempty name provided with the --name option")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := setSuspend(cmd, clients, opts, false); err != nil {
+				return fmt.Errorf("failed to resume source: %v", err)
+			}
+
+			fmt.Fprintln(cmd.OutOrStdout(), "Resumed source")
+			return nil
+		},
+	}
+
+	flags := result.Flags()
+	flags.StringVar(&opts.Name, "name", "", "name of the source to resume")
+	_ = result.MarkFlagRequired("name")
+
+	return &result
+}
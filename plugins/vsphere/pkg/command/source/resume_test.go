@@ -0,0 +1,79 @@
+/*
+Copyright 2020 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package source_test
+
+import (
+	"fmt"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/sources-for-knative/pkg/apis/sources/v1alpha1"
+	"github.com/vmware-tanzu/sources-for-knative/plugins/vsphere/pkg"
+	"github.com/vmware-tanzu/sources-for-knative/plugins/vsphere/pkg/command"
+	"github.com/vmware-tanzu/sources-for-knative/plugins/vsphere/pkg/command/source"
+)
+
+func TestNewSourceResumeCommand(t *testing.T) {
+	const (
+		sourceName    = "spring"
+		secretRef     = "street-creds"
+		sourceAddress = "https://my-vsphere-endpoint.example.com"
+		sinkURI       = "https://sink.example.com"
+	)
+
+	t.Run("defines basic metadata", func(t *testing.T) {
+		cmd := source.NewSourceResumeCommand(&pkg.Clients{}, &source.Options{})
+
+		assert.Equal(t, cmd.Use, "resume")
+		assert.Check(t, len(cmd.Short) > 0,
+			"command should have a nonempty short description")
+		assert.Check(t, len(cmd.Long) > 0,
+			"command should have a nonempty long description")
+		command.CheckFlag(t, cmd, "name")
+		assert.Assert(t, cmd.RunE != nil)
+	})
+
+	t.Run("fails to execute with an empty name", func(t *testing.T) {
+		cmd, _ := sourceTestCommand(command.RegularClientConfig())
+		cmd.SetArgs([]string{
+			"resume",
+		})
+
+		err := cmd.Execute()
+		assert.ErrorContains(t, err, "requires a nonempty name provided with the --name option")
+	})
+
+	t.Run("resumes suspended source", func(t *testing.T) {
+		existingSource := newSource(t, command.DefaultNamespace, sourceName, sourceAddress, secretRef, sinkURI)
+		existingSource.(*v1alpha1.VSphereSource).Spec.Suspend = true
+		cmd, client := sourceTestCommand(command.RegularClientConfig(), existingSource)
+		cmd.SetArgs([]string{
+			"resume",
+			"--name", sourceName,
+		})
+
+		err := cmd.Execute()
+		assert.NilError(t, err)
+
+		src, err := client.SourcesV1alpha1().VSphereSources(command.DefaultNamespace).Get(cmd.Context(), sourceName, metav1.GetOptions{})
+		assert.NilError(t, err)
+		assert.Check(t, !src.Spec.Suspend, "source should not be suspended")
+		assert.Equal(t, src.Spec.VAuthSpec.SecretRef.Name, secretRef)
+	})
+
+	t.Run("fails to execute when the source does not exist", func(t *testing.T) {
+		cmd, _ := sourceTestCommand(command.RegularClientConfig())
+		cmd.SetArgs([]string{
+			"resume",
+			"--name", sourceName,
+		})
+
+		err := cmd.Execute()
+		assert.ErrorContains(t, err, fmt.Sprintf("vspheresources.sources.tanzu.vmware.com %q not found", sourceName))
+	})
+}
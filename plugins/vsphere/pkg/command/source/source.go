@@ -74,6 +74,8 @@ func NewSourceCommand(clients *pkg.Clients) *cobra.Command {
 	result.AddCommand(NewSourceCreateCommand(clients, &options))
 	result.AddCommand(NewSourceDeleteCommand(clients, &options))
 	result.AddCommand(NewSourceListCommand(clients, &options))
+	result.AddCommand(NewSourceSuspendCommand(clients, &options))
+	result.AddCommand(NewSourceResumeCommand(clients, &options))
 
 	return &result
 }
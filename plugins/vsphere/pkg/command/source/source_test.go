@@ -33,10 +33,12 @@ func TestNewSourceCommand(t *testing.T) {
 			"command should have a nonempty long description")
 		command.CheckFlag(t, cmd, "namespace")
 
-		assert.Check(t, len(cmd.Commands()) == 3, "unexpected number of subcommands")
+		assert.Check(t, len(cmd.Commands()) == 5, "unexpected number of subcommands")
 		assert.Check(t, command.HasLeafCommand(cmd, "create"), "command should have subcommand create")
 		assert.Check(t, command.HasLeafCommand(cmd, "delete"), "command should have subcommand delete")
 		assert.Check(t, command.HasLeafCommand(cmd, "list"), "command should have subcommand delete")
+		assert.Check(t, command.HasLeafCommand(cmd, "suspend"), "command should have subcommand suspend")
+		assert.Check(t, command.HasLeafCommand(cmd, "resume"), "command should have subcommand resume")
 	})
 }
 
@@ -0,0 +1,74 @@
+/*
+Copyright 2020 VMware, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package source
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/vmware-tanzu/sources-for-knative/plugins/vsphere/pkg"
+)
+
+func NewSourceSuspendCommand(clients *pkg.Clients, opts *Options) *cobra.Command {
+	result := cobra.Command{
+		Use:   "suspend",
+		Short: "Suspend a vSphere source",
+		Long:  "Suspend a vSphere source, stopping its adapter until it is resumed. The checkpoint of the source is kept.",
+		Example: `# Suspend the source in the default namespace
+kn vsphere source suspend --name vc-01-source
+
+# Suspend the source in the specified namespace
+kn vsphere source suspend --namespace ns --name vc-01-source
+`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if opts.Name == "" {
+				return fmt.Errorf("'name' requires a nonempty name provided with the --name option")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := setSuspend(cmd, clients, opts, true); err != nil {
+				return fmt.Errorf("failed to suspend source: %v", err)
+			}
+
+			fmt.Fprintln(cmd.OutOrStdout(), "Suspended source")
+			return nil
+		},
+	}
+
+	flags := result.Flags()
+	flags.StringVar(&opts.Name, "name", "", "name of the source to suspend")
+	_ = result.MarkFlagRequired("name")
+
+	return &result
+}
+
+// setSuspend patches spec.suspend of the source named in opts
+func setSuspend(cmd *cobra.Command, clients *pkg.Clients, opts *Options, suspend bool) error {
+	namespace, err := clients.GetExplicitOrDefaultNamespace(opts.Namespace)
+	if err != nil {
+		return fmt.Errorf("failed to get namespace: %v", err)
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"suspend": suspend,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = clients.VSphereClientSet.
+		SourcesV1alpha1().
+		VSphereSources(namespace).
+		Patch(cmd.Context(), opts.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
@@ -11,6 +11,7 @@ import (
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 	"github.com/vmware-tanzu/sources-for-knative/pkg/vsphere"
+	"github.com/vmware-tanzu/sources-for-knative/pkg/vsphere/events"
 	"github.com/vmware/govmomi/vapi/tags"
 	"github.com/vmware/govmomi/vim25/types"
 )
@@ -35,16 +36,16 @@ func main() {
 	defer client.Logout(context.Background()) // using fresh context to avoid canceled err
 
 	r := &receiver{manager: tags.NewManager(client)}
-	if err := ceclient.StartReceiver(ctx, r.handle); err != nil {
+
+	// Decodes XML and JSON payloads into the concrete vSphere event.
+	h := events.NewHandler()
+	h.OnVmCreated(r.handle)
+	if err := ceclient.StartReceiver(ctx, h.Handle); err != nil {
 		log.Fatal(err)
 	}
 }
 
-func (r *receiver) handle(ctx context.Context, event cloudevents.Event) error {
-	req := &types.VmCreatedEvent{}
-	if err := event.DataAs(&req); err != nil {
-		return err
-	}
-	log.Printf("Tagging VM: %v", req.Vm.Vm)
-	return r.manager.AttachTag(ctx, "shrug", req.Vm.Vm)
+func (r *receiver) handle(ctx context.Context, event *types.VmCreatedEvent) error {
+	log.Printf("Tagging VM: %v", event.Vm.Vm)
+	return r.manager.AttachTag(ctx, "shrug", event.Vm.Vm)
 }